@@ -0,0 +1,451 @@
+package main
+
+import (
+	"bufio"
+	"cards-service/internal/adapters/audit"
+	"cards-service/internal/adapters/health"
+	"cards-service/internal/adapters/keys"
+	"cards-service/internal/adapters/wallet"
+	"cards-service/internal/config"
+	"cards-service/internal/core/app"
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mwinyimoha/commons/pkg/logging"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// commands are the subcommands of the binary. Without one it starts the server.
+var commands = map[string]func(args []string) int{
+	"config":          configCommand,
+	"healthcheck":     healthcheckCommand,
+	"rotate-keys":     rotateKeysCommand,
+	"fpe":             fpeCommand,
+	"fingerprint-key": fingerprintKeyCommand,
+}
+
+// configCommand prints the effective configuration with secrets redacted. It accepts the
+// same flags as the server so overrides can be checked before starting it.
+func configCommand(args []string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "usage: cards-service config print [flags]")
+		return 2
+	}
+
+	cfg, err := config.New(validator.New(), args[1:]...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not load configuration:", err)
+		return 1
+	}
+
+	out, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not encode configuration:", err)
+		return 1
+	}
+
+	fmt.Println(string(out))
+	return 0
+}
+
+// healthcheckCommand probes the local server's gRPC health service and exits 0 when the
+// service is serving and 1 otherwise, so it can be used as a container HEALTHCHECK in an
+// image that has nothing but the binary. With TLS the server certificate is verified unless
+// --tls-skip-verify is given, and servers that require client certificates need --tls-cert.
+// A HEALTHCHECK cannot pass flags that depend on the deployment, so the TLS flags default to
+// HEALTHCHECK_TLS_CA, HEALTHCHECK_TLS_SERVER_NAME, HEALTHCHECK_TLS_CERT and HEALTHCHECK_TLS_KEY.
+func healthcheckCommand(args []string) int {
+	port := os.Getenv("SERVER_PORT")
+	if port == "" {
+		port = "8080"
+	}
+
+	flags := pflag.NewFlagSet("healthcheck", pflag.ContinueOnError)
+	addr := flags.String("addr", "127.0.0.1:"+port, "gRPC address to probe")
+	socket := flags.String("socket", os.Getenv("SERVER_SOCKET"), "unix socket to probe instead of addr")
+	service := flags.String("service", "", "service to check; empty checks the server as a whole")
+	timeout := flags.Duration("timeout", 3*time.Second, "time allowed for the probe")
+	useTLS := flags.Bool("tls", os.Getenv("TLS_ENABLED") == "true", "connect with TLS")
+	skipVerify := flags.Bool("tls-skip-verify", false, "skip verification of the server certificate")
+	serverName := flags.String("tls-server-name", os.Getenv("HEALTHCHECK_TLS_SERVER_NAME"), "name the server certificate is verified against; the host of addr when empty")
+	caFile := flags.String("tls-ca", os.Getenv("HEALTHCHECK_TLS_CA"), "CA certificates the server certificate is verified with; the system pool when empty")
+	certFile := flags.String("tls-cert", os.Getenv("HEALTHCHECK_TLS_CERT"), "client certificate, for servers that require one")
+	keyFile := flags.String("tls-key", os.Getenv("HEALTHCHECK_TLS_KEY"), "key of the client certificate")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if *useTLS && *certFile == "" && os.Getenv("TLS_CLIENT_AUTH") == "require" {
+		fmt.Fprintln(os.Stderr, "the server requires a client certificate (TLS_CLIENT_AUTH=require); pass --tls-cert and --tls-key")
+		return 2
+	}
+
+	// The image has no system CA pool, so without a CA nothing could verify the server.
+	if *useTLS && *caFile == "" && !*skipVerify && !hasSystemRoots() {
+		fmt.Fprintln(os.Stderr, "there are no system CA certificates to verify the server with; pass --tls-ca or set HEALTHCHECK_TLS_CA")
+		return 2
+	}
+
+	target := *addr
+	if *socket != "" {
+		target = "unix://" + *socket
+	}
+
+	creds := insecure.NewCredentials()
+	if *useTLS {
+		tlsConfig, err := probeTLSConfig(*serverName, *caFile, *certFile, *keyFile, *skipVerify)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "could not configure TLS:", err)
+			return 2
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	st, err := health.Probe(ctx, target, *service, creds)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "health check failed:", err)
+		return 1
+	}
+
+	if st != healthpb.HealthCheckResponse_SERVING {
+		fmt.Fprintln(os.Stderr, "service is", st)
+		return 1
+	}
+
+	return 0
+}
+
+func hasSystemRoots() bool {
+	pool, err := x509.SystemCertPool()
+	return err == nil && !pool.Equal(x509.NewCertPool())
+}
+
+// probeTLSConfig describes how the health probe connects to a TLS server. The probe runs
+// next to the server, which typically has a certificate issued for its public name, hence
+// serverName.
+func probeTLSConfig(serverName, caFile, certFile, keyFile string, skipVerify bool) (*tls.Config, error) {
+	config := &tls.Config{ServerName: serverName, InsecureSkipVerify: skipVerify, MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", caFile)
+		}
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// openCLIAudit opens the audit trail of the command-line tools. It is kept apart from the
+// server's, whose chain a second writer would fork, and locked while the command runs.
+func openCLIAudit(cfg *config.Config) (*audit.FileSink, error) {
+	logger, err := logging.NewLoggerConfig().BuildLogger()
+	if err != nil {
+		return nil, err
+	}
+
+	return audit.NewExclusiveFileSink(cfg.AuditCLIFile, int64(cfg.AuditMaxSizeMB)<<20, logger)
+}
+
+// rotateKeysCommand adds a key version to the keyring and rewraps the data keys of the saved
+// card numbers with it. The card numbers themselves are not encrypted again. Running servers
+// keep decrypting with the earlier versions and pick the new one up on their own, so keys
+// can be rotated without downtime. It accepts the same flags as the server.
+func rotateKeysCommand(args []string) int {
+	cfg, err := config.New(validator.New(), args...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not load configuration:", err)
+		return 1
+	}
+
+	if cfg.KeyManager != "local" {
+		fmt.Fprintln(os.Stderr, "rotate-keys needs KEY_MANAGER=local")
+		return 2
+	}
+
+	keyring, err := keys.NewLocalKeyManager(cfg.KeyringFile, cfg.KeyringPassphrase)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not open keyring:", err)
+		return 1
+	}
+
+	var manager interface {
+		ports.KeyManager
+		ports.KeyRotator
+	} = keyring
+
+	// Like the server, the command records its use of the keys when there is an audit trail.
+	if cfg.AuditEnabled {
+		auditSink, err := openCLIAudit(cfg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "could not open audit log:", err)
+			return 1
+		}
+		defer auditSink.Close()
+
+		manager = app.NewAuditedKeys(keyring, auditSink)
+	}
+
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{Method: "rotate-keys"})
+
+	version, err := manager.Rotate(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not rotate keys:", err)
+		return 1
+	}
+	fmt.Printf("key version %d is current\n", version)
+
+	// Card numbers kept in memory are gone with the server, so only SQLite stores are rewrapped.
+	if !cfg.WalletEnabled || cfg.WalletStore != "sqlite" {
+		return 0
+	}
+
+	store, err := wallet.NewSQLiteRepository(cfg.WalletDB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not open wallet store:", err)
+		return 1
+	}
+	defer store.Close()
+
+	n, err := app.RewrapEnvelopes(ctx, manager, domain.KeyPurposeWalletPAN, store, 100)
+	fmt.Printf("rewrapped %d card numbers\n", n)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not rewrap card numbers:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// fpeCommand encrypts or decrypts the card numbers read from standard input, one per line,
+// with the key of the FPE service, writing the results to standard output in the same order.
+// It is meant for migrating stored card numbers. keygen prints a new key instead: an envelope
+// for FPE_KEY_ENVELOPE when there is a key manager, otherwise hex for FPE_KEY. Besides its
+// own flags it accepts the same flags as the server.
+func fpeCommand(args []string) int {
+	if len(args) == 0 || (args[0] != "encrypt" && args[0] != "decrypt" && args[0] != "keygen") {
+		fmt.Fprintln(os.Stderr, "usage: cards-service fpe encrypt|decrypt|keygen [--algorithm ff1|ff3-1] [--tweak hex] [flags]")
+		return 2
+	}
+
+	flags := pflag.NewFlagSet("fpe", pflag.ContinueOnError)
+	algorithm := flags.String("algorithm", domain.FPEFF1, "ff1 or ff3-1")
+	tweakHex := flags.String("tweak", "", "tweak in hex, the same for every card number")
+
+	own, rest := splitFlags(args[1:], "algorithm", "tweak")
+	if err := flags.Parse(own); err != nil {
+		return 2
+	}
+
+	tweak, err := hex.DecodeString(*tweakHex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid tweak:", err)
+		return 2
+	}
+
+	cfg, err := config.New(validator.New(), rest...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not load configuration:", err)
+		return 1
+	}
+
+	var manager ports.KeyManager
+	if cfg.KeyManager == "local" {
+		keyring, err := keys.NewLocalKeyManager(cfg.KeyringFile, cfg.KeyringPassphrase)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "could not open keyring:", err)
+			return 1
+		}
+		manager = keyring
+
+		if cfg.AuditEnabled {
+			auditSink, err := openCLIAudit(cfg)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "could not open audit log:", err)
+				return 1
+			}
+			defer auditSink.Close()
+
+			manager = app.NewAuditedKeys(keyring, auditSink)
+		}
+	}
+
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{Method: "fpe " + args[0]})
+
+	if args[0] == "keygen" {
+		return fpeKeygen(ctx, manager)
+	}
+
+	if cfg.FPEKey == "" && cfg.FPEKeyEnvelope == "" {
+		fmt.Fprintln(os.Stderr, "fpe needs FPE_KEY or FPE_KEY_ENVELOPE")
+		return 2
+	}
+
+	ciphers, err := newPANCiphers(ctx, cfg, manager)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not load FPE key:", err)
+		return 1
+	}
+
+	panCipher := app.NewPANCipher(ciphers)
+	convert := panCipher.EncryptPAN
+	if args[0] == "decrypt" {
+		convert = panCipher.DecryptPAN
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	in := bufio.NewScanner(os.Stdin)
+	for line := 1; in.Scan(); line++ {
+		result, err := convert(ctx, *algorithm, strings.TrimSpace(in.Text()), tweak)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", line, err)
+			return 1
+		}
+		fmt.Fprintln(out, result)
+	}
+	if err := in.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, "could not read card numbers:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// splitFlags separates the flags called names, which all take a value, from the other
+// arguments, so a command can have flags of its own next to those of the configuration.
+func splitFlags(args []string, names ...string) (own, rest []string) {
+	for i := 0; i < len(args); i++ {
+		name, _, inline := strings.Cut(strings.TrimPrefix(args[i], "--"), "=")
+		if !strings.HasPrefix(args[i], "--") || !slices.Contains(names, name) {
+			rest = append(rest, args[i])
+			continue
+		}
+
+		own = append(own, args[i])
+		if !inline && i+1 < len(args) {
+			i++
+			own = append(own, args[i])
+		}
+	}
+
+	return own, rest
+}
+
+// fpeKeygen prints a new 256-bit FPE key, sealed by manager when there is one.
+func fpeKeygen(ctx context.Context, manager ports.KeyManager) int {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		fmt.Fprintln(os.Stderr, "could not generate key:", err)
+		return 1
+	}
+
+	if manager == nil {
+		fmt.Println(hex.EncodeToString(key))
+		return 0
+	}
+
+	envelope, err := manager.Encrypt(ctx, domain.KeyPurposeFPEKey, key, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not seal key:", err)
+		return 1
+	}
+
+	fmt.Println(envelope)
+	return 0
+}
+
+// fingerprintKeyCommand seals FINGERPRINT_KEY, or the key read from standard input when it
+// is not set, with the key manager and prints the envelope, for FINGERPRINT_KEY_ENVELOPE.
+// The key itself is unchanged, so fingerprints already stored keep matching. It accepts the
+// same flags as the server.
+func fingerprintKeyCommand(args []string) int {
+	if len(args) == 0 || args[0] != "seal" {
+		fmt.Fprintln(os.Stderr, "usage: cards-service fingerprint-key seal [flags] [< key]")
+		return 2
+	}
+
+	cfg, err := config.New(validator.New(), args[1:]...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not load configuration:", err)
+		return 1
+	}
+
+	if cfg.KeyManager != "local" {
+		fmt.Fprintln(os.Stderr, "fingerprint-key needs KEY_MANAGER=local")
+		return 2
+	}
+
+	key := cfg.FingerprintKey
+	if key == "" {
+		in := bufio.NewScanner(os.Stdin)
+		in.Scan()
+		key = strings.TrimSpace(in.Text())
+	}
+	if len(key) < 32 {
+		fmt.Fprintln(os.Stderr, "fingerprint keys are at least 32 characters")
+		return 2
+	}
+
+	keyring, err := keys.NewLocalKeyManager(cfg.KeyringFile, cfg.KeyringPassphrase)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not open keyring:", err)
+		return 1
+	}
+
+	var manager ports.KeyManager = keyring
+	if cfg.AuditEnabled {
+		auditSink, err := openCLIAudit(cfg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "could not open audit log:", err)
+			return 1
+		}
+		defer auditSink.Close()
+
+		manager = app.NewAuditedKeys(keyring, auditSink)
+	}
+
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{Method: "fingerprint-key seal"})
+
+	envelope, err := manager.Encrypt(ctx, domain.KeyPurposeFingerprintKey, []byte(key), nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not seal key:", err)
+		return 1
+	}
+
+	fmt.Println(envelope)
+	return 0
+}
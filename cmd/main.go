@@ -1,26 +1,58 @@
 package main
 
 import (
+	"cards-service/internal/adapters/admin"
 	"cards-service/internal/adapters/api"
+	"cards-service/internal/adapters/audit"
+	"cards-service/internal/adapters/bin"
+	"cards-service/internal/adapters/binrules"
+	"cards-service/internal/adapters/cardrules"
+	"cards-service/internal/adapters/certs"
+	"cards-service/internal/adapters/events"
+	"cards-service/internal/adapters/fpe"
+	"cards-service/internal/adapters/health"
+	"cards-service/internal/adapters/keys"
+	"cards-service/internal/adapters/metrics"
+	"cards-service/internal/adapters/oidc"
+	"cards-service/internal/adapters/ratelimit"
+	"cards-service/internal/adapters/redact"
+	"cards-service/internal/adapters/tracing"
+	"cards-service/internal/adapters/velocity"
+	"cards-service/internal/adapters/wallet"
 	"cards-service/internal/config"
 	"cards-service/internal/core/app"
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"buf.build/go/protovalidate"
 	"github.com/go-playground/validator/v10"
+	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	grpclogging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	reqvalidator "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/protovalidate"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	"github.com/mwinyimoha/commons/pkg/logging"
 	"github.com/mwinyimoha/protos/gen/go/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
@@ -28,78 +60,761 @@ import (
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	logger, err := logging.NewLoggerConfig().BuildLogger()
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			os.Exit(command(os.Args[2:]))
+		}
+	}
+
+	logLevel := zap.NewAtomicLevel()
+	loggerConfig := logging.NewLoggerConfig()
+	loggerConfig.Config.Level = logLevel
+
+	baseLogger, err := loggerConfig.BuildLogger()
 	if err != nil {
 		log.Fatal("could not initialize logging:", err)
 	}
 
-	defer func() { _ = logger.Sync() }()
+	defer func() { _ = baseLogger.Sync() }()
+
+	logger := baseLogger.WithOptions(redact.WrapCore())
 
 	val := validator.New()
-	cfg, err := config.New(val)
+	cfg, err := config.New(val, os.Args[1:]...)
 	if err != nil {
 		logger.Fatal("could not initialize configuration", zap.Error(err))
 	}
 
-	svc := app.NewService(val)
+	if !cfg.LogRedactPANs {
+		logger = baseLogger
+	}
+
+	reloader := config.NewReloader(cfg, val, os.Args[1:], logger)
+	// A level set through the admin server is kept across reloads that leave LOG_LEVEL and
+	// DEBUG as they were.
+	configuredLevel := cfg.EffectiveLogLevel()
+	setLogLevel(logLevel, configuredLevel)
+	reloader.Subscribe(func(cfg *config.Config) {
+		if level := cfg.EffectiveLogLevel(); level != configuredLevel {
+			configuredLevel = level
+			setLogLevel(logLevel, level)
+		}
+	})
+
+	shutdownTracing := func(context.Context) error { return nil }
+	if cfg.TracingEnabled {
+		shutdownTracing, err = tracing.NewProvider(context.Background(), tracing.Config{
+			ServiceName:    cfg.ServiceName,
+			ServiceVersion: cfg.ServiceVersion,
+			Exporter:       cfg.TracingExporter,
+			OTLPEndpoint:   cfg.TracingOTLPEndpoint,
+			OTLPInsecure:   cfg.TracingOTLPInsecure,
+			FilePath:       cfg.TracingFile,
+			SampleRatio:    cfg.TracingSampleRatio,
+		})
+		if err != nil {
+			logger.Fatal("could not initialize tracing", zap.Error(err))
+		}
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	srvMetrics := grpcprom.NewServerMetrics(grpcprom.WithServerHandlingTimeHistogram())
+	registry.MustRegister(srvMetrics)
+
+	recorder := metrics.NewRecorder(registry)
+
+	binTable, err := bin.NewTable(cfg.BinTableFile, logger)
+	if err != nil {
+		logger.Fatal("could not load BIN table", zap.Error(err))
+	}
+
+	var binLookup ports.BinLookup = binTable
+	if cfg.BinProviderURL != "" {
+		provider := bin.NewProvider(bin.ProviderConfig{
+			URL:     cfg.BinProviderURL,
+			APIKey:  cfg.BinProviderAPIKey,
+			Retries: cfg.BinProviderRetries,
+			Backoff: 100 * time.Millisecond,
+			Breaker: bin.NewBreaker(
+				cfg.BinProviderBreakerThreshold,
+				time.Duration(cfg.BinProviderBreakerCooldown)*time.Second,
+			),
+		}, &http.Client{Timeout: cfg.BinProviderTimeout()})
+		binLookup = bin.NewFallback(provider, binTable, logger)
+	}
+	if cfg.BinCacheEnabled {
+		// With a provider, local answers are fallbacks and are kept only briefly.
+		var sourceTTLs map[string]time.Duration
+		if cfg.BinProviderURL != "" {
+			sourceTTLs = map[string]time.Duration{bin.SourceLocal: time.Duration(cfg.BinCacheFallbackTTL) * time.Second}
+		}
+		binCache := bin.NewCache(binLookup, bin.CacheConfig{
+			Size:        cfg.BinCacheSize,
+			TTL:         time.Duration(cfg.BinCacheTTL) * time.Second,
+			NegativeTTL: time.Duration(cfg.BinCacheNegativeTTL) * time.Second,
+			SourceTTLs:  sourceTTLs,
+			Metrics:     recorder,
+		})
+		binTable.OnReload(binCache.Invalidate)
+		binLookup = binCache
+	}
+
+	ruleCompiler, err := cardrules.NewCompiler(cfg.CardRuleCostLimit)
+	if err != nil {
+		logger.Fatal("could not initialize card rules", zap.Error(err))
+	}
+	cardRules, err := ruleCompiler.CompilePolicies(cfg.AppPolicies)
+	if err != nil {
+		logger.Fatal("could not compile card rules", zap.Error(err))
+	}
+
+	svcOpts := []app.Option{
+		app.WithMetrics(recorder),
+		app.WithBinLookup(binLookup),
+		app.WithPolicies(cfg.AppPolicies),
+		app.WithCardRules(cardRules),
+	}
+
+	var auditSink ports.AuditSink
+	if cfg.AuditEnabled {
+		auditSink, err = audit.NewFileSink(cfg.AuditFile, int64(cfg.AuditMaxSizeMB)<<20, logger)
+		if err != nil {
+			logger.Fatal("could not initialize audit sink", zap.Error(err))
+		}
+		svcOpts = append(svcOpts, app.WithAudit(auditSink))
+	}
+
+	var keyring *keys.LocalKeyManager
+	var keyManager ports.KeyManager
+	var walletOpts []app.WalletOption
+	if cfg.KeyManager == "local" {
+		keyring, err = keys.NewLocalKeyManager(cfg.KeyringFile, cfg.KeyringPassphrase)
+		if err != nil {
+			logger.Fatal("could not open keyring", zap.Error(err))
+		}
+		keyManager = auditedKeys(keyring, auditSink)
+		walletOpts = append(walletOpts, app.WithPANEncryption(keyManager))
+	}
+
+	// Audit records, events and velocity counters all fingerprint cards with the same key.
+	fingerprintKey, err := newFingerprintKey(context.Background(), cfg, keyManager)
+	if err != nil {
+		logger.Fatal("could not load fingerprint key", zap.Error(err))
+	}
+	if len(fingerprintKey) > 0 {
+		svcOpts = append(svcOpts, app.WithFingerprintKey(fingerprintKey))
+	}
+
+	var outbox *events.Outbox
+	var relay *events.Relay
+	var eventSink ports.EventSink
+	if cfg.EventsEnabled {
+		outbox, err = events.NewOutbox(cfg.EventsOutboxDB)
+		if err != nil {
+			logger.Fatal("could not open event outbox", zap.Error(err))
+		}
+		eventSink, err = newEventSink(cfg)
+		if err != nil {
+			logger.Fatal("could not initialize event sink", zap.Error(err))
+		}
+		relay = events.NewRelay(outbox, eventSink, newRelayConfig(cfg), logger)
+		svcOpts = append(svcOpts, app.WithEvents(outbox))
+	}
+
+	var binRuleStore *binrules.SQLiteStore
+	var binRules *app.BinRules
+	if cfg.BinRulesEnabled {
+		binRuleStore, err = binrules.NewSQLiteStore(cfg.BinRulesDB)
+		if err != nil {
+			logger.Fatal("could not open BIN rule store", zap.Error(err))
+		}
+		binRules, err = app.NewBinRules(context.Background(), binRuleStore)
+		if err != nil {
+			logger.Fatal("could not load BIN rules", zap.Error(err))
+		}
+		svcOpts = append(svcOpts, app.WithBinRules(binRules))
+	}
+
+	if cfg.VelocityEnabled {
+		svcOpts = append(svcOpts, app.WithVelocity(velocity.NewMemoryStore(), cfg.VelocityLimits(), cfg.VelocityMode == "reject"))
+	}
+
+	svc := app.NewService(val, svcOpts...)
+	// Card rules are compiled while the reload is checked, so a bad rule rejects all of it.
+	// Checks and subscribers run in turn under the reloader's lock.
+	var reloadedRules map[string][]ports.CardRule
+	reloader.Check(func(cfg *config.Config) (err error) {
+		reloadedRules, err = ruleCompiler.CompilePolicies(cfg.AppPolicies)
+		return err
+	})
+	reloader.Subscribe(func(cfg *config.Config) {
+		svc.SetPolicies(cfg.AppPolicies)
+		svc.SetCardRules(reloadedRules)
+	})
 
 	validator, err := protovalidate.New()
 	if err != nil {
 		logger.Fatal("could not initialize request validator", zap.Error(err))
 	}
 
-	s := grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			grpclogging.UnaryServerInterceptor(api.RequestLogInterceptor(logger)),
-			reqvalidator.UnaryServerInterceptor(validator),
-			recovery.UnaryServerInterceptor(),
-		),
+	healthSrv := grpchealth.NewServer()
+	monitor := health.NewMonitor(
+		healthSrv,
+		time.Duration(cfg.HealthCheckInterval)*time.Second,
+		time.Duration(cfg.HealthCheckTimeout)*time.Second,
+		logger,
+	)
+
+	// Checks each service depends on, besides the overall status which needs all of them.
+	cardsChecks := []string{"bin_lookup"}
+	usageChecks := []string{}
+
+	monitor.Register("bin_lookup", binTable.Check)
+	if cfg.AuditEnabled {
+		monitor.Register("fingerprint_key", func(context.Context) error {
+			if len(fingerprintKey) == 0 {
+				return fmt.Errorf("fingerprint key is not configured")
+			}
+			return nil
+		})
+		cardsChecks = append(cardsChecks, "fingerprint_key")
+	}
+
+	limiter, err := newRateLimiter(cfg)
+	if err != nil {
+		logger.Fatal("could not initialize rate limiter", zap.Error(err))
+	}
+	reloader.Subscribe(func(cfg *config.Config) { limiter.SetLimits(cfg.RateLimits()) })
+
+	if outbox != nil {
+		// Events wait in the outbox while the sink is down, so only the outbox is checked.
+		monitor.Register("event_outbox", outbox.Check)
+		cardsChecks = append(cardsChecks, "event_outbox")
+	}
+
+	if binRuleStore != nil {
+		monitor.Register("bin_rule_store", binRuleStore.Check)
+		cardsChecks = append(cardsChecks, "bin_rule_store")
+	}
+
+	var walletRepo walletStore
+	var walletOutbox *events.Outbox
+	var walletRelay *events.Relay
+	walletChecks := []string{}
+	if cfg.WalletEnabled {
+		walletRepo, err = newWalletRepository(cfg, outbox)
+		if err != nil {
+			logger.Fatal("could not open wallet store", zap.Error(err))
+		}
+		if store, ok := walletRepo.(*wallet.SQLiteRepository); ok {
+			monitor.Register("wallet_store", store.Check)
+			walletChecks = append(walletChecks, "wallet_store")
+
+			if eventSink != nil {
+				// Expiry events are stored in the wallet database, in the transaction of the
+				// status change they describe, and relayed to the sink from there.
+				walletOutbox, err = events.NewOutbox(cfg.WalletDB)
+				if err != nil {
+					logger.Fatal("could not open wallet event outbox", zap.Error(err))
+				}
+				walletRelay = events.NewRelay(walletOutbox, eventSink, newRelayConfig(cfg), logger)
+			}
+		}
+	}
+
+	if redisLimiter, ok := limiter.(*ratelimit.RedisLimiter); ok {
+		monitor.Register("rate_limit_store", redisLimiter.Check)
+		usageChecks = append(usageChecks, "rate_limit_store")
+	}
+
+	inFlight := api.NewInFlight()
+
+	interceptors := []grpc.UnaryServerInterceptor{
+		srvMetrics.UnaryServerInterceptor(),
+		grpclogging.UnaryServerInterceptor(api.RequestLogInterceptor(logger)),
+		inFlight.UnaryInterceptor(),
+		api.DeadlineInterceptor(cfg.Timeout(), cfg.MethodDeadlines),
+	}
+	if cfg.TrustAppIDHeader {
+		interceptors = append(interceptors, api.GatewayIdentityInterceptor())
+	}
+	if cfg.TLSClientAuth != "none" {
+		interceptors = append(interceptors, api.ClientCertInterceptor())
+	}
+	if cfg.JWTEnabled {
+		verifier, keys, err := newTokenVerifier(cfg)
+		if err != nil {
+			logger.Fatal("could not initialize token verifier", zap.Error(err))
+		}
+		monitor.Register("jwks", keys.Check)
+		cardsChecks = append(cardsChecks, "jwks")
+		usageChecks = append(usageChecks, "jwks")
+		interceptors = append(interceptors, api.BearerAuthInterceptor(verifier, cfg.MethodScopes, cfg.JWTRequired))
+	}
+	if cfg.RateLimitEnabled {
+		interceptors = append(interceptors, api.RateLimitInterceptor(limiter, logger))
+	}
+	interceptors = append(
+		interceptors,
+		api.CallerInterceptor(),
+		reqvalidator.UnaryServerInterceptor(validator),
+		recovery.UnaryServerInterceptor(),
 	)
 
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(
+			srvMetrics.StreamServerInterceptor(),
+			inFlight.StreamInterceptor(),
+			api.DeadlineStreamInterceptor(cfg.Timeout(), cfg.MethodDeadlines),
+			recovery.StreamServerInterceptor(),
+		),
+	}
+	if cfg.TLSEnabled {
+		creds, err := newServerCredentials(cfg, logger)
+		if err != nil {
+			logger.Fatal("could not initialize TLS", zap.Error(err))
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	s := grpc.NewServer(opts...)
+
 	srv := api.NewServer(svc)
 	pb.RegisterCardsServiceServer(s, srv)
+	cardsv1.RegisterValidationServiceServer(s, api.NewValidationServer(svc))
+	cardsv1.RegisterUsageServiceServer(s, api.NewUsageServer(limiter))
 
-	healthSrv := health.NewServer()
 	healthpb.RegisterHealthServer(s, healthSrv)
+	monitor.Service(pb.CardsService_ServiceDesc.ServiceName, cardsChecks...)
+	monitor.Service(cardsv1.ValidationService_ServiceDesc.ServiceName, cardsChecks...)
+	monitor.Service(cardsv1.UsageService_ServiceDesc.ServiceName, usageChecks...)
+
+	// Listing rules requires the cards.bin_rules.read scope and changing them cards.bin_rules.write,
+	// unless JWT_METHOD_SCOPES sets others.
+	if binRules != nil {
+		cardsv1.RegisterBinRuleServiceServer(s, api.NewBinRuleServer(binRules, logger))
+		monitor.Service(cardsv1.BinRuleService_ServiceDesc.ServiceName, "bin_rule_store")
+	}
+
+	if walletRepo != nil {
+		// Cards are validated before they are saved, so the wallet depends on the cards checks too.
+		cardWallet := app.NewWallet(svc, walletRepo, fingerprintKey, cfg.WalletMaxCards, walletOpts...)
+		cardsv1.RegisterWalletServiceServer(s, api.NewWalletServer(cardWallet))
+		monitor.Service(cardsv1.WalletService_ServiceDesc.ServiceName, append(walletChecks, cardsChecks...)...)
+	}
+
+	// Callers need the cards.fpe.encrypt or cards.fpe.decrypt scope unless JWT_METHOD_SCOPES sets another.
+	if cfg.FPEEnabled {
+		ciphers, err := newPANCiphers(context.Background(), cfg, keyManager)
+		if err != nil {
+			logger.Fatal("could not load FPE key", zap.Error(err))
+		}
+		cardsv1.RegisterFPEServiceServer(s, api.NewFPEServer(app.NewPANCipher(ciphers)))
+		monitor.Service(cardsv1.FPEService_ServiceDesc.ServiceName)
+	}
+
+	var expiryJob *app.ExpiryJob
+	if walletRepo != nil && cfg.ExpiryJobEnabled {
+		expiryJob = newExpiryJob(cfg, walletRepo)
+	}
 
 	reflection.Register(s)
+	srvMetrics.InitializeMetrics(s)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", cfg.ServerPort))
 	if err != nil {
 		logger.Fatal("could not bind port", zap.Error(err))
 	}
 
+	var socketLis net.Listener
+	if cfg.ServerSocket != "" {
+		_ = os.Remove(cfg.ServerSocket)
+
+		socketLis, err = net.Listen("unix", cfg.ServerSocket)
+		if err != nil {
+			logger.Fatal("could not bind socket", zap.Error(err))
+		}
+	}
+
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go reloader.Run(bgCtx, time.Duration(cfg.ConfigWatch)*time.Second)
+	go monitor.Run(bgCtx)
+	go binTable.Run(bgCtx, time.Duration(cfg.ConfigWatch)*time.Second)
+	if relay != nil {
+		go relay.Run(bgCtx)
+	}
+	if walletRelay != nil {
+		go walletRelay.Run(bgCtx)
+	}
+	if keyring != nil {
+		// Picks up the key versions the rotate-keys command adds.
+		go keyring.Run(bgCtx, time.Duration(cfg.ConfigWatch)*time.Second, func(err error) {
+			logger.Warn("could not refresh keyring", zap.Error(err))
+		})
+	}
+	if expiryJob != nil {
+		go expiryJob.Run(bgCtx, cfg.ExpiryCron, func(err error) {
+			logger.Error("could not check card expiry", zap.Error(err))
+		})
+	}
+	if binRules != nil {
+		go binRules.Run(bgCtx, time.Duration(cfg.BinRulesRefresh)*time.Second, func(err error) {
+			logger.Warn("could not refresh BIN rules", zap.Error(err))
+		})
+	}
+
 	sigCh := make(chan os.Signal, 1)
-	errCh := make(chan error, 1)
+	errCh := make(chan error, 5)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
+	metricsSrv := metrics.NewServer(cfg.MetricsPort, registry)
+	if cfg.MetricsEnabled {
+		go func() {
+			logger.Info("starting metrics server", zap.Int("metrics_port", cfg.MetricsPort))
+
+			if err := metricsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errCh <- err
+			}
+		}()
+	}
+
+	healthHTTPSrv := health.NewServer(cfg.HealthPort, monitor)
+	if cfg.HealthHTTPEnabled {
+		go func() {
+			logger.Info("starting health server", zap.Int("health_port", cfg.HealthPort))
+
+			if err := healthHTTPSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errCh <- err
+			}
+		}()
+	}
+
+	adminSrv := admin.NewServer(net.JoinHostPort(cfg.AdminAddr, strconv.Itoa(cfg.AdminPort)), admin.Options{
+		ServiceName:    cfg.ServiceName,
+		ServiceVersion: cfg.ServiceVersion,
+		Level:          logLevel,
+		Config: func() (map[string]any, uint64) {
+			return reloader.Current().Redacted(), reloader.Version()
+		},
+	})
+	if cfg.AdminEnabled {
+		go func() {
+			logger.Info("starting admin server", zap.String("admin_addr", adminSrv.Addr))
+
+			if err := adminSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errCh <- err
+			}
+		}()
+	}
+
 	go func() {
 		logger.Info(
 			"starting gRPC server",
 			zap.String("service_name", cfg.ServiceName),
 			zap.String("service_version", cfg.ServiceVersion),
+			zap.Uint64("config_version", reloader.Version()),
+			zap.String("config_checksum", cfg.Checksum()),
 			zap.Int("server_port", cfg.ServerPort),
 			zap.Bool("debug_mode", cfg.Debug),
+			zap.String("log_level", logLevel.String()),
+			zap.Duration("default_timeout", cfg.Timeout()),
+			zap.Bool("tls_enabled", cfg.TLSEnabled),
+			zap.String("tls_client_auth", cfg.TLSClientAuth),
+			zap.Bool("jwt_enabled", cfg.JWTEnabled),
+			zap.Bool("tracing_enabled", cfg.TracingEnabled),
+			zap.Bool("rate_limit_enabled", cfg.RateLimitEnabled),
+			zap.String("rate_limit_store", cfg.RateLimitStore),
+			zap.Bool("audit_enabled", cfg.AuditEnabled),
+			zap.Bool("velocity_enabled", cfg.VelocityEnabled),
+			zap.String("velocity_mode", cfg.VelocityMode),
+			zap.Int("bin_ranges", binTable.Len()),
+			zap.Bool("bin_cache_enabled", cfg.BinCacheEnabled),
+			zap.Bool("bin_provider_enabled", cfg.BinProviderURL != ""),
+			zap.Bool("bin_rules_enabled", cfg.BinRulesEnabled),
+			zap.Int("app_policies", len(cfg.AppPolicies)),
+			zap.Bool("events_enabled", cfg.EventsEnabled),
+			zap.String("events_sink", cfg.EventsSink),
+			zap.Bool("log_redact_pans", cfg.LogRedactPANs),
 		)
 
-		healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 		if err := s.Serve(lis); err != nil {
 			errCh <- err
 		}
 	}()
 
+	if socketLis != nil {
+		go func() {
+			logger.Info("serving gRPC on unix socket", zap.String("server_socket", cfg.ServerSocket))
+
+			if err := s.Serve(socketLis); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
 	select {
 	case signal := <-sigCh:
 		logger.Info("initiating graceful shutdown", zap.String("signal", signal.String()))
 
-		healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
-		s.GracefulStop()
+		// Report NOT_SERVING first and give load balancers time to stop routing to us
+		// before refusing new connections.
+		monitor.Shutdown()
+		if cfg.ShutdownDelay > 0 {
+			logger.Info("waiting for load balancers", zap.Int("shutdown_delay", cfg.ShutdownDelay))
+			time.Sleep(time.Duration(cfg.ShutdownDelay) * time.Second)
+		}
+
+		logger.Info("draining in-flight requests", zap.Int64("in_flight", inFlight.Active()))
+		abandoned := api.Drain(s, inFlight, time.Duration(cfg.DrainTimeout)*time.Second)
+		if abandoned > 0 {
+			logger.Warn("drain timed out, abandoned in-flight requests", zap.Int64("abandoned", abandoned))
+		} else {
+			logger.Info("drained in-flight requests")
+		}
 	case err = <-errCh:
 		logger.Error("server stopped unexpectedly", zap.Error(err))
 
-		healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		monitor.Shutdown()
 		s.Stop()
 	}
 
+	stopBackground()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = adminSrv.Shutdown(ctx)
+	_ = healthHTTPSrv.Shutdown(ctx)
+	// The metrics server goes last so the final counters can still be scraped while draining.
+	_ = metricsSrv.Shutdown(ctx)
+
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Warn("could not flush traces", zap.Error(err))
+	}
+
+	if auditSink != nil {
+		if err := auditSink.Close(); err != nil {
+			logger.Warn("could not close audit sink", zap.Error(err))
+		}
+	}
+
+	if outbox != nil {
+		if closer, ok := eventSink.(interface{ Close() error }); ok {
+			if err := closer.Close(); err != nil {
+				logger.Warn("could not close event sink", zap.Error(err))
+			}
+		}
+		if err := outbox.Close(); err != nil {
+			logger.Warn("could not close event outbox", zap.Error(err))
+		}
+	}
+
+	if walletOutbox != nil {
+		if err := walletOutbox.Close(); err != nil {
+			logger.Warn("could not close wallet event outbox", zap.Error(err))
+		}
+	}
+
+	if binRuleStore != nil {
+		if err := binRuleStore.Close(); err != nil {
+			logger.Warn("could not close BIN rule store", zap.Error(err))
+		}
+	}
+
+	if closer, ok := walletRepo.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			logger.Warn("could not close wallet store", zap.Error(err))
+		}
+	}
+
 	logger.Info("server stopped")
 }
+
+func setLogLevel(level zap.AtomicLevel, name string) {
+	if parsed, err := zapcore.ParseLevel(name); err == nil {
+		level.SetLevel(parsed)
+	}
+}
+
+func newRateLimiter(cfg *config.Config) (ports.RateLimiter, error) {
+	if cfg.RateLimitStore != "redis" {
+		return ratelimit.NewMemoryLimiter(cfg.RateLimits()), nil
+	}
+
+	opts, err := redis.ParseURL(cfg.RateLimitRedisURL)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(opts)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	return ratelimit.NewRedisLimiter(client, cfg.RateLimits(), cfg.ServiceName), nil
+}
+
+func newEventSink(cfg *config.Config) (ports.EventSink, error) {
+	switch cfg.EventsSink {
+	case "webhook":
+		return events.NewWebhookSink(cfg.EventsWebhookURL, cfg.EventsWebhookSecret, &http.Client{Timeout: cfg.Timeout()}), nil
+	case "memory":
+		return events.NewMemoryBroker(), nil
+	default:
+		return events.NewFileSink(cfg.EventsFile)
+	}
+}
+
+func newRelayConfig(cfg *config.Config) events.RelayConfig {
+	return events.RelayConfig{
+		Interval:    time.Duration(cfg.EventsPollInterval) * time.Second,
+		BatchSize:   cfg.EventsBatchSize,
+		MaxAttempts: cfg.EventsMaxAttempts,
+		Backoff:     time.Second,
+		MaxBackoff:  time.Duration(cfg.EventsMaxBackoff) * time.Second,
+	}
+}
+
+// walletStore keeps saved cards, elects the replica that checks their expiry, and holds
+// their encrypted card numbers.
+type walletStore interface {
+	ports.WalletRepository
+	ports.Lease
+	ports.EnvelopeStore
+}
+
+// auditedKeys records the use of keys in the audit trail, when there is one.
+func auditedKeys(manager ports.KeyManager, auditSink ports.AuditSink) ports.KeyManager {
+	if auditSink == nil {
+		return manager
+	}
+
+	return app.NewAuditedKeys(manager, auditSink)
+}
+
+// newFingerprintKey returns FINGERPRINT_KEY, or FINGERPRINT_KEY_ENVELOPE opened by the key
+// manager. The key is empty when neither is set.
+func newFingerprintKey(ctx context.Context, cfg *config.Config, manager ports.KeyManager) ([]byte, error) {
+	if cfg.FingerprintKeyEnvelope == "" {
+		return []byte(cfg.FingerprintKey), nil
+	}
+
+	envelope, err := domain.ParseEnvelope(cfg.FingerprintKeyEnvelope)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := manager.Decrypt(ctx, domain.KeyPurposeFingerprintKey, envelope, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) < 32 {
+		return nil, fmt.Errorf("fingerprint key is shorter than 32 bytes")
+	}
+
+	return key, nil
+}
+
+// newPANCiphers creates the format-preserving ciphers of card numbers, with FPE_KEY or with
+// FPE_KEY_ENVELOPE opened by the key manager.
+func newPANCiphers(ctx context.Context, cfg *config.Config, manager ports.KeyManager) (map[string]ports.FormatPreservingCipher, error) {
+	var key []byte
+	var err error
+	if cfg.FPEKey != "" {
+		key, err = hex.DecodeString(cfg.FPEKey)
+	} else {
+		var envelope *domain.Envelope
+		if envelope, err = domain.ParseEnvelope(cfg.FPEKeyEnvelope); err == nil {
+			key, err = manager.Decrypt(ctx, domain.KeyPurposeFPEKey, envelope, nil)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ff1, err := fpe.NewFF1(key)
+	if err != nil {
+		return nil, err
+	}
+	ff31, err := fpe.NewFF31(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]ports.FormatPreservingCipher{domain.FPEFF1: ff1, domain.FPEFF31: ff31}, nil
+}
+
+// newWalletRepository opens the wallet store. A memory store publishes the events of status
+// changes to outbox, when there is one; a SQLite store keeps them in an outbox of its own.
+func newWalletRepository(cfg *config.Config, outbox *events.Outbox) (walletStore, error) {
+	if cfg.WalletStore == "memory" {
+		var publisher ports.EventPublisher
+		if outbox != nil {
+			publisher = outbox
+		}
+		return wallet.NewMemoryRepository(publisher), nil
+	}
+
+	return wallet.NewSQLiteRepository(cfg.WalletDB)
+}
+
+func newExpiryJob(cfg *config.Config, store walletStore) *app.ExpiryJob {
+	// Replicas tell each other apart in the lease by host and process.
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+
+	var opts []app.ExpiryOption
+	if cfg.ExpiryNotify == "events" {
+		opts = append(opts, app.WithExpiryEvents())
+	} else {
+		opts = append(opts, app.WithExpiryReports(wallet.NewFileReporter(cfg.ExpiryReportDir)))
+	}
+
+	return app.NewExpiryJob(
+		store,
+		store,
+		holder,
+		time.Duration(cfg.ExpiryWindowDays)*24*time.Hour,
+		time.Duration(cfg.ExpiryLeaseTTL)*time.Second,
+		opts...,
+	)
+}
+
+func newServerCredentials(cfg *config.Config, logger *zap.Logger) (credentials.TransportCredentials, error) {
+	reloader, err := certs.NewReloader(cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := reloader.ServerConfig(certs.MinVersion(cfg.TLSMinVersion), certs.ClientAuth(cfg.TLSClientAuth))
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+func newTokenVerifier(cfg *config.Config) (ports.TokenVerifier, *oidc.KeySet, error) {
+	client := &http.Client{Timeout: cfg.Timeout()}
+
+	keys, err := oidc.NewKeySet(
+		context.Background(),
+		cfg.JWTJWKSSource,
+		time.Duration(cfg.JWTJWKSRefresh)*time.Second,
+		client,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oidc.NewVerifier(keys, oidc.VerifierConfig{
+		Issuer:    cfg.JWTIssuer,
+		Audience:  cfg.JWTAudience,
+		ClockSkew: time.Duration(cfg.JWTClockSkew) * time.Second,
+		AppClaim:  cfg.JWTAppClaim,
+	}), keys, nil
+}
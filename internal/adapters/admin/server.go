@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Options describes what the admin server exposes.
+type Options struct {
+	ServiceName    string
+	ServiceVersion string
+	// Level is the logger level read and changed through /loglevel.
+	Level zap.AtomicLevel
+	// Config returns the active configuration with secrets redacted, and its version.
+	Config func() (map[string]any, uint64)
+}
+
+type buildInfo struct {
+	ServiceName    string            `json:"service_name"`
+	ServiceVersion string            `json:"service_version"`
+	GoVersion      string            `json:"go_version"`
+	Module         string            `json:"module"`
+	ModuleVersion  string            `json:"module_version"`
+	Settings       map[string]string `json:"settings,omitempty"`
+}
+
+type configDump struct {
+	Version  uint64         `json:"version"`
+	Settings map[string]any `json:"settings"`
+}
+
+// NewServer serves operational endpoints that must stay off the public gRPC port:
+// pprof under /debug/pprof/, build information on /version, the redacted configuration
+// on /config and the logger level on /loglevel (GET to read, PUT {"level":"debug"} to change).
+// The endpoints are not authenticated, so addr should only be reachable by operators.
+// pprof's cmdline endpoint is left out, as secrets may be passed as flags.
+func NewServer(addr string, opts Options) *http.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("GET /version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, readBuildInfo(opts.ServiceName, opts.ServiceVersion))
+	})
+
+	mux.HandleFunc("GET /config", func(w http.ResponseWriter, r *http.Request) {
+		settings, version := opts.Config()
+		writeJSON(w, configDump{Version: version, Settings: settings})
+	})
+
+	mux.Handle("/loglevel", opts.Level)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func readBuildInfo(name, version string) buildInfo {
+	info := buildInfo{ServiceName: name, ServiceVersion: version}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.GoVersion = build.GoVersion
+	info.Module = build.Main.Path
+	info.ModuleVersion = build.Main.Version
+	info.Settings = make(map[string]string)
+
+	for _, setting := range build.Settings {
+		info.Settings[setting.Key] = setting.Value
+	}
+
+	return info
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
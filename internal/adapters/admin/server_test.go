@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newTestServer() (*Options, func(method, path, body string) *httptest.ResponseRecorder) {
+	opts := &Options{
+		ServiceName:    "cards-service",
+		ServiceVersion: "1.2.3",
+		Level:          zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		Config: func() (map[string]any, uint64) {
+			return map[string]any{"SERVER_PORT": 8080, "FINGERPRINT_KEY": "[REDACTED]"}, 3
+		},
+	}
+	srv := NewServer("127.0.0.1:9465", *opts)
+
+	return opts, func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+}
+
+func TestServer(t *testing.T) {
+
+	t.Run("Version", func(t *testing.T) {
+		_, do := newTestServer()
+
+		rec := do("GET", "/version", "")
+		require.Equal(t, 200, rec.Code)
+
+		var info buildInfo
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+		assert.Equal(t, "cards-service", info.ServiceName)
+		assert.Equal(t, "1.2.3", info.ServiceVersion)
+		assert.Equal(t, runtime.Version(), info.GoVersion)
+	})
+
+	t.Run("Redacted Config", func(t *testing.T) {
+		_, do := newTestServer()
+
+		rec := do("GET", "/config", "")
+		require.Equal(t, 200, rec.Code)
+		assert.JSONEq(t, `{"version": 3, "settings": {"SERVER_PORT": 8080, "FINGERPRINT_KEY": "[REDACTED]"}}`, rec.Body.String())
+	})
+
+	t.Run("Log Level", func(t *testing.T) {
+		opts, do := newTestServer()
+
+		rec := do("GET", "/loglevel", "")
+		require.Equal(t, 200, rec.Code)
+		assert.JSONEq(t, `{"level": "info"}`, rec.Body.String())
+
+		rec = do("PUT", "/loglevel", `{"level": "debug"}`)
+		require.Equal(t, 200, rec.Code)
+		assert.Equal(t, zapcore.DebugLevel, opts.Level.Level())
+
+		rec = do("PUT", "/loglevel", `{"level": "loud"}`)
+		assert.Equal(t, 400, rec.Code)
+		assert.Equal(t, zapcore.DebugLevel, opts.Level.Level())
+	})
+
+	t.Run("Pprof", func(t *testing.T) {
+		_, do := newTestServer()
+
+		rec := do("GET", "/debug/pprof/", "")
+		assert.Equal(t, 200, rec.Code)
+		assert.Contains(t, rec.Body.String(), "goroutine")
+
+		rec = do("GET", "/debug/pprof/goroutine?debug=1", "")
+		assert.Equal(t, 200, rec.Code)
+
+		rec = do("GET", "/debug/pprof/cmdline", "")
+		assert.Equal(t, 404, rec.Code, "flags may hold secrets")
+	})
+}
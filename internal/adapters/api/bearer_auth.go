@@ -0,0 +1,70 @@
+package api
+
+import (
+	"cards-service/internal/core/ports"
+	"context"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// publicServices can be called without a bearer token even when one is required.
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// BearerAuthInterceptor authenticates callers that present a JWT bearer token and enforces the
+// scopes configured for the called method. Calls without a token are passed through for other
+// authentication schemes unless required is set or the method requires scopes, which only a
+// token can carry.
+func BearerAuthInterceptor(verifier ports.TokenVerifier, methodScopes map[string][]string, required bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		scopes, scoped := methodScopes[info.FullMethod]
+
+		token, ok := bearerToken(ctx)
+		if !ok {
+			if scoped || (required && !matchesService(info.FullMethod, publicServices)) {
+				return nil, errors.NewErrorf(errors.Unauthenticated, "missing bearer token")
+			}
+
+			return handler(ctx, req)
+		}
+
+		principal, err := verifier.Verify(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if scoped && !principal.HasScopes(scopes) {
+			return nil, errors.NewErrorf(errors.Unauthorized, "token is missing required scopes for %s", info.FullMethod)
+		}
+
+		ctx = withIdentity(ctx, Identity{
+			AppID:   principal.AppID,
+			Subject: principal.Subject,
+			Scopes:  principal.Scopes,
+			Peer:    peerIP(ctx),
+		})
+
+		return handler(ctx, req)
+	}
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(value, " ")
+		if found && strings.EqualFold(scheme, "bearer") && token != "" {
+			return strings.TrimSpace(token), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"testing"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type mockTokenVerifier struct {
+	principal *domain.Principal
+	token     string
+}
+
+func (m *mockTokenVerifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	m.token = token
+	if m.principal == nil {
+		return nil, errors.NewErrorf(errors.Unauthenticated, "invalid bearer token")
+	}
+
+	return m.principal, nil
+}
+
+func TestBearerAuthInterceptor(t *testing.T) {
+	const method = "/cards.v1.CardsService/ValidateCardNumber"
+
+	scopes := map[string][]string{method: {"cards.validate"}}
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+
+	withToken := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+	}
+
+	run := func(interceptor grpc.UnaryServerInterceptor, ctx context.Context, info *grpc.UnaryServerInfo) (Identity, error) {
+		var id Identity
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			id = IdentityFromContext(ctx)
+			return nil, nil
+		})
+
+		return id, err
+	}
+
+	t.Run("Valid Token", func(t *testing.T) {
+		verifier := &mockTokenVerifier{principal: &domain.Principal{
+			Subject: "sa-1",
+			AppID:   "checkout-app",
+			Scopes:  []string{"cards.validate"},
+		}}
+
+		id, err := run(BearerAuthInterceptor(verifier, scopes, true), withToken("Bearer abc.def.ghi"), info)
+		require.NoError(t, err)
+
+		assert.Equal(t, "abc.def.ghi", verifier.token)
+		assert.Equal(t, "checkout-app", id.AppID)
+		assert.Equal(t, "sa-1", id.Subject)
+		assert.Equal(t, []string{"cards.validate"}, id.Scopes)
+	})
+
+	t.Run("Missing Scope", func(t *testing.T) {
+		verifier := &mockTokenVerifier{principal: &domain.Principal{AppID: "checkout-app"}}
+
+		_, err := run(BearerAuthInterceptor(verifier, scopes, false), withToken("bearer abc"), info)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Invalid Token", func(t *testing.T) {
+		_, err := run(BearerAuthInterceptor(&mockTokenVerifier{}, scopes, false), withToken("Bearer bad"), info)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("Missing Token", func(t *testing.T) {
+		verifier := &mockTokenVerifier{}
+
+		_, err := run(BearerAuthInterceptor(verifier, scopes, true), context.Background(), info)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = run(BearerAuthInterceptor(verifier, scopes, true), withToken("Basic dXNlcjpwYXNz"), info)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		health := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+		_, err = run(BearerAuthInterceptor(verifier, scopes, true), context.Background(), health)
+		require.NoError(t, err)
+
+		unscoped := &grpc.UnaryServerInfo{FullMethod: "/cards.v1.UsageService/GetUsage"}
+		id, err := run(BearerAuthInterceptor(verifier, scopes, false), context.Background(), unscoped)
+		require.NoError(t, err)
+		assert.Equal(t, anonymousApp, id.AppID)
+		assert.Empty(t, verifier.token)
+	})
+
+	t.Run("Scoped Methods Need A Token Even When Tokens Are Optional", func(t *testing.T) {
+		_, err := run(BearerAuthInterceptor(&mockTokenVerifier{}, scopes, false), context.Background(), info)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var binRuleActions = map[cardsv1.BinRuleAction]string{
+	cardsv1.BinRuleAction_BIN_RULE_ACTION_BLOCK: domain.BinRuleBlock,
+	cardsv1.BinRuleAction_BIN_RULE_ACTION_ALLOW: domain.BinRuleAllow,
+}
+
+type BinRuleServer struct {
+	cardsv1.UnimplementedBinRuleServiceServer
+	rules  ports.BinRuleService
+	logger *zap.Logger
+}
+
+func NewBinRuleServer(rules ports.BinRuleService, logger *zap.Logger) *BinRuleServer {
+	return &BinRuleServer{rules: rules, logger: logger}
+}
+
+func (srv *BinRuleServer) CreateBinRule(ctx context.Context, req *cardsv1.CreateBinRuleRequest) (*cardsv1.CreateBinRuleResponse, error) {
+	rule, err := srv.rules.CreateBinRule(ctx, &domain.BinRule{
+		RangeStart: req.GetRangeStart(),
+		RangeEnd:   req.GetRangeEnd(),
+		Action:     binRuleActions[req.GetAction()],
+		Reason:     req.GetReason(),
+		ExpiresAt:  fromTimestamp(req.GetExpiresAt()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	srv.logger.Info(
+		"BIN rule created",
+		zap.String("rule_id", rule.ID),
+		zap.String("range_start", rule.RangeStart),
+		zap.String("range_end", rule.RangeEnd),
+		zap.String("action", rule.Action),
+		zap.String("created_by", rule.CreatedBy),
+	)
+
+	return &cardsv1.CreateBinRuleResponse{Rule: toBinRule(rule)}, nil
+}
+
+func (srv *BinRuleServer) ListBinRules(ctx context.Context, req *cardsv1.ListBinRulesRequest) (*cardsv1.ListBinRulesResponse, error) {
+	rules, err := srv.rules.ListBinRules(ctx, req.GetIncludeExpired())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &cardsv1.ListBinRulesResponse{Rules: make([]*cardsv1.BinRule, 0, len(rules))}
+	for i := range rules {
+		resp.Rules = append(resp.Rules, toBinRule(&rules[i]))
+	}
+
+	return resp, nil
+}
+
+func (srv *BinRuleServer) DeleteBinRule(ctx context.Context, req *cardsv1.DeleteBinRuleRequest) (*cardsv1.DeleteBinRuleResponse, error) {
+	if err := srv.rules.DeleteBinRule(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	id := IdentityFromContext(ctx)
+	srv.logger.Info(
+		"BIN rule deleted",
+		zap.String("rule_id", req.GetId()),
+		zap.String("app_id", id.AppID),
+		zap.String("subject", id.Subject),
+	)
+
+	return &cardsv1.DeleteBinRuleResponse{}, nil
+}
+
+func toBinRule(rule *domain.BinRule) *cardsv1.BinRule {
+	pbRule := &cardsv1.BinRule{
+		Id:         rule.ID,
+		RangeStart: rule.RangeStart,
+		RangeEnd:   rule.RangeEnd,
+		Reason:     rule.Reason,
+		CreatedAt:  timestamppb.New(rule.CreatedAt),
+		CreatedBy:  rule.CreatedBy,
+	}
+
+	for action, name := range binRuleActions {
+		if name == rule.Action {
+			pbRule.Action = action
+		}
+	}
+	if !rule.ExpiresAt.IsZero() {
+		pbRule.ExpiresAt = timestamppb.New(rule.ExpiresAt)
+	}
+
+	return pbRule
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+
+	return ts.AsTime()
+}
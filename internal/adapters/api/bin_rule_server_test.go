@@ -0,0 +1,106 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mockBinRuleService struct {
+	created        *domain.BinRule
+	rules          []domain.BinRule
+	includeExpired bool
+	deleted        string
+	err            error
+}
+
+func (m *mockBinRuleService) CreateBinRule(ctx context.Context, rule *domain.BinRule) (*domain.BinRule, error) {
+	m.created = rule
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	created := *rule
+	created.ID = "rule-1"
+	created.CreatedBy = "ops"
+	return &created, nil
+}
+
+func (m *mockBinRuleService) ListBinRules(ctx context.Context, includeExpired bool) ([]domain.BinRule, error) {
+	m.includeExpired = includeExpired
+	return m.rules, m.err
+}
+
+func (m *mockBinRuleService) DeleteBinRule(ctx context.Context, id string) error {
+	m.deleted = id
+	return m.err
+}
+
+func TestBinRuleServer(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Create", func(t *testing.T) {
+		rules := &mockBinRuleService{}
+		srv := NewBinRuleServer(rules, zap.NewNop())
+
+		resp, err := srv.CreateBinRule(ctx, &cardsv1.CreateBinRuleRequest{
+			RangeStart: "411111",
+			Action:     cardsv1.BinRuleAction_BIN_RULE_ACTION_BLOCK,
+			Reason:     "fraud",
+			ExpiresAt:  timestamppb.New(expiresAt),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.BinRuleBlock, rules.created.Action)
+		assert.Equal(t, expiresAt, rules.created.ExpiresAt)
+		assert.Equal(t, "rule-1", resp.Rule.Id)
+		assert.Equal(t, cardsv1.BinRuleAction_BIN_RULE_ACTION_BLOCK, resp.Rule.Action)
+		assert.Equal(t, expiresAt, resp.Rule.ExpiresAt.AsTime())
+	})
+
+	t.Run("Create Without Action", func(t *testing.T) {
+		rules := &mockBinRuleService{err: assert.AnError}
+		srv := NewBinRuleServer(rules, zap.NewNop())
+
+		resp, err := srv.CreateBinRule(ctx, &cardsv1.CreateBinRuleRequest{RangeStart: "411111", Reason: "fraud"})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, rules.created.Action, "unspecified actions are left to validation")
+		assert.True(t, rules.created.ExpiresAt.IsZero())
+	})
+
+	t.Run("List", func(t *testing.T) {
+		rules := &mockBinRuleService{rules: []domain.BinRule{
+			{ID: "a", RangeStart: "6800", RangeEnd: "6899", Action: domain.BinRuleAllow, Reason: "private label"},
+		}}
+		srv := NewBinRuleServer(rules, zap.NewNop())
+
+		resp, err := srv.ListBinRules(ctx, &cardsv1.ListBinRulesRequest{IncludeExpired: true})
+
+		require.NoError(t, err)
+		assert.True(t, rules.includeExpired)
+		require.Len(t, resp.Rules, 1)
+		assert.Equal(t, "6899", resp.Rules[0].RangeEnd)
+		assert.Equal(t, cardsv1.BinRuleAction_BIN_RULE_ACTION_ALLOW, resp.Rules[0].Action)
+		assert.Nil(t, resp.Rules[0].ExpiresAt, "rules that never expire have no expiry")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		rules := &mockBinRuleService{}
+		srv := NewBinRuleServer(rules, zap.NewNop())
+
+		_, err := srv.DeleteBinRule(ctx, &cardsv1.DeleteBinRuleRequest{Id: "a"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "a", rules.deleted)
+	})
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// CallerInterceptor hands the resolved identity to the core so it can be recorded
+// without the core depending on gRPC. It must run after the authentication interceptors.
+func CallerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := IdentityFromContext(ctx)
+
+		ctx = domain.ContextWithCaller(ctx, domain.Caller{
+			AppID:   id.AppID,
+			Subject: id.Subject,
+			Peer:    id.Peer,
+			Method:  info.FullMethod,
+		})
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCallerInterceptor(t *testing.T) {
+	interceptor := CallerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/cards.v1.CardsService/ValidateCardNumber"}
+
+	t.Run("Carries Identity And Method", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(tlsPeerContext(nil), metadata.Pairs(AppIDHeader, "checkout"))
+
+		var caller domain.Caller
+		_, err := GatewayIdentityInterceptor()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				caller = domain.CallerFromContext(ctx)
+				return nil, nil
+			})
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.Caller{
+			AppID:  "checkout",
+			Peer:   "10.1.2.3",
+			Method: "/cards.v1.CardsService/ValidateCardNumber",
+		}, caller)
+	})
+
+	t.Run("Ignores The Header Without A Gateway", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(tlsPeerContext(nil), metadata.Pairs(AppIDHeader, "checkout"))
+
+		var caller domain.Caller
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			caller = domain.CallerFromContext(ctx)
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, anonymousApp, caller.AppID)
+		assert.Equal(t, "10.1.2.3", caller.Peer)
+	})
+
+	t.Run("Authenticated Subject", func(t *testing.T) {
+		ctx := withIdentity(context.Background(), Identity{AppID: "merchant-app", Subject: "CN=merchant-app"})
+
+		var caller domain.Caller
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			caller = domain.CallerFromContext(ctx)
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "merchant-app", caller.AppID)
+		assert.Equal(t, "CN=merchant-app", caller.Subject)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientCertInterceptor makes the verified client certificate the caller identity.
+// The certificate common name becomes the app ID and the full subject is kept for authorization.
+func ClientCertInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if id, ok := clientCertIdentity(ctx); ok {
+			ctx = withIdentity(ctx, id)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func clientCertIdentity(ctx context.Context) (Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Identity{}, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return Identity{}, false
+	}
+
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+
+	id := Identity{
+		AppID:   leaf.Subject.CommonName,
+		Subject: leaf.Subject.String(),
+		Peer:    peerIP(ctx),
+	}
+	if id.AppID == "" {
+		id.AppID = id.Subject
+	}
+
+	return id, true
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func tlsPeerContext(chains [][]*x509.Certificate) context.Context {
+	p := &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 4567},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: chains}},
+	}
+
+	return peer.NewContext(context.Background(), p)
+}
+
+func TestClientCertInterceptor(t *testing.T) {
+	interceptor := ClientCertInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/cards.v1.CardsService/ValidateCardNumber"}
+
+	capture := func(id *Identity) grpc.UnaryHandler {
+		return func(ctx context.Context, req any) (any, error) {
+			*id = IdentityFromContext(ctx)
+			return nil, nil
+		}
+	}
+
+	t.Run("Verified Certificate", func(t *testing.T) {
+		leaf := &x509.Certificate{Subject: pkix.Name{CommonName: "merchant-app", Organization: []string{"Cards"}}}
+		ctx := tlsPeerContext([][]*x509.Certificate{{leaf}})
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(AppIDHeader, "spoofed"))
+
+		var id Identity
+		_, err := interceptor(ctx, nil, info, capture(&id))
+		require.NoError(t, err)
+
+		assert.Equal(t, "merchant-app", id.AppID)
+		assert.Equal(t, "CN=merchant-app,O=Cards", id.Subject)
+		assert.Equal(t, "10.1.2.3", id.Peer)
+	})
+
+	t.Run("Subject Without Common Name", func(t *testing.T) {
+		leaf := &x509.Certificate{Subject: pkix.Name{Organization: []string{"Cards"}}}
+
+		var id Identity
+		_, err := interceptor(tlsPeerContext([][]*x509.Certificate{{leaf}}), nil, info, capture(&id))
+		require.NoError(t, err)
+
+		assert.Equal(t, "O=Cards", id.AppID)
+	})
+
+	t.Run("No Client Certificate", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(tlsPeerContext(nil), metadata.Pairs(AppIDHeader, "app-1"))
+
+		var id Identity
+		_, err := interceptor(ctx, nil, info, capture(&id))
+		require.NoError(t, err)
+
+		assert.Equal(t, anonymousApp, id.AppID, "the header alone is not trusted")
+		assert.Equal(t, "10.1.2.3", id.Peer)
+		assert.Empty(t, id.Subject)
+	})
+}
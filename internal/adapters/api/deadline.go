@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unboundedMethods are long-lived streams that must not be cut off by the default deadline.
+var unboundedMethods = []string{
+	"/grpc.health.v1.Health/Watch",
+	"/grpc.reflection.",
+}
+
+// DeadlineInterceptor applies a deadline to requests whose callers did not set one,
+// using the per-method override when there is one. Work abandoned because the deadline
+// passed or the caller went away is reported as DeadlineExceeded or Canceled. Other errors
+// are returned as they are, even when they arrive after the deadline.
+func DeadlineInterceptor(timeout time.Duration, overrides map[string]time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := withDefaultDeadline(ctx, info.FullMethod, timeout, overrides)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		if ctx.Err() != nil && fromContext(err) {
+			return nil, abandoned(ctx, err)
+		}
+
+		return resp, err
+	}
+}
+
+func DeadlineStreamInterceptor(timeout time.Duration, overrides map[string]time.Duration) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := withDefaultDeadline(ss.Context(), info.FullMethod, timeout, overrides)
+		defer cancel()
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		if ctx.Err() != nil && fromContext(err) {
+			return abandoned(ctx, err)
+		}
+
+		return err
+	}
+}
+
+func withDefaultDeadline(ctx context.Context, method string, timeout time.Duration, overrides map[string]time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || matchesService(method, unboundedMethods) {
+		return context.WithCancel(ctx)
+	}
+
+	if override, ok := overrides[method]; ok {
+		timeout = override
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// fromContext reports whether err is, or carries the code of, a context error.
+func fromContext(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled) {
+		return true
+	}
+
+	code := status.Code(err)
+	return code == codes.DeadlineExceeded || code == codes.Canceled
+}
+
+func abandoned(ctx context.Context, err error) error {
+	if ctx.Err() == context.Canceled {
+		return status.FromContextError(context.Canceled).Err()
+	}
+
+	if appErr, ok := err.(*errors.Error); ok && appErr.ErrCode == errors.DeadlineExceeded {
+		return err
+	}
+
+	return errors.WrapError(err, errors.DeadlineExceeded, "request deadline exceeded")
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
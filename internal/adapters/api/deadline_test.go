@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestDeadlineInterceptor(t *testing.T) {
+	overrides := map[string]time.Duration{"/cards.CardsService/Slow": time.Minute}
+	interceptor := DeadlineInterceptor(10*time.Second, overrides)
+
+	remaining := func(method string, ctx context.Context) (time.Duration, bool) {
+		var left time.Duration
+		var ok bool
+
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req any) (any, error) {
+			var deadline time.Time
+			deadline, ok = ctx.Deadline()
+			left = time.Until(deadline)
+			return nil, nil
+		})
+		require.NoError(t, err)
+
+		return left, ok
+	}
+
+	t.Run("Applies Default", func(t *testing.T) {
+		left, ok := remaining("/cards.CardsService/ValidateCardNumber", context.Background())
+		require.True(t, ok)
+		assert.InDelta(t, 10*time.Second, left, float64(time.Second))
+	})
+
+	t.Run("Applies Method Override", func(t *testing.T) {
+		left, ok := remaining("/cards.CardsService/Slow", context.Background())
+		require.True(t, ok)
+		assert.InDelta(t, time.Minute, left, float64(time.Second))
+	})
+
+	t.Run("Keeps Caller Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		left, ok := remaining("/cards.CardsService/ValidateCardNumber", ctx)
+		require.True(t, ok)
+		assert.InDelta(t, time.Hour, left, float64(time.Second))
+	})
+
+	t.Run("Expired Work", func(t *testing.T) {
+		interceptor := DeadlineInterceptor(10*time.Millisecond, nil)
+		info := &grpc.UnaryServerInfo{FullMethod: "/cards.CardsService/ValidateCardNumber"}
+
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		require.Error(t, err)
+
+		appErr, ok := err.(*errors.Error)
+		require.True(t, ok)
+		assert.Equal(t, errors.DeadlineExceeded, appErr.ErrCode)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("Canceled Work", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/cards.CardsService/ValidateCardNumber"}
+
+		_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, ctx.Err()
+		})
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("Other Errors Pass Through After The Deadline", func(t *testing.T) {
+		interceptor := DeadlineInterceptor(10*time.Millisecond, nil)
+		info := &grpc.UnaryServerInfo{FullMethod: "/cards.CardsService/ValidateCardNumber"}
+
+		for _, code := range []errors.ErrorCode{errors.InvalidArgument, errors.PreconditionFailed} {
+			_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+				<-ctx.Done()
+				return nil, errors.NewErrorf(code, "rejected")
+			})
+
+			appErr, ok := err.(*errors.Error)
+			require.True(t, ok)
+			assert.Equal(t, code, appErr.ErrCode)
+		}
+	})
+
+	t.Run("Wrapped Context Errors", func(t *testing.T) {
+		interceptor := DeadlineInterceptor(10*time.Millisecond, nil)
+		info := &grpc.UnaryServerInfo{FullMethod: "/cards.CardsService/ValidateCardNumber"}
+
+		_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			<-ctx.Done()
+			return nil, errors.WrapError(ctx.Err(), errors.ServiceUnavailable, "failed to call BIN provider")
+		})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+}
+
+func TestDeadlineStreamInterceptor(t *testing.T) {
+	interceptor := DeadlineStreamInterceptor(10*time.Millisecond, nil)
+
+	t.Run("Expired Stream", func(t *testing.T) {
+		stream := &fakeServerStream{ctx: context.Background()}
+		info := &grpc.StreamServerInfo{FullMethod: "/cards.CardsService/ValidateCardNumbers"}
+
+		err := interceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+			<-ss.Context().Done()
+			return ss.Context().Err()
+		})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	})
+
+	t.Run("Health Watch Is Unbounded", func(t *testing.T) {
+		stream := &fakeServerStream{ctx: context.Background()}
+		info := &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"}
+
+		err := interceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+			_, ok := ss.Context().Deadline()
+			assert.False(t, ok)
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+}
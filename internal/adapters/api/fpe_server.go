@@ -0,0 +1,41 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+)
+
+var fpeAlgorithms = map[cardsv1.FPEAlgorithm]string{
+	cardsv1.FPEAlgorithm_FPE_ALGORITHM_UNSPECIFIED: domain.FPEFF1,
+	cardsv1.FPEAlgorithm_FPE_ALGORITHM_FF1:         domain.FPEFF1,
+	cardsv1.FPEAlgorithm_FPE_ALGORITHM_FF3_1:       domain.FPEFF31,
+}
+
+type FPEServer struct {
+	cardsv1.UnimplementedFPEServiceServer
+	ciphers ports.PANCipherService
+}
+
+func NewFPEServer(ciphers ports.PANCipherService) *FPEServer {
+	return &FPEServer{ciphers: ciphers}
+}
+
+func (srv *FPEServer) EncryptPAN(ctx context.Context, req *cardsv1.EncryptPANRequest) (*cardsv1.EncryptPANResponse, error) {
+	encrypted, err := srv.ciphers.EncryptPAN(ctx, fpeAlgorithms[req.GetAlgorithm()], req.GetCardNumber(), req.GetTweak())
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.EncryptPANResponse{EncryptedCardNumber: encrypted}, nil
+}
+
+func (srv *FPEServer) DecryptPAN(ctx context.Context, req *cardsv1.DecryptPANRequest) (*cardsv1.DecryptPANResponse, error) {
+	pan, err := srv.ciphers.DecryptPAN(ctx, fpeAlgorithms[req.GetAlgorithm()], req.GetEncryptedCardNumber(), req.GetTweak())
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.DecryptPANResponse{CardNumber: pan}, nil
+}
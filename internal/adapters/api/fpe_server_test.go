@@ -0,0 +1,67 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"testing"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPANCipherService struct {
+	algorithm string
+	pan       string
+	tweak     []byte
+	err       error
+}
+
+func (m *mockPANCipherService) EncryptPAN(ctx context.Context, algorithm string, cardNumber string, tweak []byte) (string, error) {
+	m.algorithm, m.pan, m.tweak = algorithm, cardNumber, tweak
+	return "4111119876541111", m.err
+}
+
+func (m *mockPANCipherService) DecryptPAN(ctx context.Context, algorithm string, encrypted string, tweak []byte) (string, error) {
+	m.algorithm, m.pan, m.tweak = algorithm, encrypted, tweak
+	return "4111111111111111", m.err
+}
+
+func TestFPEServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Encrypt", func(t *testing.T) {
+		ciphers := &mockPANCipherService{}
+		srv := NewFPEServer(ciphers)
+
+		resp, err := srv.EncryptPAN(ctx, &cardsv1.EncryptPANRequest{
+			CardNumber: "4111111111111111",
+			Algorithm:  cardsv1.FPEAlgorithm_FPE_ALGORITHM_FF3_1,
+			Tweak:      []byte("tweak-7"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "4111119876541111", resp.GetEncryptedCardNumber())
+		assert.Equal(t, domain.FPEFF31, ciphers.algorithm)
+		assert.Equal(t, "4111111111111111", ciphers.pan)
+		assert.Equal(t, []byte("tweak-7"), ciphers.tweak)
+	})
+
+	t.Run("Decrypt Defaults To FF1", func(t *testing.T) {
+		ciphers := &mockPANCipherService{}
+		srv := NewFPEServer(ciphers)
+
+		resp, err := srv.DecryptPAN(ctx, &cardsv1.DecryptPANRequest{EncryptedCardNumber: "4111119876541111"})
+		require.NoError(t, err)
+		assert.Equal(t, "4111111111111111", resp.GetCardNumber())
+		assert.Equal(t, domain.FPEFF1, ciphers.algorithm)
+		assert.Equal(t, "4111119876541111", ciphers.pan)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		srv := NewFPEServer(&mockPANCipherService{err: errors.NewErrorf(errors.InvalidArgument, "bad")})
+
+		_, err := srv.EncryptPAN(ctx, &cardsv1.EncryptPANRequest{CardNumber: "4111"})
+		require.Error(t, err)
+	})
+}
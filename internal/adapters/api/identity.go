@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// AppIDHeader carries the app identity resolved by the API gateway.
+	AppIDHeader = "x-app-id"
+
+	anonymousApp = "anonymous"
+)
+
+type identityKey struct{}
+
+// Identity describes the caller of an RPC. Subject is only set for callers
+// authenticated with a client certificate or a bearer token, and Scopes only for the latter.
+type Identity struct {
+	AppID   string
+	Subject string
+	Scopes  []string
+	Peer    string
+}
+
+// IdentityFromContext returns the identity attached by an interceptor, falling back to an
+// anonymous caller known only by the peer address of the connection.
+func IdentityFromContext(ctx context.Context) Identity {
+	if id, ok := ctx.Value(identityKey{}).(Identity); ok {
+		return id
+	}
+
+	return Identity{AppID: anonymousApp, Peer: peerIP(ctx)}
+}
+
+// GatewayIdentityInterceptor takes the app ID from the header set by the API gateway. Any
+// client can send the header, so it is only installed when every request passes through a
+// gateway that sets it. Authentication interceptors later in the chain take precedence.
+func GatewayIdentityInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(AppIDHeader); len(values) > 0 && values[0] != "" {
+				ctx = withIdentity(ctx, Identity{AppID: values[0], Peer: peerIP(ctx)})
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func withIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	if addr, ok := p.Addr.(*net.TCPAddr); ok {
+		return addr.IP.String()
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
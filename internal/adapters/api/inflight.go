@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// InFlight counts the requests being handled so shutdown can wait for them and report
+// how many it had to abandon.
+type InFlight struct {
+	mu     sync.Mutex
+	active int64
+	idle   chan struct{}
+}
+
+func NewInFlight() *InFlight {
+	idle := make(chan struct{})
+	close(idle)
+
+	return &InFlight{idle: idle}
+}
+
+func (f *InFlight) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		f.start()
+		defer f.done()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor counts streams, except for health watches and reflection, which last as
+// long as their callers want.
+func (f *InFlight) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if matchesService(info.FullMethod, unboundedMethods) {
+			return handler(srv, ss)
+		}
+
+		f.start()
+		defer f.done()
+
+		return handler(srv, ss)
+	}
+}
+
+func (f *InFlight) Active() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.active
+}
+
+// Idle returns a channel that is closed once no request is in flight.
+func (f *InFlight) Idle() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.idle
+}
+
+func (f *InFlight) start() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.active == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.active++
+}
+
+func (f *InFlight) done() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.active--
+	if f.active == 0 {
+		close(f.idle)
+	}
+}
+
+// Drain stops the server from accepting new work and waits up to timeout for in-flight
+// requests to finish, then stops it forcibly. Streams that are not counted, like health
+// watches, are closed as soon as the rest is done. It returns how many requests were
+// abandoned.
+func Drain(server *grpc.Server, inFlight *InFlight, timeout time.Duration) int64 {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		return 0
+	case <-inFlight.Idle():
+		server.Stop()
+		return 0
+	case <-timer.C:
+	}
+
+	// Stop closes the connections and cancels the remaining requests, but does not wait for
+	// handlers that ignore their context, so neither do we.
+	abandoned := inFlight.Active()
+	server.Stop()
+
+	return abandoned
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/protos/gen/go/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type blockingService struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingService) ValidateCardNumber(ctx context.Context, cardNumber string) (*domain.CardInfo, error) {
+	s.started <- struct{}{}
+
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return &domain.CardInfo{CardNumber: cardNumber, CardProvider: "VISA"}, nil
+}
+
+func setupDrainServer(t *testing.T) (*grpc.Server, *InFlight, *blockingService, pb.CardsServiceClient) {
+	listener := bufconn.Listen(bufSize)
+	inFlight := NewInFlight()
+	svc := &blockingService{started: make(chan struct{}, 10), release: make(chan struct{})}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(inFlight.UnaryInterceptor()))
+	pb.RegisterCardsServiceServer(server, NewServer(svc))
+
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return server, inFlight, svc, pb.NewCardsServiceClient(conn)
+}
+
+func TestDrain(t *testing.T) {
+	req := &pb.ValidateCardNumberRequest{CardNumber: "4111111111111111"}
+
+	t.Run("Waits For In-Flight Requests", func(t *testing.T) {
+		server, inFlight, svc, client := setupDrainServer(t)
+
+		errs := make(chan error, 1)
+		go func() {
+			_, err := client.ValidateCardNumber(context.Background(), req)
+			errs <- err
+		}()
+		<-svc.started
+		assert.Equal(t, int64(1), inFlight.Active())
+
+		drained := make(chan int64, 1)
+		go func() { drained <- Drain(server, inFlight, 5*time.Second) }()
+
+		time.Sleep(50 * time.Millisecond)
+		close(svc.release)
+
+		assert.Equal(t, int64(0), <-drained)
+		assert.NoError(t, <-errs)
+		assert.Equal(t, int64(0), inFlight.Active())
+	})
+
+	t.Run("Abandons Requests After Timeout", func(t *testing.T) {
+		server, inFlight, svc, client := setupDrainServer(t)
+
+		errs := make(chan error, 2)
+		for range 2 {
+			go func() {
+				_, err := client.ValidateCardNumber(context.Background(), req)
+				errs <- err
+			}()
+			<-svc.started
+		}
+
+		abandoned := Drain(server, inFlight, 50*time.Millisecond)
+		assert.Equal(t, int64(2), abandoned)
+
+		assert.Error(t, <-errs)
+		assert.Error(t, <-errs)
+	})
+
+	t.Run("Health Watches Do Not Hold Up Shutdown", func(t *testing.T) {
+		listener := bufconn.Listen(bufSize)
+		inFlight := NewInFlight()
+		server := grpc.NewServer(grpc.ChainStreamInterceptor(inFlight.StreamInterceptor()))
+		healthpb.RegisterHealthServer(server, health.NewServer())
+		go func() { _ = server.Serve(listener) }()
+
+		conn, err := grpc.NewClient(
+			"passthrough:///bufnet",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+
+		watch, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = watch.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), inFlight.Active())
+
+		start := time.Now()
+		assert.Equal(t, int64(0), Drain(server, inFlight, 5*time.Second))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("Idle", func(t *testing.T) {
+		inFlight := NewInFlight()
+
+		select {
+		case <-inFlight.Idle():
+		default:
+			t.Fatal("a new tracker is idle")
+		}
+
+		inFlight.start()
+		idle := inFlight.Idle()
+		select {
+		case <-idle:
+			t.Fatal("not idle while a request is in flight")
+		default:
+		}
+
+		inFlight.done()
+		<-idle
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"cards-service/internal/core/ports"
+	"context"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RetryAfterHeader tells rejected callers how many seconds to wait before retrying.
+const RetryAfterHeader = "retry-after"
+
+// unlimitedServices are never rate limited so probes and usage queries keep working.
+var unlimitedServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+	"/cards.v1.UsageService/",
+}
+
+func RateLimitInterceptor(limiter ports.RateLimiter, logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if matchesService(info.FullMethod, unlimitedServices) {
+			return handler(ctx, req)
+		}
+
+		id := IdentityFromContext(ctx)
+
+		decision, err := limiter.Allow(ctx, id.AppID, id.Peer)
+		if err != nil {
+			// Failing open keeps the service available when the limiter store is down.
+			logger.Warn("rate limiter unavailable", zap.String("method", info.FullMethod), zap.Error(err))
+			return handler(ctx, req)
+		}
+
+		if !decision.Allowed {
+			retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+			_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.Itoa(retryAfter)))
+
+			return nil, errors.NewErrorf(errors.QuotaExceeded, "request limit exceeded: %s", decision.Reason)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func matchesService(method string, services []string) bool {
+	for _, prefix := range services {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
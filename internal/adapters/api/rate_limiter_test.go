@@ -0,0 +1,107 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/protos/gen/go/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockRateLimiter struct {
+	decision *domain.LimitDecision
+	err      error
+	appID    string
+	usage    *domain.Usage
+}
+
+func (m *mockRateLimiter) Allow(ctx context.Context, appID string, peer string) (*domain.LimitDecision, error) {
+	m.appID = appID
+	return m.decision, m.err
+}
+
+func (m *mockRateLimiter) Usage(ctx context.Context, appID string) (*domain.Usage, error) {
+	m.appID = appID
+	return m.usage, m.err
+}
+
+func (m *mockRateLimiter) SetLimits(*domain.RateLimits) {}
+
+func setupLimitedServer(t *testing.T, limiter *mockRateLimiter) (pb.CardsServiceClient, func()) {
+	listener := bufconn.Listen(bufSize)
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(GatewayIdentityInterceptor(), RateLimitInterceptor(limiter, zap.NewNop())))
+	pb.RegisterCardsServiceServer(server, NewServer(&mockAppService{cardInfo: &domain.CardInfo{CardNumber: "4111111111111111"}}))
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	return pb.NewCardsServiceClient(conn), func() {
+		server.Stop()
+		conn.Close()
+	}
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	req := &pb.ValidateCardNumberRequest{CardNumber: "4111111111111111"}
+
+	t.Run("Allowed", func(t *testing.T) {
+		limiter := &mockRateLimiter{decision: &domain.LimitDecision{Allowed: true}}
+		client, cleanup := setupLimitedServer(t, limiter)
+		defer cleanup()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), AppIDHeader, "app-1")
+		resp, err := client.ValidateCardNumber(ctx, req)
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, "app-1", limiter.appID)
+	})
+
+	t.Run("Rejected With Retry After", func(t *testing.T) {
+		limiter := &mockRateLimiter{decision: &domain.LimitDecision{
+			Reason:     domain.ReasonAppRateLimited,
+			RetryAfter: 1500 * time.Millisecond,
+		}}
+		client, cleanup := setupLimitedServer(t, limiter)
+		defer cleanup()
+
+		var header metadata.MD
+		resp, err := client.ValidateCardNumber(context.Background(), req, grpc.Header(&header))
+
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"2"}, header.Get(RetryAfterHeader))
+		assert.Equal(t, anonymousApp, limiter.appID)
+	})
+
+	t.Run("Fails Open", func(t *testing.T) {
+		limiter := &mockRateLimiter{err: assert.AnError}
+		client, cleanup := setupLimitedServer(t, limiter)
+		defer cleanup()
+
+		resp, err := client.ValidateCardNumber(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+	})
+}
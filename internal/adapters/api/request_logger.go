@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 func RequestLogInterceptor(l *zap.Logger) logging.Logger {
 	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
-		f := make([]zap.Field, 0, len(fields)/2)
+		f := make([]zap.Field, 0, len(fields)/2+2)
 
 		for i := 0; i < len(fields); i += 2 {
 			key := fields[i]
@@ -28,6 +29,10 @@ func RequestLogInterceptor(l *zap.Logger) logging.Logger {
 			}
 		}
 
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			f = append(f, zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+		}
+
 		logger := l.WithOptions(zap.AddCallerSkip(1)).With(f...)
 
 		switch lvl {
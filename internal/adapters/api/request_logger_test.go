@@ -0,0 +1,67 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"net"
+	"testing"
+
+	grpclogging "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/mwinyimoha/protos/gen/go/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRequestLogInterceptor(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	provider := sdktrace.NewTracerProvider()
+	listener := bufconn.Listen(bufSize)
+
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithTracerProvider(provider),
+			otelgrpc.WithPropagators(propagation.TraceContext{}),
+		)),
+		grpc.UnaryInterceptor(grpclogging.UnaryServerInterceptor(RequestLogInterceptor(zap.New(core)))),
+	)
+	pb.RegisterCardsServiceServer(server, NewServer(&mockAppService{cardInfo: &domain.CardInfo{}}))
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.AppendToOutgoingContext(
+		context.Background(),
+		"traceparent", "00-"+traceID+"-00f067aa0ba902b7-01",
+	)
+
+	_, err = pb.NewCardsServiceClient(conn).ValidateCardNumber(ctx, &pb.ValidateCardNumberRequest{CardNumber: "4111111111111111"})
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("finished call").All()
+	require.Len(t, entries, 1)
+
+	fields := entries[0].ContextMap()
+	assert.Equal(t, traceID, fields["trace_id"])
+	assert.NotEmpty(t, fields["span_id"])
+	assert.Equal(t, "ValidateCardNumber", fields["grpc.method"])
+}
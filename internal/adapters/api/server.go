@@ -3,8 +3,27 @@ package api
 import (
 	"cards-service/internal/core/ports"
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/mwinyimoha/protos/gen/go/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Response details the response message of CardsService has no fields for are sent as
+// headers. ValidationService returns the same as response fields.
+const (
+	// BinSourceHeader names the BIN data set that identified the card.
+	BinSourceHeader = "x-bin-source"
+	// RiskSignalsHeader lists the risk signals the validation raised, separated by commas.
+	RiskSignalsHeader = "x-risk-signals"
+	// AcceptedHeader is "false" when the policy of the calling app does not accept the card.
+	AcceptedHeader = "x-accepted"
+	// PolicyReasonHeader explains why the policy of the calling app does not accept the card.
+	PolicyReasonHeader = "x-policy-reason"
+	// PolicyRuleHeader names the custom rule of the calling app the card failed.
+	PolicyRuleHeader = "x-policy-rule"
 )
 
 type Server struct {
@@ -17,11 +36,26 @@ func NewServer(svc ports.AppService) *Server {
 }
 
 func (srv *Server) ValidateCardNumber(ctx context.Context, req *pb.ValidateCardNumberRequest) (*pb.ValidateCardNumberResponse, error) {
-	cardInfo, err := srv.service.ValidateCardNumber(req.GetCardNumber())
+	cardInfo, err := srv.service.ValidateCardNumber(ctx, req.GetCardNumber())
 	if err != nil {
 		return nil, err
 	}
 
+	header := metadata.Pairs(AcceptedHeader, strconv.FormatBool(cardInfo.PolicyReason == ""))
+	if cardInfo.PolicyReason != "" {
+		header.Set(PolicyReasonHeader, cardInfo.PolicyReason)
+	}
+	if cardInfo.PolicyRule != "" {
+		header.Set(PolicyRuleHeader, cardInfo.PolicyRule)
+	}
+	if cardInfo.Bin != nil && cardInfo.Bin.Source != "" {
+		header.Set(BinSourceHeader, cardInfo.Bin.Source)
+	}
+	if len(cardInfo.RiskSignals) > 0 {
+		header.Set(RiskSignalsHeader, strings.Join(cardInfo.RiskSignals, ","))
+	}
+	_ = grpc.SetHeader(ctx, header)
+
 	return &pb.ValidateCardNumberResponse{
 		CardNumber:    cardInfo.CardNumber,
 		ProviderName:  cardInfo.CardProvider,
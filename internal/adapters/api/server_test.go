@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -22,7 +23,7 @@ type mockAppService struct {
 	err      error
 }
 
-func (m *mockAppService) ValidateCardNumber(cardNumber string) (*domain.CardInfo, error) {
+func (m *mockAppService) ValidateCardNumber(ctx context.Context, cardNumber string) (*domain.CardInfo, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -69,6 +70,8 @@ func TestValidateCardNumberRPC(t *testing.T) {
 			CardNumber:    "4111111111111111",
 			CardProvider:  "Visa",
 			ProviderBadge: "visa",
+			Bin:           &domain.BinInfo{Prefix: "411111", Network: "VISA", Source: "provider"},
+			RiskSignals:   []string{"card_velocity_exceeded", "bin_velocity_exceeded"},
 		}
 		mockSvc := &mockAppService{cardInfo: expected}
 
@@ -77,16 +80,45 @@ func TestValidateCardNumberRPC(t *testing.T) {
 
 		client := pb.NewCardsServiceClient(conn)
 
+		var header metadata.MD
 		req := &pb.ValidateCardNumberRequest{CardNumber: expected.CardNumber}
-		resp, err := client.ValidateCardNumber(context.Background(), req)
+		resp, err := client.ValidateCardNumber(context.Background(), req, grpc.Header(&header))
 
 		require.NoError(t, err)
+		assert.Equal(t, []string{"provider"}, header.Get(BinSourceHeader))
+		assert.Equal(t, []string{"card_velocity_exceeded,bin_velocity_exceeded"}, header.Get(RiskSignalsHeader))
+		assert.Equal(t, []string{"true"}, header.Get(AcceptedHeader))
+		assert.Empty(t, header.Get(PolicyReasonHeader))
 		require.NotNil(t, resp)
 		assert.Equal(t, expected.CardNumber, resp.CardNumber)
 		assert.Equal(t, expected.CardProvider, resp.ProviderName)
 		assert.Equal(t, expected.ProviderBadge, resp.ProviderBadge)
 	})
 
+	t.Run("Not Accepted By Policy", func(t *testing.T) {
+		mockSvc := &mockAppService{cardInfo: &domain.CardInfo{
+			CardNumber:   "378282246310005",
+			CardProvider: "AMEX",
+			PolicyReason: domain.ReasonRuleNotSatisfied,
+			PolicyRule:   "no-amex",
+		}}
+
+		conn, cleanup := setupGRPCServer(t, mockSvc)
+		defer cleanup()
+
+		client := pb.NewCardsServiceClient(conn)
+
+		var header metadata.MD
+		req := &pb.ValidateCardNumberRequest{CardNumber: "378282246310005"}
+		resp, err := client.ValidateCardNumber(context.Background(), req, grpc.Header(&header))
+
+		require.NoError(t, err)
+		assert.Equal(t, "AMEX", resp.ProviderName)
+		assert.Equal(t, []string{"false"}, header.Get(AcceptedHeader))
+		assert.Equal(t, []string{domain.ReasonRuleNotSatisfied}, header.Get(PolicyReasonHeader))
+		assert.Equal(t, []string{"no-amex"}, header.Get(PolicyRuleHeader))
+	})
+
 	t.Run("Error", func(t *testing.T) {
 		mockErr := assert.AnError
 		mockSvc := &mockAppService{err: mockErr}
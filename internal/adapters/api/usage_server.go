@@ -0,0 +1,44 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type UsageServer struct {
+	cardsv1.UnimplementedUsageServiceServer
+	limiter ports.RateLimiter
+}
+
+func NewUsageServer(limiter ports.RateLimiter) *UsageServer {
+	return &UsageServer{limiter: limiter}
+}
+
+func (srv *UsageServer) GetUsage(ctx context.Context, req *cardsv1.GetUsageRequest) (*cardsv1.GetUsageResponse, error) {
+	id := IdentityFromContext(ctx)
+
+	usage, err := srv.limiter.Usage(ctx, id.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.GetUsageResponse{
+		AppId:             usage.AppID,
+		RequestsPerSecond: usage.RequestsPerSecond,
+		Burst:             int32(usage.Burst),
+		Daily:             toQuotaUsage(usage.Daily),
+		Monthly:           toQuotaUsage(usage.Monthly),
+	}, nil
+}
+
+func toQuotaUsage(q domain.QuotaUsage) *cardsv1.QuotaUsage {
+	return &cardsv1.QuotaUsage{
+		Used:     q.Used,
+		Limit:    q.Limit,
+		ResetsAt: timestamppb.New(q.ResetsAt),
+	}
+}
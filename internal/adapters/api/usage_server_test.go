@@ -0,0 +1,48 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUsage(t *testing.T) {
+	resetsAt := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		limiter := &mockRateLimiter{usage: &domain.Usage{
+			AppID:             "app-1",
+			RequestsPerSecond: 5,
+			Burst:             10,
+			Daily:             domain.QuotaUsage{Used: 3, Limit: 100, ResetsAt: resetsAt},
+			Monthly:           domain.QuotaUsage{Used: 30, Limit: 1000, ResetsAt: resetsAt},
+		}}
+		srv := NewUsageServer(limiter)
+
+		ctx := withIdentity(context.Background(), Identity{AppID: "app-1"})
+		resp, err := srv.GetUsage(ctx, &cardsv1.GetUsageRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "app-1", limiter.appID)
+		assert.Equal(t, "app-1", resp.AppId)
+		assert.Equal(t, float64(5), resp.RequestsPerSecond)
+		assert.Equal(t, int32(10), resp.Burst)
+		assert.Equal(t, int64(3), resp.Daily.Used)
+		assert.Equal(t, int64(1000), resp.Monthly.Limit)
+		assert.Equal(t, resetsAt, resp.Daily.ResetsAt.AsTime())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		srv := NewUsageServer(&mockRateLimiter{err: assert.AnError})
+
+		resp, err := srv.GetUsage(context.Background(), &cardsv1.GetUsageRequest{})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"cards-service/internal/core/ports"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+)
+
+// ValidationServer validates card numbers like Server, returning everything the validation
+// found out in the response rather than in headers.
+type ValidationServer struct {
+	cardsv1.UnimplementedValidationServiceServer
+	service ports.AppService
+}
+
+func NewValidationServer(svc ports.AppService) *ValidationServer {
+	return &ValidationServer{service: svc}
+}
+
+func (srv *ValidationServer) ValidateCard(ctx context.Context, req *cardsv1.ValidateCardRequest) (*cardsv1.ValidateCardResponse, error) {
+	cardInfo, err := srv.service.ValidateCardNumber(ctx, req.GetCardNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &cardsv1.ValidateCardResponse{
+		CardNumber:    cardInfo.CardNumber,
+		ProviderName:  cardInfo.CardProvider,
+		ProviderBadge: cardInfo.ProviderBadge,
+		RiskSignals:   cardInfo.RiskSignals,
+		Accepted:      cardInfo.PolicyReason == "",
+		PolicyReason:  cardInfo.PolicyReason,
+		PolicyRule:    cardInfo.PolicyRule,
+	}
+	if cardInfo.Bin != nil {
+		resp.BinSource = cardInfo.Bin.Source
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"testing"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationServer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Details Are Response Fields", func(t *testing.T) {
+		srv := NewValidationServer(&mockAppService{cardInfo: &domain.CardInfo{
+			CardNumber:    "4111111111111111",
+			CardProvider:  "VISA",
+			ProviderBadge: "visa",
+			Bin:           &domain.BinInfo{Prefix: "411111", Network: "VISA", Source: "local"},
+			RiskSignals:   []string{"card_velocity_exceeded"},
+		}})
+
+		resp, err := srv.ValidateCard(ctx, &cardsv1.ValidateCardRequest{CardNumber: "4111111111111111"})
+		require.NoError(t, err)
+		assert.Equal(t, "4111111111111111", resp.GetCardNumber())
+		assert.Equal(t, "VISA", resp.GetProviderName())
+		assert.Equal(t, "visa", resp.GetProviderBadge())
+		assert.Equal(t, "local", resp.GetBinSource())
+		assert.Equal(t, []string{"card_velocity_exceeded"}, resp.GetRiskSignals())
+		assert.True(t, resp.GetAccepted())
+		assert.Empty(t, resp.GetPolicyReason())
+	})
+
+	t.Run("Not Accepted", func(t *testing.T) {
+		srv := NewValidationServer(&mockAppService{cardInfo: &domain.CardInfo{
+			CardNumber:   "378282246310005",
+			CardProvider: "AMEX",
+			PolicyReason: domain.ReasonRuleNotSatisfied,
+			PolicyRule:   "no-amex",
+		}})
+
+		resp, err := srv.ValidateCard(ctx, &cardsv1.ValidateCardRequest{CardNumber: "378282246310005"})
+		require.NoError(t, err)
+		assert.False(t, resp.GetAccepted())
+		assert.Equal(t, domain.ReasonRuleNotSatisfied, resp.GetPolicyReason())
+		assert.Equal(t, "no-amex", resp.GetPolicyRule())
+		assert.Empty(t, resp.GetBinSource())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		srv := NewValidationServer(&mockAppService{err: errors.NewErrorf(errors.InvalidArgument, "invalid card number")})
+
+		_, err := srv.ValidateCard(ctx, &cardsv1.ValidateCardRequest{CardNumber: "4111"})
+		require.Error(t, err)
+	})
+}
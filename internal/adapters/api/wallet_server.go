@@ -0,0 +1,97 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WalletServer struct {
+	cardsv1.UnimplementedWalletServiceServer
+	wallet ports.WalletService
+}
+
+func NewWalletServer(wallet ports.WalletService) *WalletServer {
+	return &WalletServer{wallet: wallet}
+}
+
+func (srv *WalletServer) AddCard(ctx context.Context, req *cardsv1.AddCardRequest) (*cardsv1.AddCardResponse, error) {
+	card, err := srv.wallet.AddCard(ctx, req.GetCustomerId(), req.GetCardNumber(), int(req.GetExpiryMonth()), int(req.GetExpiryYear()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.AddCardResponse{Card: toWalletCard(card)}, nil
+}
+
+func (srv *WalletServer) ListCards(ctx context.Context, req *cardsv1.ListCardsRequest) (*cardsv1.ListCardsResponse, error) {
+	cards, err := srv.wallet.ListCards(ctx, req.GetCustomerId())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &cardsv1.ListCardsResponse{Cards: make([]*cardsv1.WalletCard, 0, len(cards))}
+	for i := range cards {
+		resp.Cards = append(resp.Cards, toWalletCard(&cards[i]))
+	}
+
+	return resp, nil
+}
+
+func (srv *WalletServer) GetCard(ctx context.Context, req *cardsv1.GetCardRequest) (*cardsv1.GetCardResponse, error) {
+	card, err := srv.wallet.GetCard(ctx, req.GetCustomerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.GetCardResponse{Card: toWalletCard(card)}, nil
+}
+
+func (srv *WalletServer) RemoveCard(ctx context.Context, req *cardsv1.RemoveCardRequest) (*cardsv1.RemoveCardResponse, error) {
+	if err := srv.wallet.RemoveCard(ctx, req.GetCustomerId(), req.GetToken()); err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.RemoveCardResponse{}, nil
+}
+
+func (srv *WalletServer) SetDefaultCard(ctx context.Context, req *cardsv1.SetDefaultCardRequest) (*cardsv1.SetDefaultCardResponse, error) {
+	card, err := srv.wallet.SetDefaultCard(ctx, req.GetCustomerId(), req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return &cardsv1.SetDefaultCardResponse{Card: toWalletCard(card)}, nil
+}
+
+func (srv *WalletServer) ListExpiringCards(ctx context.Context, req *cardsv1.ListExpiringCardsRequest) (*cardsv1.ListExpiringCardsResponse, error) {
+	cards, err := srv.wallet.ListExpiringCards(ctx, int(req.GetWithinDays()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &cardsv1.ListExpiringCardsResponse{Cards: make([]*cardsv1.WalletCard, 0, len(cards))}
+	for i := range cards {
+		resp.Cards = append(resp.Cards, toWalletCard(&cards[i]))
+	}
+
+	return resp, nil
+}
+
+func toWalletCard(card *domain.WalletCard) *cardsv1.WalletCard {
+	return &cardsv1.WalletCard{
+		Token:       card.Token,
+		CustomerId:  card.CustomerID,
+		MaskedPan:   card.MaskedPAN,
+		Brand:       card.Brand,
+		Badge:       card.Badge,
+		ExpiryMonth: int32(card.ExpiryMonth),
+		ExpiryYear:  int32(card.ExpiryYear),
+		IsDefault:   card.Default,
+		CreatedAt:   timestamppb.New(card.CreatedAt),
+		Status:      card.Status,
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"cards-service/internal/core/domain"
+	cardsv1 "cards-service/internal/gen/cards/v1"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockWalletService struct {
+	card  *domain.WalletCard
+	err   error
+	calls []string
+}
+
+func (m *mockWalletService) AddCard(ctx context.Context, customerID string, cardNumber string, expiryMonth int, expiryYear int) (*domain.WalletCard, error) {
+	m.calls = append(m.calls, "AddCard:"+customerID+":"+cardNumber)
+	return m.card, m.err
+}
+
+func (m *mockWalletService) ListCards(ctx context.Context, customerID string) ([]domain.WalletCard, error) {
+	m.calls = append(m.calls, "ListCards:"+customerID)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.WalletCard{*m.card}, nil
+}
+
+func (m *mockWalletService) GetCard(ctx context.Context, customerID string, token string) (*domain.WalletCard, error) {
+	m.calls = append(m.calls, "GetCard:"+customerID+":"+token)
+	return m.card, m.err
+}
+
+func (m *mockWalletService) RemoveCard(ctx context.Context, customerID string, token string) error {
+	m.calls = append(m.calls, "RemoveCard:"+customerID+":"+token)
+	return m.err
+}
+
+func (m *mockWalletService) SetDefaultCard(ctx context.Context, customerID string, token string) (*domain.WalletCard, error) {
+	m.calls = append(m.calls, "SetDefaultCard:"+customerID+":"+token)
+	return m.card, m.err
+}
+
+func (m *mockWalletService) ListExpiringCards(ctx context.Context, withinDays int) ([]domain.WalletCard, error) {
+	m.calls = append(m.calls, fmt.Sprintf("ListExpiringCards:%d", withinDays))
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.WalletCard{*m.card}, nil
+}
+
+func TestWalletServer(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	card := &domain.WalletCard{
+		Token:       "tok",
+		CustomerID:  "cus-1",
+		MaskedPAN:   "411111******1111",
+		Brand:       "VISA",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+		Default:     true,
+		Status:      domain.CardStatusActive,
+		CreatedAt:   createdAt,
+	}
+
+	t.Run("Add Card", func(t *testing.T) {
+		wallet := &mockWalletService{card: card}
+		srv := NewWalletServer(wallet)
+
+		resp, err := srv.AddCard(ctx, &cardsv1.AddCardRequest{CustomerId: "cus-1", CardNumber: "4111111111111111", ExpiryMonth: 12, ExpiryYear: 2030})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"AddCard:cus-1:4111111111111111"}, wallet.calls)
+		assert.Equal(t, "tok", resp.Card.Token)
+		assert.Equal(t, "411111******1111", resp.Card.MaskedPan)
+		assert.Equal(t, int32(2030), resp.Card.ExpiryYear)
+		assert.True(t, resp.Card.IsDefault)
+		assert.Equal(t, createdAt, resp.Card.CreatedAt.AsTime())
+		assert.Equal(t, "active", resp.Card.Status)
+	})
+
+	t.Run("Card Operations", func(t *testing.T) {
+		wallet := &mockWalletService{card: card}
+		srv := NewWalletServer(wallet)
+
+		list, err := srv.ListCards(ctx, &cardsv1.ListCardsRequest{CustomerId: "cus-1"})
+		require.NoError(t, err)
+		assert.Len(t, list.Cards, 1)
+
+		_, err = srv.GetCard(ctx, &cardsv1.GetCardRequest{CustomerId: "cus-1", Token: "tok"})
+		require.NoError(t, err)
+		_, err = srv.SetDefaultCard(ctx, &cardsv1.SetDefaultCardRequest{CustomerId: "cus-1", Token: "tok"})
+		require.NoError(t, err)
+		_, err = srv.RemoveCard(ctx, &cardsv1.RemoveCardRequest{CustomerId: "cus-1", Token: "tok"})
+		require.NoError(t, err)
+		expiring, err := srv.ListExpiringCards(ctx, &cardsv1.ListExpiringCardsRequest{WithinDays: 30})
+		require.NoError(t, err)
+		assert.Len(t, expiring.Cards, 1)
+
+		assert.Equal(t, []string{"ListCards:cus-1", "GetCard:cus-1:tok", "SetDefaultCard:cus-1:tok", "RemoveCard:cus-1:tok", "ListExpiringCards:30"}, wallet.calls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		srv := NewWalletServer(&mockWalletService{err: assert.AnError})
+
+		resp, err := srv.GetCard(ctx, &cardsv1.GetCardRequest{CustomerId: "cus-1", Token: "tok"})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
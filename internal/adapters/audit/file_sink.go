@@ -0,0 +1,306 @@
+package audit
+
+import (
+	"bufio"
+	"cards-service/internal/adapters/filelock"
+	"cards-service/internal/core/domain"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// entry is a line in the audit file. Each entry commits to the hash of the one before it,
+// so editing, removing or reordering lines breaks the chain from that point on.
+type entry struct {
+	Seq uint64 `json:"seq"`
+	*domain.AuditEvent
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+func (e *entry) computeHash() (string, error) {
+	unsigned := *e
+	unsigned.Hash = ""
+
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// FileSink appends hash-chained audit entries as JSON lines and rotates the file once it
+// reaches maxBytes. Rotated files are renamed with a timestamp suffix and never deleted.
+type FileSink struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	file     *os.File
+	size     int64
+	seq      uint64
+	lastHash string
+	now      func() time.Time
+	unlock   func()
+	logger   *zap.Logger
+}
+
+func NewFileSink(path string, maxBytes int64, logger *zap.Logger) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to create audit directory")
+	}
+
+	s := &FileSink{path: path, maxBytes: maxBytes, now: time.Now, logger: logger}
+
+	if err := s.recover(); err != nil {
+		return nil, err
+	}
+
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NewExclusiveFileSink is NewFileSink for a file that other processes may write too, such
+// as the trail of the command-line tools. It holds an exclusive lock on the file until Close,
+// so each process continues the chain where the previous one left it.
+func NewExclusiveFileSink(path string, maxBytes int64, logger *zap.Logger) (*FileSink, error) {
+	unlock, err := filelock.Lock(path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewFileSink(path, maxBytes, logger)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	s.unlock = unlock
+
+	return s, nil
+}
+
+func (s *FileSink) Record(ctx context.Context, event *domain.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &entry{Seq: s.seq + 1, AuditEvent: event, PrevHash: s.lastHash}
+
+	hash, err := e.computeHash()
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to hash audit entry")
+	}
+	e.Hash = hash
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to encode audit entry")
+	}
+	line = append(line, '\n')
+
+	if s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to write audit entry")
+	}
+
+	s.seq = e.Seq
+	s.lastHash = e.Hash
+
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unlock != nil {
+		defer s.unlock()
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	return s.file.Close()
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to open audit file")
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return errors.WrapError(err, errors.Internal, "failed to stat audit file")
+	}
+
+	s.file = file
+	s.size = info.Size()
+
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.file.Sync(); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to sync audit file")
+	}
+
+	if err := s.file.Close(); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to close audit file")
+	}
+
+	rotated := s.path + "." + s.now().UTC().Format("20060102T150405.000000000")
+	if err := os.Rename(s.path, rotated); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to rotate audit file")
+	}
+
+	return s.open()
+}
+
+// recover continues the chain from the last entry on disk, looking at the newest
+// rotated file when the current one is missing or empty. A final line cut short in the
+// current file, as a crash while writing leaves it, is removed; anything else that cannot
+// be read is corruption.
+func (s *FileSink) recover() error {
+	rotated, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to list rotated audit files")
+	}
+	slices.Sort(rotated)
+	slices.Reverse(rotated)
+
+	candidates := append([]string{s.path}, rotated...)
+	for _, path := range candidates {
+		last, torn, err := lastEntry(path)
+		if err != nil {
+			return err
+		}
+
+		if torn >= 0 {
+			if path != s.path {
+				return errors.NewErrorf(errors.Internal, "corrupt audit entry in %s", path)
+			}
+
+			if err := os.Truncate(path, torn); err != nil {
+				return errors.WrapError(err, errors.Internal, "failed to remove partial audit entry")
+			}
+			s.logger.Warn("removed a partial entry from the end of the audit file", zap.String("path", path), zap.Int64("offset", torn))
+		}
+
+		if last != nil {
+			s.seq = last.Seq
+			s.lastHash = last.Hash
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// lastEntry returns the last entry in the file at path, and the offset of its final line
+// when that line is incomplete, or -1.
+func lastEntry(path string) (*entry, int64, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, -1, nil
+	}
+	if err != nil {
+		return nil, -1, errors.WrapError(err, errors.Internal, "failed to open audit file")
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, -1, errors.WrapError(err, errors.Internal, "failed to stat audit file")
+	}
+
+	var last *entry
+	var offset int64
+	var bad error
+	badAt := int64(-1)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if bad != nil {
+			return nil, -1, errors.WrapError(bad, errors.Internal, "corrupt audit entry in %s", path)
+		}
+
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			bad, badAt = err, offset
+		} else {
+			last = &e
+		}
+		offset += int64(len(scanner.Bytes())) + 1
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, -1, errors.WrapError(err, errors.Internal, "failed to read audit file")
+	}
+
+	// Only a final line without its newline was cut short; a complete one is corrupt.
+	if bad != nil && offset <= info.Size() {
+		return nil, -1, errors.WrapError(bad, errors.Internal, "corrupt audit entry in %s", path)
+	}
+
+	return last, badAt, nil
+}
+
+// VerifyChain checks every entry read from r against its hash and its predecessor,
+// starting from prevHash, and returns the hash of the last entry.
+func VerifyChain(r io.Reader, prevHash string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return "", errors.WrapError(err, errors.PreconditionFailed, "line %d is not a valid audit entry", line)
+		}
+
+		if e.PrevHash != prevHash {
+			return "", errors.NewErrorf(errors.PreconditionFailed, "line %d does not follow the previous entry", line)
+		}
+
+		hash, err := e.computeHash()
+		if err != nil {
+			return "", errors.WrapError(err, errors.Internal, "failed to hash line %d", line)
+		}
+
+		if hash != e.Hash {
+			return "", errors.NewErrorf(errors.PreconditionFailed, "line %d has been modified", line)
+		}
+
+		prevHash = e.Hash
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", errors.WrapError(err, errors.Internal, "failed to read audit entries")
+	}
+
+	return prevHash, nil
+}
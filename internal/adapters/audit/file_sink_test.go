@@ -0,0 +1,217 @@
+package audit
+
+import (
+	"bytes"
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newEvent(outcome string) *domain.AuditEvent {
+	return &domain.AuditEvent{
+		Timestamp:   time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		AppID:       "checkout",
+		Peer:        "10.0.0.1",
+		Method:      "/cards.CardsService/ValidateCardNumber",
+		MaskedPAN:   domain.MaskPAN("4111111111111111"),
+		Fingerprint: domain.Fingerprint([]byte("key"), "4111111111111111"),
+		Network:     "VISA",
+		Outcome:     outcome,
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Writes A Verifiable Chain", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		sink, err := NewFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+
+		for range 3 {
+			require.NoError(t, sink.Record(ctx, newEvent("accepted")))
+		}
+		require.NoError(t, sink.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 3)
+
+		var first map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, float64(1), first["seq"])
+		assert.Equal(t, "411111******1111", first["masked_pan"])
+		assert.Equal(t, "", first["prev_hash"])
+		assert.NotContains(t, string(data), "4111111111111111")
+
+		last, err := VerifyChain(bytes.NewReader(data), "")
+		assert.NoError(t, err)
+		assert.Equal(t, sink.lastHash, last)
+	})
+
+	t.Run("Detects Tampering", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		sink, err := NewFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+
+		require.NoError(t, sink.Record(ctx, newEvent("rejected")))
+		require.NoError(t, sink.Record(ctx, newEvent("accepted")))
+		require.NoError(t, sink.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		modified := strings.Replace(string(data), `"outcome":"rejected"`, `"outcome":"accepted"`, 1)
+		_, err = VerifyChain(strings.NewReader(modified), "")
+		assert.ErrorContains(t, err, "line 1 has been modified")
+
+		lines := strings.SplitAfter(string(data), "\n")
+		_, err = VerifyChain(strings.NewReader(lines[1]), "")
+		assert.ErrorContains(t, err, "line 1 does not follow the previous entry")
+	})
+
+	t.Run("Rotates And Continues The Chain", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "audit.log")
+		sink, err := NewFileSink(path, 600, zap.NewNop())
+		require.NoError(t, err)
+
+		tick := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		sink.now = func() time.Time {
+			tick = tick.Add(time.Second)
+			return tick
+		}
+
+		for range 6 {
+			require.NoError(t, sink.Record(ctx, newEvent("accepted")))
+		}
+		require.NoError(t, sink.Close())
+
+		rotated, err := filepath.Glob(path + ".*")
+		require.NoError(t, err)
+		require.NotEmpty(t, rotated)
+
+		prev := ""
+		for _, file := range append(rotated, path) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			prev, err = VerifyChain(bytes.NewReader(data), prev)
+			require.NoError(t, err, file)
+		}
+		assert.Equal(t, sink.lastHash, prev)
+	})
+
+	t.Run("Resumes After Reopen", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		sink, err := NewFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, sink.Record(ctx, newEvent("accepted")))
+		require.NoError(t, sink.Close())
+
+		reopened, err := NewFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), reopened.seq)
+		require.NoError(t, reopened.Record(ctx, newEvent("rejected")))
+		require.NoError(t, reopened.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		_, err = VerifyChain(bytes.NewReader(data), "")
+		assert.NoError(t, err)
+	})
+	t.Run("Removes A Partial Last Line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		sink, err := NewFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, sink.Record(ctx, newEvent("accepted")))
+		require.NoError(t, sink.Close())
+
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"seq":2,"outco`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		reopened, err := NewFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), reopened.seq)
+		require.NoError(t, reopened.Record(ctx, newEvent("rejected")))
+		require.NoError(t, reopened.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		_, err = VerifyChain(bytes.NewReader(data), "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Refuses A Corrupt File", func(t *testing.T) {
+		for name, tail := range map[string]string{
+			"Complete Last Line": "{\"seq\":2,\"outco\n",
+			"Earlier Line":       "{\"seq\":2,\"outco\n{\"seq\":3}",
+		} {
+			t.Run(name, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "audit.log")
+				sink, err := NewFileSink(path, 1<<20, zap.NewNop())
+				require.NoError(t, err)
+				require.NoError(t, sink.Record(ctx, newEvent("accepted")))
+				require.NoError(t, sink.Close())
+
+				f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+				require.NoError(t, err)
+				_, err = f.WriteString(tail)
+				require.NoError(t, err)
+				require.NoError(t, f.Close())
+
+				_, err = NewFileSink(path, 1<<20, zap.NewNop())
+				assert.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("Exclusive Sinks Take Turns", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cli.log")
+		first, err := NewExclusiveFileSink(path, 1<<20, zap.NewNop())
+		require.NoError(t, err)
+
+		opened := make(chan *FileSink)
+		go func() {
+			second, err := NewExclusiveFileSink(path, 1<<20, zap.NewNop())
+			assert.NoError(t, err)
+			opened <- second
+		}()
+
+		require.NoError(t, first.Record(ctx, newEvent("accepted")))
+		select {
+		case <-opened:
+			t.Fatal("second sink opened while the first held the file")
+		case <-time.After(50 * time.Millisecond):
+		}
+		require.NoError(t, first.Close())
+
+		second := <-opened
+		require.NotNil(t, second)
+		assert.Equal(t, uint64(1), second.seq)
+		require.NoError(t, second.Record(ctx, newEvent("rejected")))
+		require.NoError(t, second.Close())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		_, err = VerifyChain(bytes.NewReader(data), "")
+		assert.NoError(t, err)
+	})
+}
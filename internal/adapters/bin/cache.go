@@ -0,0 +1,173 @@
+package bin
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	CacheHit         = "hit"
+	CacheNegativeHit = "negative_hit"
+	CacheMiss        = "miss"
+)
+
+type CacheConfig struct {
+	// Size bounds the number of BINs kept, evicting the least recently used first.
+	Size int
+	TTL  time.Duration
+	// NegativeTTL is how long unknown BINs are remembered. Zero disables negative caching.
+	NegativeTTL time.Duration
+	// SourceTTLs overrides TTL for answers from the given sources, such as the local table
+	// when it stands in for a failing provider. Zero keeps those answers out of the cache.
+	SourceTTLs map[string]time.Duration
+	Metrics    ports.BinCacheMetrics
+}
+
+// Cache keeps recent answers of another BinLookup. Failures other than an unknown BIN are
+// never cached, so they always reach the next lookup.
+type Cache struct {
+	next ports.BinLookup
+	cfg  CacheConfig
+	now  func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+	generation uint64
+}
+
+type cacheEntry struct {
+	bin     string
+	info    *domain.BinInfo
+	err     error
+	expires time.Time
+}
+
+func NewCache(next ports.BinLookup, cfg CacheConfig) *Cache {
+	if cfg.Metrics == nil {
+		cfg.Metrics = noopMetrics{}
+	}
+
+	return &Cache{
+		next:    next,
+		cfg:     cfg,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *Cache) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	c.mu.Lock()
+	entry, ok := c.get(bin)
+	generation := c.generation
+	c.mu.Unlock()
+
+	if ok {
+		if entry.err != nil {
+			c.cfg.Metrics.BinCacheLookup(CacheNegativeHit)
+			return nil, entry.err
+		}
+
+		c.cfg.Metrics.BinCacheLookup(CacheHit)
+		info := *entry.info
+		return &info, nil
+	}
+
+	c.cfg.Metrics.BinCacheLookup(CacheMiss)
+
+	info, err := c.next.Lookup(ctx, bin)
+	switch {
+	case err == nil:
+		ttl, ok := c.cfg.SourceTTLs[info.Source]
+		if !ok {
+			ttl = c.cfg.TTL
+		}
+		if ttl > 0 {
+			stored := *info
+			c.put(generation, &cacheEntry{bin: bin, info: &stored, expires: c.now().Add(ttl)})
+		}
+	case isUnknownBin(err) && c.cfg.NegativeTTL > 0:
+		c.put(generation, &cacheEntry{bin: bin, err: err, expires: c.now().Add(c.cfg.NegativeTTL)})
+	}
+
+	return info, err
+}
+
+// Invalidate drops every cached answer, including those of lookups still in progress.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.order.Init()
+	c.generation++
+}
+
+// Len returns the number of cached BINs, including expired ones not yet evicted.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache) get(bin string) (*cacheEntry, bool) {
+	elem, ok := c.entries[bin]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, bin)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry, true
+}
+
+func (c *Cache) put(generation uint64, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The data was reloaded while this lookup was running, so its answer may be stale.
+	if generation != c.generation {
+		return
+	}
+
+	if elem, ok := c.entries[entry.bin]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[entry.bin] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.cfg.Size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).bin)
+		c.cfg.Metrics.BinCacheEvicted()
+	}
+}
+
+func isUnknownBin(err error) bool {
+	appErr, ok := err.(*errors.Error)
+	return ok && appErr.ErrCode == errors.NotFound
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) BinCacheLookup(string) {}
+
+func (noopMetrics) BinCacheEvicted() {}
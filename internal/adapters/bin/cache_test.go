@@ -0,0 +1,253 @@
+package bin
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLookup struct {
+	mu    sync.Mutex
+	calls map[string]int
+	err   error
+}
+
+func (l *countingLookup) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.calls == nil {
+		l.calls = make(map[string]int)
+	}
+	l.calls[bin]++
+
+	if l.err != nil {
+		return nil, l.err
+	}
+	if bin[0] == '9' {
+		return nil, domain.UnknownBinError(bin)
+	}
+
+	return &domain.BinInfo{Prefix: bin[:1], Network: "VISA", Source: SourceLocal}, nil
+}
+
+func (l *countingLookup) count(bin string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.calls[bin]
+}
+
+type fakeCacheMetrics struct {
+	mu        sync.Mutex
+	results   map[string]int
+	evictions int
+}
+
+func (m *fakeCacheMetrics) BinCacheLookup(result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.results == nil {
+		m.results = make(map[string]int)
+	}
+	m.results[result]++
+}
+
+func (m *fakeCacheMetrics) BinCacheEvicted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.evictions++
+}
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newCache(next *countingLookup, cfg CacheConfig) (*Cache, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	cache := NewCache(next, cfg)
+	cache.now = clock.Now
+
+	return cache, clock
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Hits And Misses", func(t *testing.T) {
+		next := &countingLookup{}
+		metrics := &fakeCacheMetrics{}
+		cache, _ := newCache(next, CacheConfig{Size: 10, TTL: time.Minute, Metrics: metrics})
+
+		for range 3 {
+			info, err := cache.Lookup(ctx, "41111111")
+			require.NoError(t, err)
+			assert.Equal(t, "VISA", info.Network)
+		}
+
+		assert.Equal(t, 1, next.count("41111111"))
+		assert.Equal(t, map[string]int{CacheMiss: 1, CacheHit: 2}, metrics.results)
+	})
+
+	t.Run("Answers Are Copies", func(t *testing.T) {
+		cache, _ := newCache(&countingLookup{}, CacheConfig{Size: 10, TTL: time.Minute})
+
+		info, err := cache.Lookup(ctx, "41111111")
+		require.NoError(t, err)
+		info.Network = "CHANGED"
+
+		info, err = cache.Lookup(ctx, "41111111")
+		require.NoError(t, err)
+		assert.Equal(t, "VISA", info.Network)
+	})
+
+	t.Run("Expires Entries", func(t *testing.T) {
+		next := &countingLookup{}
+		cache, clock := newCache(next, CacheConfig{Size: 10, TTL: time.Minute})
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		clock.Advance(59 * time.Second)
+		_, _ = cache.Lookup(ctx, "41111111")
+		assert.Equal(t, 1, next.count("41111111"))
+
+		clock.Advance(time.Second)
+		_, _ = cache.Lookup(ctx, "41111111")
+		assert.Equal(t, 2, next.count("41111111"))
+	})
+
+	t.Run("Source TTLs", func(t *testing.T) {
+		next := &countingLookup{}
+		cache, clock := newCache(next, CacheConfig{Size: 10, TTL: time.Hour, SourceTTLs: map[string]time.Duration{SourceLocal: time.Minute}})
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		_, _ = cache.Lookup(ctx, "41111111")
+		assert.Equal(t, 1, next.count("41111111"))
+
+		clock.Advance(time.Minute)
+		_, _ = cache.Lookup(ctx, "41111111")
+		assert.Equal(t, 2, next.count("41111111"))
+
+		uncached, _ := newCache(next, CacheConfig{Size: 10, TTL: time.Hour, SourceTTLs: map[string]time.Duration{SourceLocal: 0}})
+		_, _ = uncached.Lookup(ctx, "51111111")
+		_, _ = uncached.Lookup(ctx, "51111111")
+		assert.Equal(t, 2, next.count("51111111"))
+		assert.Zero(t, uncached.Len())
+	})
+
+	t.Run("Negative Caching", func(t *testing.T) {
+		next := &countingLookup{}
+		metrics := &fakeCacheMetrics{}
+		cache, clock := newCache(next, CacheConfig{Size: 10, TTL: time.Hour, NegativeTTL: time.Minute, Metrics: metrics})
+
+		for range 2 {
+			_, err := cache.Lookup(ctx, "99999999")
+			require.Error(t, err)
+			assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+		}
+		assert.Equal(t, 1, next.count("99999999"))
+		assert.Equal(t, 1, metrics.results[CacheNegativeHit])
+
+		clock.Advance(time.Minute)
+		_, _ = cache.Lookup(ctx, "99999999")
+		assert.Equal(t, 2, next.count("99999999"))
+	})
+
+	t.Run("Negative Caching Disabled", func(t *testing.T) {
+		next := &countingLookup{}
+		cache, _ := newCache(next, CacheConfig{Size: 10, TTL: time.Hour})
+
+		_, _ = cache.Lookup(ctx, "99999999")
+		_, _ = cache.Lookup(ctx, "99999999")
+		assert.Equal(t, 2, next.count("99999999"))
+	})
+
+	t.Run("Does Not Cache Failures", func(t *testing.T) {
+		next := &countingLookup{err: errors.NewErrorf(errors.ServiceUnavailable, "provider down")}
+		cache, _ := newCache(next, CacheConfig{Size: 10, TTL: time.Hour, NegativeTTL: time.Hour})
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		_, _ = cache.Lookup(ctx, "41111111")
+		assert.Equal(t, 2, next.count("41111111"))
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("Evicts Least Recently Used", func(t *testing.T) {
+		next := &countingLookup{}
+		metrics := &fakeCacheMetrics{}
+		cache, _ := newCache(next, CacheConfig{Size: 2, TTL: time.Hour, Metrics: metrics})
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		_, _ = cache.Lookup(ctx, "42222222")
+		_, _ = cache.Lookup(ctx, "41111111")
+		_, _ = cache.Lookup(ctx, "43333333")
+
+		assert.Equal(t, 2, cache.Len())
+		assert.Equal(t, 1, metrics.evictions)
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		assert.Equal(t, 1, next.count("41111111"))
+		_, _ = cache.Lookup(ctx, "42222222")
+		assert.Equal(t, 2, next.count("42222222"))
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		next := &countingLookup{}
+		cache, _ := newCache(next, CacheConfig{Size: 10, TTL: time.Hour, NegativeTTL: time.Hour})
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		_, _ = cache.Lookup(ctx, "99999999")
+		cache.Invalidate()
+		assert.Equal(t, 0, cache.Len())
+
+		_, _ = cache.Lookup(ctx, "41111111")
+		_, _ = cache.Lookup(ctx, "99999999")
+		assert.Equal(t, 2, next.count("41111111"))
+		assert.Equal(t, 2, next.count("99999999"))
+	})
+
+	t.Run("Concurrent Lookups", func(t *testing.T) {
+		cache, _ := newCache(&countingLookup{}, CacheConfig{Size: 16, TTL: time.Hour, NegativeTTL: time.Hour})
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 200 {
+					_, _ = cache.Lookup(ctx, fmt.Sprintf("4%07d", (i*j)%32))
+					if j%50 == 0 {
+						cache.Invalidate()
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, cache.Len(), 16)
+	})
+}
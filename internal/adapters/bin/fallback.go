@@ -0,0 +1,38 @@
+package bin
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Fallback answers from the primary lookup and turns to the secondary one when the primary
+// fails or does not know the BIN. Answers carry the source that produced them.
+type Fallback struct {
+	primary   ports.BinLookup
+	secondary ports.BinLookup
+	logger    *zap.Logger
+}
+
+func NewFallback(primary, secondary ports.BinLookup, logger *zap.Logger) *Fallback {
+	return &Fallback{primary: primary, secondary: secondary, logger: logger}
+}
+
+func (f *Fallback) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	info, err := f.primary.Lookup(ctx, bin)
+	if err == nil {
+		return info, nil
+	}
+
+	switch {
+	case isUnknownBin(err):
+	case err == ErrBreakerOpen:
+		f.logger.Debug("BIN provider skipped, circuit breaker is open")
+	default:
+		f.logger.Warn("BIN provider failed, falling back", zap.String("bin", domain.MaskBIN(bin)), zap.Error(err))
+	}
+
+	return f.secondary.Lookup(ctx, bin)
+}
@@ -0,0 +1,161 @@
+package bin
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// SourceProvider identifies answers from the external BIN provider.
+const SourceProvider = "provider"
+
+type ProviderConfig struct {
+	// URL of the lookup endpoint. A {bin} placeholder is replaced with the BIN, otherwise
+	// the BIN is appended as the last path segment.
+	URL    string
+	APIKey string
+	// Retries is how many times a failed call is repeated, waiting Backoff, then twice as
+	// long, between attempts.
+	Retries int
+	Backoff time.Duration
+	Breaker *Breaker
+}
+
+// Provider looks BINs up through an HTTP API answering in the binlist.net format. Only the
+// first six digits of a card ever leave the service.
+type Provider struct {
+	cfg    ProviderConfig
+	client *http.Client
+}
+
+type providerResponse struct {
+	Scheme  string `json:"scheme"`
+	Type    string `json:"type"`
+	Prepaid bool   `json:"prepaid"`
+	Bank    struct {
+		Name string `json:"name"`
+	} `json:"bank"`
+	Country struct {
+		Alpha2 string `json:"alpha2"`
+	} `json:"country"`
+}
+
+func NewProvider(cfg ProviderConfig, client *http.Client) *Provider {
+	return &Provider{cfg: cfg, client: client}
+}
+
+func (p *Provider) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	bin = domain.MaskBIN(bin)
+	delay := p.cfg.Backoff
+
+	for attempt := 0; ; attempt++ {
+		info, err := p.call(ctx, bin)
+		if err == nil || err == ErrBreakerOpen || !retryable(err) || attempt >= p.cfg.Retries {
+			return info, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// call makes a single request, guarded by the circuit breaker.
+func (p *Provider) call(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	if p.cfg.Breaker != nil {
+		if err := p.cfg.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	info, err := p.fetch(ctx, bin)
+
+	if p.cfg.Breaker != nil {
+		switch {
+		case ctx.Err() != nil:
+			// The caller gave up, which says nothing about the provider. Let the next call try.
+			p.cfg.Breaker.Release()
+		case retryable(err):
+			p.cfg.Breaker.Failure()
+		default:
+			p.cfg.Breaker.Success()
+		}
+	}
+
+	return info, err
+}
+
+func (p *Provider) fetch(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	url := strings.TrimSuffix(p.cfg.URL, "/") + "/" + bin
+	if strings.Contains(p.cfg.URL, "{bin}") {
+		url = strings.ReplaceAll(p.cfg.URL, "{bin}", bin)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to build BIN provider request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ServiceUnavailable, "failed to call BIN provider")
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, domain.UnknownBinError(bin)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, errors.NewErrorf(errors.ServiceUnavailable, "BIN provider returned %d", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.NewErrorf(errors.Internal, "BIN provider returned %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ServiceUnavailable, "failed to read BIN provider response")
+	}
+
+	var body providerResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to parse BIN provider response")
+	}
+
+	if body.Scheme == "" {
+		return nil, domain.UnknownBinError(bin)
+	}
+
+	cardType := strings.ToLower(body.Type)
+	if body.Prepaid {
+		cardType = "prepaid"
+	}
+
+	return &domain.BinInfo{
+		Prefix:   bin,
+		Network:  strings.ToUpper(body.Scheme),
+		Issuer:   body.Bank.Name,
+		Country:  strings.ToUpper(body.Country.Alpha2),
+		CardType: cardType,
+		Source:   SourceProvider,
+	}, nil
+}
+
+// retryable reports whether a failure may go away by itself, as opposed to a definite answer
+// or a request the provider will never accept.
+func retryable(err error) bool {
+	appErr, ok := err.(*errors.Error)
+	return ok && appErr.ErrCode == errors.ServiceUnavailable
+}
@@ -0,0 +1,253 @@
+package bin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const visaDebit = `{
+	"scheme": "visa",
+	"type": "debit",
+	"prepaid": false,
+	"bank": {"name": "Test Bank"},
+	"country": {"alpha2": "ke"}
+}`
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		var path, auth string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, auth = r.URL.Path, r.Header.Get("Authorization")
+			w.Write([]byte(visaDebit))
+		}))
+		defer server.Close()
+
+		provider := NewProvider(ProviderConfig{URL: server.URL + "/bins/{bin}", APIKey: "secret"}, server.Client())
+
+		info, err := provider.Lookup(ctx, "41111111")
+		require.NoError(t, err)
+		assert.Equal(t, "/bins/411111", path, "only six digits are sent")
+		assert.Equal(t, "Bearer secret", auth)
+		assert.Equal(t, "VISA", info.Network)
+		assert.Equal(t, "Test Bank", info.Issuer)
+		assert.Equal(t, "KE", info.Country)
+		assert.Equal(t, "debit", info.CardType)
+		assert.Equal(t, SourceProvider, info.Source)
+	})
+
+	t.Run("Prepaid", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/411111", r.URL.Path)
+			w.Write([]byte(`{"scheme": "mastercard", "type": "debit", "prepaid": true}`))
+		}))
+		defer server.Close()
+
+		info, err := NewProvider(ProviderConfig{URL: server.URL + "/"}, server.Client()).Lookup(ctx, "41111111")
+		require.NoError(t, err)
+		assert.Equal(t, "prepaid", info.CardType)
+	})
+
+	t.Run("Unknown BIN", func(t *testing.T) {
+		for _, handler := range []http.HandlerFunc{
+			func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) },
+			func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{}`)) },
+		} {
+			server := httptest.NewServer(handler)
+
+			_, err := NewProvider(ProviderConfig{URL: server.URL}, server.Client()).Lookup(ctx, "99999999")
+			require.Error(t, err)
+			assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+
+			server.Close()
+		}
+	})
+
+	t.Run("Retries Transient Failures", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(visaDebit))
+		}))
+		defer server.Close()
+
+		provider := NewProvider(ProviderConfig{URL: server.URL, Retries: 2, Backoff: time.Millisecond}, server.Client())
+
+		info, err := provider.Lookup(ctx, "41111111")
+		require.NoError(t, err)
+		assert.Equal(t, "VISA", info.Network)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Does Not Retry Definite Answers", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		provider := NewProvider(ProviderConfig{URL: server.URL, Retries: 2, Backoff: time.Millisecond}, server.Client())
+
+		_, err := provider.Lookup(ctx, "41111111")
+		require.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("Circuit Breaker", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		breaker := NewBreaker(3, time.Minute)
+		provider := NewProvider(ProviderConfig{URL: server.URL, Retries: 1, Breaker: breaker}, server.Client())
+
+		_, err := provider.Lookup(ctx, "41111111")
+		require.Error(t, err)
+		_, err = provider.Lookup(ctx, "41111111")
+		require.Error(t, err)
+		assert.Equal(t, int32(3), calls.Load(), "the breaker opens on the third failure")
+		assert.Equal(t, BreakerOpen, breaker.State())
+
+		_, err = provider.Lookup(ctx, "41111111")
+		assert.Equal(t, ErrBreakerOpen, err)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("Caller Cancellation Does Not Settle The Breaker", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+		breaker := NewBreaker(2, time.Minute)
+		breaker.now = clock.Now
+		provider := NewProvider(ProviderConfig{URL: server.URL, Breaker: breaker}, server.Client())
+
+		lookup := func() {
+			ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+			defer cancel()
+
+			_, err := provider.Lookup(ctx, "41111111")
+			require.Error(t, err)
+		}
+
+		breaker.Failure()
+		lookup()
+		assert.Equal(t, BreakerClosed, breaker.State())
+		breaker.Failure()
+		assert.Equal(t, BreakerOpen, breaker.State(), "a canceled call does not reset the failures")
+
+		clock.Advance(time.Minute)
+		lookup()
+		assert.Equal(t, BreakerOpen, breaker.State(), "a canceled trial does not close the breaker")
+		assert.NoError(t, breaker.Allow(), "the next call gets the trial instead")
+	})
+}
+
+func TestBreaker(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	breaker := NewBreaker(2, time.Minute)
+	breaker.now = clock.Now
+
+	require.NoError(t, breaker.Allow())
+	breaker.Failure()
+	require.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, BreakerClosed, breaker.State(), "successes reset the failure count")
+
+	breaker.Failure()
+	breaker.Failure()
+	assert.Equal(t, BreakerOpen, breaker.State())
+	assert.Equal(t, ErrBreakerOpen, breaker.Allow())
+
+	clock.Advance(time.Minute)
+	require.NoError(t, breaker.Allow(), "a trial call goes through after the cooldown")
+	assert.Equal(t, ErrBreakerOpen, breaker.Allow(), "only one trial call at a time")
+
+	breaker.Failure()
+	assert.Equal(t, BreakerOpen, breaker.State(), "a failed trial opens the breaker again")
+
+	clock.Advance(time.Minute)
+	require.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, BreakerClosed, breaker.State())
+}
+
+func TestFallback(t *testing.T) {
+	ctx := context.Background()
+
+	table, err := NewTable(writeTable(t, testTable), zap.NewNop())
+	require.NoError(t, err)
+
+	t.Run("Provider Answers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(visaDebit))
+		}))
+		defer server.Close()
+
+		lookup := NewFallback(NewProvider(ProviderConfig{URL: server.URL}, server.Client()), table, zap.NewNop())
+
+		info, err := lookup.Lookup(ctx, "41111111")
+		require.NoError(t, err)
+		assert.Equal(t, SourceProvider, info.Source)
+	})
+
+	t.Run("Provider Down", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		lookup := NewFallback(NewProvider(ProviderConfig{URL: server.URL}, server.Client()), table, zap.NewNop())
+
+		info, err := lookup.Lookup(ctx, "41111199")
+		require.NoError(t, err)
+		assert.Equal(t, SourceLocal, info.Source)
+		assert.Equal(t, "41111199", info.Prefix)
+	})
+
+	t.Run("Provider Unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		lookup := NewFallback(NewProvider(ProviderConfig{URL: server.URL}, http.DefaultClient), table, zap.NewNop())
+
+		info, err := lookup.Lookup(ctx, "51234567")
+		require.NoError(t, err)
+		assert.Equal(t, SourceLocal, info.Source)
+		assert.Equal(t, "MASTERCARD", info.Network)
+	})
+
+	t.Run("Provider Does Not Know The BIN", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		lookup := NewFallback(NewProvider(ProviderConfig{URL: server.URL}, server.Client()), table, zap.NewNop())
+
+		info, err := lookup.Lookup(ctx, "41111100")
+		require.NoError(t, err)
+		assert.Equal(t, SourceLocal, info.Source)
+
+		_, err = lookup.Lookup(ctx, "99999999")
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+	})
+}
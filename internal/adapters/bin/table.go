@@ -0,0 +1,219 @@
+package bin
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SourceLocal identifies answers from the local BIN table.
+const SourceLocal = "local"
+
+// Table answers BIN lookups from an in-memory table, loaded from a CSV file with the columns
+// prefix, network, issuer, country and card_type. Without a file it holds domain.DefaultBins.
+// The longest matching prefix wins.
+type Table struct {
+	path   string
+	logger *zap.Logger
+
+	mu        sync.RWMutex
+	bins      map[string]domain.BinInfo
+	modTime   time.Time
+	listeners []func()
+}
+
+func NewTable(path string, logger *zap.Logger) (*Table, error) {
+	t := &Table{path: path, logger: logger}
+
+	if err := t.Reload(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Table) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for n := min(len(bin), domain.BinLength); n > 0; n-- {
+		if info, ok := t.bins[bin[:n]]; ok {
+			return &info, nil
+		}
+	}
+
+	return nil, domain.UnknownBinError(bin)
+}
+
+// Len returns the number of ranges in the table.
+func (t *Table) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return len(t.bins)
+}
+
+// OnReload registers fn to be called after the table has been replaced.
+func (t *Table) OnReload(fn func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.listeners = append(t.listeners, fn)
+}
+
+// Reload reads the file again. The current table stays active when the file is invalid.
+func (t *Table) Reload() error {
+	bins, modTime, err := t.load()
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.bins, t.modTime = bins, modTime
+	listeners := t.listeners
+	t.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+
+	return nil
+}
+
+// Run reloads the table whenever its file changes, checking every interval until ctx is done.
+func (t *Table) Run(ctx context.Context, interval time.Duration) {
+	if t.path == "" || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(t.path)
+		if err != nil {
+			t.logger.Warn("could not check BIN table", zap.String("bin_table_file", t.path), zap.Error(err))
+			continue
+		}
+
+		t.mu.RLock()
+		unchanged := info.ModTime().Equal(t.modTime)
+		t.mu.RUnlock()
+		if unchanged {
+			continue
+		}
+
+		if err := t.Reload(); err != nil {
+			t.logger.Error("rejected BIN table reload", zap.String("bin_table_file", t.path), zap.Error(err))
+			continue
+		}
+
+		t.logger.Info("BIN table reloaded", zap.String("bin_table_file", t.path), zap.Int("bin_ranges", t.Len()))
+	}
+}
+
+// Check reports whether the table has any ranges to answer from.
+func (t *Table) Check(ctx context.Context) error {
+	if t.Len() == 0 {
+		return errors.NewErrorf(errors.ServiceUnavailable, "BIN table is empty")
+	}
+
+	return nil
+}
+
+func (t *Table) load() (map[string]domain.BinInfo, time.Time, error) {
+	if t.path == "" {
+		bins := make(map[string]domain.BinInfo, len(domain.DefaultBins))
+		for _, info := range domain.DefaultBins {
+			info.Source = SourceLocal
+			bins[info.Prefix] = info
+		}
+		return bins, time.Time{}, nil
+	}
+
+	file, err := os.Open(t.path)
+	if err != nil {
+		return nil, time.Time{}, errors.WrapError(err, errors.Internal, "failed to open BIN table")
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, time.Time{}, errors.WrapError(err, errors.Internal, "failed to read BIN table")
+	}
+
+	bins, err := parseTable(file)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return bins, stat.ModTime(), nil
+}
+
+func parseTable(r io.Reader) (map[string]domain.BinInfo, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	bins := make(map[string]domain.BinInfo)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to parse BIN table")
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(bins) == 0 && record[0] == "prefix" {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > 5 {
+			return nil, errors.NewErrorf(errors.Internal, "BIN table line %d: expected 2 to 5 columns", line)
+		}
+		record = append(record, make([]string, 5-len(record))...)
+
+		prefix := record[0]
+		if prefix == "" || len(prefix) > domain.BinLength || strings.Trim(prefix, "0123456789") != "" {
+			return nil, errors.NewErrorf(errors.Internal, "BIN table line %d: invalid prefix %q", line, prefix)
+		}
+		if record[1] == "" {
+			return nil, errors.NewErrorf(errors.Internal, "BIN table line %d: missing network", line)
+		}
+		if _, ok := bins[prefix]; ok {
+			return nil, errors.NewErrorf(errors.Internal, "BIN table line %d: duplicate prefix %s", line, prefix)
+		}
+
+		bins[prefix] = domain.BinInfo{
+			Prefix:   prefix,
+			Network:  strings.ToUpper(record[1]),
+			Issuer:   record[2],
+			Country:  strings.ToUpper(record[3]),
+			CardType: strings.ToLower(record[4]),
+			Source:   SourceLocal,
+		}
+	}
+
+	if len(bins) == 0 {
+		return nil, errors.NewErrorf(errors.Internal, "BIN table has no ranges")
+	}
+
+	return bins, nil
+}
@@ -0,0 +1,147 @@
+package bin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testTable = `prefix,network,issuer,country,card_type
+# Visa ranges
+4,visa
+411111,Visa,Test Bank,ke,Debit
+41111199,visa,Test Bank,KE,prepaid
+5,mastercard
+`
+
+func writeTable(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "bins.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestTable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Built-In Ranges", func(t *testing.T) {
+		table, err := NewTable("", zap.NewNop())
+		require.NoError(t, err)
+		require.NoError(t, table.Check(ctx))
+
+		info, err := table.Lookup(ctx, "37828224")
+		require.NoError(t, err)
+		assert.Equal(t, "AMEX", info.Network)
+		assert.Equal(t, SourceLocal, info.Source)
+	})
+
+	t.Run("Longest Prefix Wins", func(t *testing.T) {
+		table, err := NewTable(writeTable(t, testTable), zap.NewNop())
+		require.NoError(t, err)
+		assert.Equal(t, 4, table.Len())
+
+		info, err := table.Lookup(ctx, "41111199")
+		require.NoError(t, err)
+		assert.Equal(t, "41111199", info.Prefix)
+		assert.Equal(t, "prepaid", info.CardType)
+
+		info, err = table.Lookup(ctx, "41111100")
+		require.NoError(t, err)
+		assert.Equal(t, "411111", info.Prefix)
+		assert.Equal(t, "VISA", info.Network)
+		assert.Equal(t, "Test Bank", info.Issuer)
+		assert.Equal(t, "KE", info.Country)
+		assert.Equal(t, "debit", info.CardType)
+
+		info, err = table.Lookup(ctx, "42424242")
+		require.NoError(t, err)
+		assert.Equal(t, "4", info.Prefix)
+	})
+
+	t.Run("Unknown BIN", func(t *testing.T) {
+		table, err := NewTable(writeTable(t, testTable), zap.NewNop())
+		require.NoError(t, err)
+
+		_, err = table.Lookup(ctx, "60110000")
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("Invalid Files", func(t *testing.T) {
+		tables := []string{
+			``,
+			`prefix,network`,
+			`4`,
+			`4x,visa`,
+			`412345678,visa`,
+			`4,`,
+			"4,visa\n4,mastercard",
+			`4,visa,bank,KE,debit,extra`,
+		}
+
+		for _, content := range tables {
+			_, err := NewTable(writeTable(t, content), zap.NewNop())
+			assert.Error(t, err, content)
+		}
+
+		_, err := NewTable(filepath.Join(t.TempDir(), "missing.csv"), zap.NewNop())
+		assert.Error(t, err)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		path := writeTable(t, testTable)
+		table, err := NewTable(path, zap.NewNop())
+		require.NoError(t, err)
+
+		var reloads atomic.Int32
+		table.OnReload(func() { reloads.Add(1) })
+
+		require.NoError(t, os.WriteFile(path, []byte("6,discover\n"), 0600))
+		require.NoError(t, table.Reload())
+		assert.Equal(t, int32(1), reloads.Load())
+
+		_, err = table.Lookup(ctx, "41111111")
+		assert.Error(t, err)
+
+		require.NoError(t, os.WriteFile(path, []byte("not,a,valid,table,at,all\n"), 0600))
+		require.Error(t, table.Reload())
+		assert.Equal(t, int32(1), reloads.Load())
+
+		info, err := table.Lookup(ctx, "60110000")
+		require.NoError(t, err, "the previous table stays active")
+		assert.Equal(t, "DISCOVER", info.Network)
+	})
+
+	t.Run("Run Picks Up Changes", func(t *testing.T) {
+		path := writeTable(t, testTable)
+		table, err := NewTable(path, zap.NewNop())
+		require.NoError(t, err)
+
+		cache := NewCache(table, CacheConfig{Size: 10, TTL: time.Hour})
+		table.OnReload(cache.Invalidate)
+
+		_, err = cache.Lookup(ctx, "41111111")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go table.Run(ctx, 10*time.Millisecond)
+
+		require.NoError(t, os.WriteFile(path, []byte("5,mastercard\n"), 0600))
+		later := time.Now().Add(time.Second)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		assert.Eventually(t, func() bool {
+			_, err := cache.Lookup(ctx, "41111111")
+			return err != nil
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,119 @@
+package binrules
+
+import (
+	"cards-service/internal/adapters/sqlite"
+	"cards-service/internal/core/domain"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS bin_rules (
+	id          TEXT PRIMARY KEY,
+	range_start TEXT NOT NULL,
+	range_end   TEXT NOT NULL,
+	action      TEXT NOT NULL,
+	reason      TEXT NOT NULL,
+	expires_at  INTEGER NOT NULL DEFAULT 0,
+	created_at  INTEGER NOT NULL,
+	created_by  TEXT NOT NULL
+)`
+
+// SQLiteStore keeps BIN rules in a SQLite database. Replicas on the same host can share the
+// file; times are stored as Unix milliseconds, with zero meaning unset.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sqlite.Open(path, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Create(ctx context.Context, rule *domain.BinRule) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO bin_rules (id, range_start, range_end, action, reason, expires_at, created_at, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		rule.ID, rule.RangeStart, rule.RangeEnd, rule.Action, rule.Reason,
+		toMillis(rule.ExpiresAt), toMillis(rule.CreatedAt), rule.CreatedBy,
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to store BIN rule")
+	}
+
+	return nil
+}
+
+func (s *SQLiteStore) List(ctx context.Context) ([]domain.BinRule, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, range_start, range_end, action, reason, expires_at, created_at, created_by
+		FROM bin_rules ORDER BY created_at, id`,
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list BIN rules")
+	}
+	defer rows.Close()
+
+	var rules []domain.BinRule
+	for rows.Next() {
+		var rule domain.BinRule
+		var expiresAt, createdAt int64
+
+		if err := rows.Scan(&rule.ID, &rule.RangeStart, &rule.RangeEnd, &rule.Action, &rule.Reason, &expiresAt, &createdAt, &rule.CreatedBy); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to read BIN rule")
+		}
+		rule.ExpiresAt, rule.CreatedAt = fromMillis(expiresAt), fromMillis(createdAt)
+
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list BIN rules")
+	}
+
+	return rules, nil
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM bin_rules WHERE id = ?`, id)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to delete BIN rule")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.NewErrorf(errors.NotFound, "BIN rule %s not found", id)
+	}
+
+	return nil
+}
+
+// Check reports whether the database can be queried.
+func (s *SQLiteStore) Check(ctx context.Context) error {
+	return sqlite.Check(ctx, s.db)
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func toMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixMilli()
+}
+
+func fromMillis(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(ms).UTC()
+}
@@ -0,0 +1,67 @@
+package binrules
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "bin_rules.db")
+
+	store, err := NewSQLiteStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	block := domain.BinRule{
+		ID: "a", RangeStart: "411111", RangeEnd: "411199", Action: domain.BinRuleBlock,
+		Reason: "fraud", CreatedAt: createdAt, CreatedBy: "ops",
+	}
+	allow := domain.BinRule{
+		ID: "b", RangeStart: "6800", RangeEnd: "6800", Action: domain.BinRuleAllow,
+		Reason: "private label", ExpiresAt: createdAt.Add(time.Hour), CreatedAt: createdAt.Add(time.Second), CreatedBy: "ops",
+	}
+
+	t.Run("Create And List", func(t *testing.T) {
+		require.NoError(t, store.Create(ctx, &block))
+		require.NoError(t, store.Create(ctx, &allow))
+
+		rules, err := store.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.BinRule{block, allow}, rules)
+	})
+
+	t.Run("Rules Survive Reopening", func(t *testing.T) {
+		reopened, err := NewSQLiteStore(path)
+		require.NoError(t, err)
+		defer reopened.Close()
+
+		rules, err := reopened.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, rules, 2)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, "a"))
+
+		rules, err := store.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.BinRule{allow}, rules)
+
+		err = store.Delete(ctx, "a")
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		assert.NoError(t, store.Check(ctx))
+	})
+}
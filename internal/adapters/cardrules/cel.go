@@ -0,0 +1,96 @@
+package cardrules
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// factsType is the CEL type of the card variable.
+var factsType = cel.ObjectType("domain.CardFacts")
+
+// Compiler compiles card rules written in CEL. Rules see a single variable, card, with the
+// fields of domain.CardFacts named by their cel tags, and must evaluate to a bool.
+type Compiler struct {
+	env       *cel.Env
+	costLimit uint64
+}
+
+// NewCompiler creates a compiler whose rules give up once an evaluation exceeds costLimit.
+func NewCompiler(costLimit uint64) (*Compiler, error) {
+	env, err := cel.NewEnv(
+		ext.NativeTypes(reflect.TypeFor[domain.CardFacts](), ext.ParseStructTags(true)),
+		cel.Variable("card", factsType),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to create CEL environment")
+	}
+
+	return &Compiler{env: env, costLimit: costLimit}, nil
+}
+
+// Compile parses and type-checks a rule.
+func (c *Compiler) Compile(rule domain.CardRule) (*Rule, error) {
+	ast, issues := c.env.Compile(rule.Expression)
+	if issues.Err() != nil {
+		return nil, errors.NewErrorf(errors.InvalidArgument, "rule %q does not compile: %v", rule.Name, issues.Err())
+	}
+
+	if !ast.OutputType().IsExactType(cel.BoolType) {
+		return nil, errors.NewErrorf(errors.InvalidArgument, "rule %q must evaluate to a bool, not %s", rule.Name, ast.OutputType())
+	}
+
+	program, err := c.env.Program(ast, cel.CostLimit(c.costLimit), cel.InterruptCheckFrequency(100))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.InvalidArgument, "rule %q cannot be evaluated", rule.Name)
+	}
+
+	return &Rule{name: rule.Name, program: program}, nil
+}
+
+// CompilePolicies compiles the rules of every app policy. Errors name the app and the rule.
+func (c *Compiler) CompilePolicies(policies map[string]domain.AppPolicy) (map[string][]ports.CardRule, error) {
+	compiled := make(map[string][]ports.CardRule, len(policies))
+
+	for appID, policy := range policies {
+		for _, rule := range policy.Rules {
+			program, err := c.Compile(rule)
+			if err != nil {
+				return nil, errors.WrapError(err, errors.InvalidArgument, "invalid rules for app %s", appID)
+			}
+			compiled[appID] = append(compiled[appID], program)
+		}
+	}
+
+	return compiled, nil
+}
+
+// Rule is a compiled card rule.
+type Rule struct {
+	name    string
+	program cel.Program
+}
+
+func (r *Rule) Name() string {
+	return r.name
+}
+
+func (r *Rule) Evaluate(ctx context.Context, facts domain.CardFacts) (bool, error) {
+	out, _, err := r.program.ContextEval(ctx, map[string]any{"card": facts})
+	if err != nil {
+		return false, errors.WrapError(err, errors.Internal, "failed to evaluate rule %q", r.name)
+	}
+
+	satisfied, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.NewErrorf(errors.Internal, "rule %q did not evaluate to a bool", r.name)
+	}
+
+	return satisfied, nil
+}
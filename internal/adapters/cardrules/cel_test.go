@@ -0,0 +1,88 @@
+package cardrules
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompiler(t *testing.T) {
+	ctx := context.Background()
+	compiler, err := NewCompiler(1000)
+	require.NoError(t, err)
+
+	kenyanPrepaid := domain.CardFacts{Network: "VISA", BIN: "41111111", Length: 16, Country: "KE", CardType: "prepaid"}
+	foreignPrepaid := domain.CardFacts{Network: "VISA", BIN: "41111111", Length: 16, Country: "US", CardType: "prepaid"}
+
+	t.Run("Evaluates Rules", func(t *testing.T) {
+		rule, err := compiler.Compile(domain.CardRule{Name: "local-prepaid", Expression: "card.type != 'prepaid' || card.country == 'KE'"})
+		require.NoError(t, err)
+		assert.Equal(t, "local-prepaid", rule.Name())
+
+		ok, err := rule.Evaluate(ctx, kenyanPrepaid)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = rule.Evaluate(ctx, foreignPrepaid)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Every Fact Is Available", func(t *testing.T) {
+		rule, err := compiler.Compile(domain.CardRule{
+			Name:       "all",
+			Expression: "card.network == 'VISA' && card.bin.startsWith('4111') && card.length == 16 && card.issuer == '' && !card.test",
+		})
+		require.NoError(t, err)
+
+		ok, err := rule.Evaluate(ctx, kenyanPrepaid)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Syntax Errors", func(t *testing.T) {
+		_, err := compiler.Compile(domain.CardRule{Name: "broken", Expression: "card.type =="})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `rule "broken" does not compile`)
+	})
+
+	t.Run("Type Errors", func(t *testing.T) {
+		for _, expression := range []string{"card.kind == 'debit'", "card.length == 'sixteen'", "card.country"} {
+			_, err := compiler.Compile(domain.CardRule{Name: "typed", Expression: expression})
+			assert.Error(t, err, expression)
+		}
+	})
+
+	t.Run("Cost Limit", func(t *testing.T) {
+		compiler, err := NewCompiler(10)
+		require.NoError(t, err)
+
+		rule, err := compiler.Compile(domain.CardRule{
+			Name:       "expensive",
+			Expression: "[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(x, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].all(y, x + y > 0))",
+		})
+		require.NoError(t, err)
+
+		_, err = rule.Evaluate(ctx, kenyanPrepaid)
+		require.Error(t, err)
+	})
+
+	t.Run("Compile Policies", func(t *testing.T) {
+		rules, err := compiler.CompilePolicies(map[string]domain.AppPolicy{
+			"merchant-a": {Rules: []domain.CardRule{{Name: "no-test-cards", Expression: "!card.test"}}},
+			"merchant-b": {Networks: []string{"VISA"}},
+		})
+		require.NoError(t, err)
+		assert.Len(t, rules["merchant-a"], 1)
+		assert.Empty(t, rules["merchant-b"])
+
+		_, err = compiler.CompilePolicies(map[string]domain.AppPolicy{
+			"merchant-a": {Rules: []domain.CardRule{{Name: "bad", Expression: "card.nope"}}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "merchant-a")
+	})
+}
@@ -0,0 +1,24 @@
+package certs
+
+import "crypto/tls"
+
+// MinVersion maps a configured version such as "1.3" to its crypto/tls constant.
+func MinVersion(version string) uint16 {
+	if version == "1.3" {
+		return tls.VersionTLS13
+	}
+
+	return tls.VersionTLS12
+}
+
+// ClientAuth maps the configured client certificate mode to its crypto/tls policy.
+func ClientAuth(mode string) tls.ClientAuthType {
+	switch mode {
+	case "optional":
+		return tls.VerifyClientCertIfGiven
+	case "require":
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
@@ -0,0 +1,215 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM encoded certificate and key signed by the CA.
+func (ca *testCA) issue(t *testing.T, cn string, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Cards"}},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// handshake dials the listener and returns the serial of the certificate presented by the server.
+// TLS 1.3 reports client certificate failures after the handshake, so it also waits for the
+// byte the server writes once it has accepted the connection.
+func handshake(t *testing.T, addr string, config *tls.Config) (int64, error) {
+	conn, err := tls.Dial("tcp", addr, config)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return 0, err
+	}
+
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func serve(t *testing.T, config *tls.Config) string {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			if err := conn.(*tls.Conn).Handshake(); err == nil {
+				_, _ = conn.Write([]byte{1})
+			}
+			conn.Close()
+		}
+	}()
+
+	return lis.Addr().String()
+}
+
+func TestReloader(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	certPEM, keyPEM := ca.issue(t, "server", 10, x509.ExtKeyUsageServerAuth)
+	created := time.Now().Add(-time.Minute)
+	writeFile(t, certFile, certPEM, created)
+	writeFile(t, keyFile, keyPEM, created)
+	writeFile(t, caFile, ca.pem, created)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	t.Run("Missing Files", func(t *testing.T) {
+		_, err := NewReloader(filepath.Join(dir, "missing.crt"), keyFile, "", zap.NewNop())
+		require.Error(t, err)
+	})
+
+	t.Run("Invalid CA Bundle", func(t *testing.T) {
+		badCA := filepath.Join(dir, "bad-ca.crt")
+		writeFile(t, badCA, []byte("not a certificate"), created)
+
+		_, err := NewReloader(certFile, keyFile, badCA, zap.NewNop())
+		require.Error(t, err)
+	})
+
+	t.Run("Rotates Server Certificate", func(t *testing.T) {
+		reloader, err := NewReloader(certFile, keyFile, "", zap.NewNop())
+		require.NoError(t, err)
+
+		addr := serve(t, reloader.ServerConfig(tls.VersionTLS12, tls.NoClientCert))
+
+		serial, err := handshake(t, addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), serial)
+
+		certPEM, keyPEM := ca.issue(t, "server", 11, x509.ExtKeyUsageServerAuth)
+		writeFile(t, certFile, certPEM, time.Now())
+		writeFile(t, keyFile, keyPEM, time.Now())
+		reloader.lastCheck = time.Time{}
+
+		serial, err = handshake(t, addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), serial)
+	})
+
+	t.Run("Keeps Previous Certificate On Bad Rotation", func(t *testing.T) {
+		reloader, err := NewReloader(certFile, keyFile, "", zap.NewNop())
+		require.NoError(t, err)
+
+		writeFile(t, keyFile, []byte("truncated"), time.Now().Add(time.Minute))
+		reloader.lastCheck = time.Time{}
+
+		addr := serve(t, reloader.ServerConfig(tls.VersionTLS12, tls.NoClientCert))
+		_, err = handshake(t, addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Requires Client Certificate", func(t *testing.T) {
+		certPEM, keyPEM := ca.issue(t, "server", 12, x509.ExtKeyUsageServerAuth)
+		writeFile(t, certFile, certPEM, created)
+		writeFile(t, keyFile, keyPEM, created)
+
+		reloader, err := NewReloader(certFile, keyFile, caFile, zap.NewNop())
+		require.NoError(t, err)
+
+		addr := serve(t, reloader.ServerConfig(tls.VersionTLS13, ClientAuth("require")))
+
+		clientPEM, clientKeyPEM := ca.issue(t, "merchant-app", 20, x509.ExtKeyUsageClientAuth)
+		clientCert, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+		require.NoError(t, err)
+
+		_, err = handshake(t, addr, &tls.Config{
+			RootCAs:      roots,
+			ServerName:   "localhost",
+			Certificates: []tls.Certificate{clientCert},
+		})
+		require.NoError(t, err)
+
+		_, err = handshake(t, addr, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+		require.Error(t, err)
+	})
+}
+
+func TestOptions(t *testing.T) {
+	assert.Equal(t, uint16(tls.VersionTLS12), MinVersion("1.2"))
+	assert.Equal(t, uint16(tls.VersionTLS13), MinVersion("1.3"))
+	assert.Equal(t, tls.NoClientCert, ClientAuth("none"))
+	assert.Equal(t, tls.VerifyClientCertIfGiven, ClientAuth("optional"))
+	assert.Equal(t, tls.RequireAndVerifyClientCert, ClientAuth("require"))
+}
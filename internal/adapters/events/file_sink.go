@@ -0,0 +1,59 @@
+package events
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// FileSink appends events to a file as JSON lines.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to create events directory")
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to open events file")
+	}
+
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Send(ctx context.Context, event *domain.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to encode event")
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(line); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to write event")
+	}
+
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	return s.file.Close()
+}
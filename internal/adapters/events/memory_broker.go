@@ -0,0 +1,47 @@
+package events
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryBroker keeps delivered events in memory, for tests and local development.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	events []*domain.Event
+	err    error
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+func (b *MemoryBroker) Send(ctx context.Context, event *domain.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+
+	b.events = append(b.events, event)
+	return nil
+}
+
+// Events returns the events delivered so far, in order.
+func (b *MemoryBroker) Events() []*domain.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return slices.Clone(b.events)
+}
+
+// Fail makes deliveries fail with err until it is called with nil.
+func (b *MemoryBroker) Fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.err = err
+}
@@ -0,0 +1,208 @@
+package events
+
+import (
+	"cards-service/internal/adapters/sqlite"
+	"cards-service/internal/core/domain"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// Schema creates the outbox table. Stores that record events together with the changes they
+// describe add it to their own database, write the events with Store and relay them from an
+// Outbox opened on that database.
+var Schema = []string{
+	`CREATE TABLE IF NOT EXISTS outbox (
+		seq             INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_id        TEXT NOT NULL,
+		payload         BLOB NOT NULL,
+		attempts        INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at INTEGER NOT NULL,
+		last_error      TEXT NOT NULL DEFAULT '',
+		dead_at         INTEGER NOT NULL DEFAULT 0
+	)`,
+	`CREATE INDEX IF NOT EXISTS outbox_due ON outbox (dead_at, next_attempt_at)`,
+}
+
+// Outbox stores published events in SQLite until a Relay delivers them, so events survive
+// restarts and outages of the systems they are delivered to. Times are Unix milliseconds.
+type Outbox struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewOutbox(path string) (*Outbox, error) {
+	db, err := sqlite.Open(path, Schema...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Outbox{db: db, now: time.Now}, nil
+}
+
+func (o *Outbox) Publish(ctx context.Context, event *domain.Event) error {
+	return store(ctx, o.db, event, o.now())
+}
+
+// Store adds an event to the outbox within tx, so it is delivered only if the change it
+// describes commits, and always then.
+func Store(ctx context.Context, tx *sql.Tx, event *domain.Event, at time.Time) error {
+	return store(ctx, tx, event, at)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func store(ctx context.Context, db execer, event *domain.Event, at time.Time) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to encode event")
+	}
+
+	_, err = db.ExecContext(ctx,
+		`INSERT INTO outbox (event_id, payload, next_attempt_at) VALUES (?, ?, ?)`,
+		event.ID, payload, at.UnixMilli(),
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to store event")
+	}
+
+	return nil
+}
+
+// DeadLetter is an event the relay gave up on.
+type DeadLetter struct {
+	Event     *domain.Event
+	Attempts  int
+	LastError string
+	DeadAt    time.Time
+}
+
+// DeadLetters lists the events the relay gave up on, oldest first.
+func (o *Outbox) DeadLetters(ctx context.Context) ([]DeadLetter, error) {
+	rows, err := o.db.QueryContext(ctx,
+		`SELECT payload, attempts, last_error, dead_at FROM outbox WHERE dead_at > 0 ORDER BY seq`,
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list dead letters")
+	}
+	defer rows.Close()
+
+	var letters []DeadLetter
+	for rows.Next() {
+		var payload []byte
+		var letter DeadLetter
+		var deadAt int64
+
+		if err := rows.Scan(&payload, &letter.Attempts, &letter.LastError, &deadAt); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to read dead letter")
+		}
+		if err := json.Unmarshal(payload, &letter.Event); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "corrupt event in outbox")
+		}
+		letter.DeadAt = time.UnixMilli(deadAt).UTC()
+
+		letters = append(letters, letter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list dead letters")
+	}
+
+	return letters, nil
+}
+
+// Pending counts the events waiting for delivery, including those waiting to be retried.
+func (o *Outbox) Pending(ctx context.Context) (int, error) {
+	var n int
+	if err := o.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM outbox WHERE dead_at = 0`).Scan(&n); err != nil {
+		return 0, errors.WrapError(err, errors.Internal, "failed to count pending events")
+	}
+
+	return n, nil
+}
+
+// Check reports whether the database can be queried.
+func (o *Outbox) Check(ctx context.Context) error {
+	return sqlite.Check(ctx, o.db)
+}
+
+func (o *Outbox) Close() error {
+	return o.db.Close()
+}
+
+// entry is an event due for delivery.
+type entry struct {
+	seq      int64
+	event    *domain.Event
+	attempts int
+}
+
+func (o *Outbox) due(ctx context.Context, limit int) ([]entry, error) {
+	rows, err := o.db.QueryContext(ctx,
+		`SELECT seq, payload, attempts FROM outbox
+		WHERE dead_at = 0 AND next_attempt_at <= ? ORDER BY seq LIMIT ?`,
+		o.now().UnixMilli(), limit,
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to read outbox")
+	}
+	defer rows.Close()
+
+	var entries []entry
+	for rows.Next() {
+		var e entry
+		var payload []byte
+
+		if err := rows.Scan(&e.seq, &payload, &e.attempts); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to read outbox")
+		}
+		if err := json.Unmarshal(payload, &e.event); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "corrupt event in outbox")
+		}
+
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to read outbox")
+	}
+
+	return entries, nil
+}
+
+func (o *Outbox) delivered(ctx context.Context, seq int64) error {
+	if _, err := o.db.ExecContext(ctx, `DELETE FROM outbox WHERE seq = ?`, seq); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to remove delivered event")
+	}
+
+	return nil
+}
+
+func (o *Outbox) retry(ctx context.Context, seq int64, attempts int, at time.Time, cause error) error {
+	_, err := o.db.ExecContext(ctx,
+		`UPDATE outbox SET attempts = ?, next_attempt_at = ?, last_error = ? WHERE seq = ?`,
+		attempts, at.UnixMilli(), cause.Error(), seq,
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to reschedule event")
+	}
+
+	return nil
+}
+
+func (o *Outbox) deadLetter(ctx context.Context, seq int64, attempts int, cause error) error {
+	_, err := o.db.ExecContext(ctx,
+		`UPDATE outbox SET attempts = ?, last_error = ?, dead_at = ? WHERE seq = ?`,
+		attempts, cause.Error(), o.now().UnixMilli(), seq,
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to dead-letter event")
+	}
+
+	return nil
+}
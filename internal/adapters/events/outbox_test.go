@@ -0,0 +1,154 @@
+package events
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestOutbox(t *testing.T) (*Outbox, *fakeClock) {
+	outbox, err := NewOutbox(filepath.Join(t.TempDir(), "outbox.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { outbox.Close() })
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	outbox.now = clock.Now
+
+	return outbox, clock
+}
+
+func testEvent(t *testing.T, id string) *domain.Event {
+	event, err := domain.NewEvent(id, domain.EventCardValidated, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "checkout",
+		domain.CardValidated{MaskedPAN: "411111******1111", Network: "VISA", Outcome: "accepted"})
+	require.NoError(t, err)
+
+	return event
+}
+
+var testRelayConfig = RelayConfig{
+	Interval:    time.Second,
+	BatchSize:   2,
+	MaxAttempts: 3,
+	Backoff:     time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+func TestRelay(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Delivers In Order", func(t *testing.T) {
+		outbox, _ := newTestOutbox(t)
+		broker := NewMemoryBroker()
+		relay := NewRelay(outbox, broker, testRelayConfig, zap.NewNop())
+
+		for _, id := range []string{"a", "b", "c"} {
+			require.NoError(t, outbox.Publish(ctx, testEvent(t, id)))
+		}
+
+		delivered, err := relay.Deliver(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, delivered, "batches are delivered until the outbox is empty")
+
+		events := broker.Events()
+		require.Len(t, events, 3)
+		assert.Equal(t, []string{"a", "b", "c"}, []string{events[0].ID, events[1].ID, events[2].ID})
+		assert.Equal(t, testEvent(t, "a"), events[0])
+
+		pending, err := outbox.Pending(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, pending)
+	})
+
+	t.Run("Retries With Backoff", func(t *testing.T) {
+		outbox, clock := newTestOutbox(t)
+		broker := NewMemoryBroker()
+		relay := NewRelay(outbox, broker, testRelayConfig, zap.NewNop())
+
+		require.NoError(t, outbox.Publish(ctx, testEvent(t, "a")))
+
+		broker.Fail(assert.AnError)
+		delivered, err := relay.Deliver(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered)
+
+		broker.Fail(nil)
+		delivered, err = relay.Deliver(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered, "the event waits out the backoff")
+
+		clock.Advance(time.Second)
+		delivered, err = relay.Deliver(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+	})
+
+	t.Run("Dead Letters Failing Events", func(t *testing.T) {
+		outbox, clock := newTestOutbox(t)
+		broker := NewMemoryBroker()
+		relay := NewRelay(outbox, broker, testRelayConfig, zap.NewNop())
+
+		require.NoError(t, outbox.Publish(ctx, testEvent(t, "a")))
+		broker.Fail(assert.AnError)
+
+		for range testRelayConfig.MaxAttempts {
+			_, err := relay.Deliver(ctx)
+			require.NoError(t, err)
+			clock.Advance(time.Minute)
+		}
+
+		letters, err := outbox.DeadLetters(ctx)
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+		assert.Equal(t, "a", letters[0].Event.ID)
+		assert.Equal(t, 3, letters[0].Attempts)
+		assert.Equal(t, assert.AnError.Error(), letters[0].LastError)
+
+		broker.Fail(nil)
+		delivered, err := relay.Deliver(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered, "dead letters are not retried")
+
+		pending, err := outbox.Pending(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, pending)
+	})
+
+	t.Run("Events Survive Reopening", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "outbox.db")
+		outbox, err := NewOutbox(path)
+		require.NoError(t, err)
+		require.NoError(t, outbox.Publish(ctx, testEvent(t, "a")))
+		require.NoError(t, outbox.Close())
+
+		outbox, err = NewOutbox(path)
+		require.NoError(t, err)
+		defer outbox.Close()
+
+		pending, err := outbox.Pending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pending)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	relay := NewRelay(nil, nil, testRelayConfig, zap.NewNop())
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, time.Minute, relay.backoff(20))
+}
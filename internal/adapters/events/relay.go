@@ -0,0 +1,115 @@
+package events
+
+import (
+	"cards-service/internal/core/ports"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type RelayConfig struct {
+	// Interval is how often the outbox is checked for events due for delivery.
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts is how many deliveries of an event fail before it is dead-lettered.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles with every failure, up to
+	// MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Relay delivers the events in an outbox to a sink, in order of publication except for
+// retries. An event is removed once delivered, so a crash in between delivers it again.
+type Relay struct {
+	outbox *Outbox
+	sink   ports.EventSink
+	cfg    RelayConfig
+	logger *zap.Logger
+}
+
+func NewRelay(outbox *Outbox, sink ports.EventSink, cfg RelayConfig, logger *zap.Logger) *Relay {
+	return &Relay{outbox: outbox, sink: sink, cfg: cfg, logger: logger}
+}
+
+// Run delivers events every interval until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Deliver(ctx); err != nil && ctx.Err() == nil {
+				r.logger.Warn("could not relay events", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Deliver sends the events that are due, a batch at a time, and returns how many were delivered.
+func (r *Relay) Deliver(ctx context.Context) (int, error) {
+	delivered := 0
+
+	for {
+		entries, err := r.outbox.due(ctx, r.cfg.BatchSize)
+		if err != nil {
+			return delivered, err
+		}
+
+		for _, e := range entries {
+			if ctx.Err() != nil {
+				return delivered, ctx.Err()
+			}
+
+			ok, err := r.send(ctx, e)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+
+		if len(entries) < r.cfg.BatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// send delivers a single event and records the outcome. Only failures to record it are
+// returned, along with cancellation, which does not count as a failed delivery.
+func (r *Relay) send(ctx context.Context, e entry) (bool, error) {
+	err := r.sink.Send(ctx, e.event)
+	if err == nil {
+		return true, r.outbox.delivered(ctx, e.seq)
+	}
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	attempts := e.attempts + 1
+	if attempts >= r.cfg.MaxAttempts {
+		r.logger.Error(
+			"dead-lettered event after repeated delivery failures",
+			zap.String("event_id", e.event.ID),
+			zap.Int("attempts", attempts),
+			zap.Error(err),
+		)
+		return false, r.outbox.deadLetter(ctx, e.seq, attempts, err)
+	}
+
+	return false, r.outbox.retry(ctx, e.seq, attempts, r.outbox.now().Add(r.backoff(attempts)), err)
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.cfg.Backoff
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, r.cfg.MaxBackoff)
+}
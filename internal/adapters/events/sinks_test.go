@@ -0,0 +1,84 @@
+package events
+
+import (
+	"bufio"
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "validations.ndjson")
+
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), testEvent(t, "a")))
+	require.NoError(t, sink.Send(context.Background(), testEvent(t, "b")))
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event domain.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		ids = append(ids, event.ID)
+	}
+	assert.Equal(t, []string{"a", "b"}, ids)
+}
+
+func TestWebhookSink(t *testing.T) {
+	secret := "webhook-secret"
+	event := testEvent(t, "a")
+
+	t.Run("Signs Requests", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "a", r.Header.Get(EventIDHeader))
+			assert.Equal(t, domain.EventCardValidated, r.Header.Get(EventTypeHeader))
+			assert.Equal(t, "1735689600", r.Header.Get(TimestampHeader))
+			assert.Equal(t, "sha256="+Sign([]byte(secret), "1735689600", body), r.Header.Get(SignatureHeader))
+
+			var received domain.Event
+			assert.NoError(t, json.Unmarshal(body, &received))
+			assert.Equal(t, event, &received)
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		sink := NewWebhookSink(server.URL, secret, server.Client())
+		sink.now = func() time.Time { return time.Unix(1735689600, 0) }
+
+		require.NoError(t, sink.Send(context.Background(), event))
+	})
+
+	t.Run("Failed Deliveries", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		sink := NewWebhookSink(server.URL, secret, server.Client())
+		assert.Error(t, sink.Send(context.Background(), event))
+
+		server.Close()
+		assert.Error(t, sink.Send(context.Background(), event))
+	})
+}
@@ -0,0 +1,82 @@
+package events
+
+import (
+	"bytes"
+	"cards-service/internal/core/domain"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	EventIDHeader   = "X-Event-ID"
+	EventTypeHeader = "X-Event-Type"
+	// TimestampHeader is the Unix time the request was signed at, in seconds.
+	TimestampHeader = "X-Signature-Timestamp"
+	// SignatureHeader is "sha256=" followed by the hex HMAC-SHA256, keyed with the webhook
+	// secret, of the timestamp, a dot and the request body.
+	SignatureHeader = "X-Signature"
+)
+
+// WebhookSink posts every event as JSON to a URL. Receivers verify the signature and should
+// refuse old timestamps to stop replays.
+type WebhookSink struct {
+	url    string
+	secret []byte
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookSink(url string, secret string, client *http.Client) *WebhookSink {
+	return &WebhookSink{url: url, secret: []byte(secret), client: client, now: time.Now}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event *domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to encode event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to build webhook request")
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, event.ID)
+	req.Header.Set(EventTypeHeader, event.Type)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign(s.secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.WrapError(err, errors.ServiceUnavailable, "webhook unreachable")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.NewErrorf(errors.ServiceUnavailable, "webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign computes the signature of a webhook request.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package filelock
+
+import "github.com/mwinyimoha/commons/pkg/errors"
+
+// Lock is not supported without flock.
+func Lock(path string) (unlock func(), err error) {
+	return nil, errors.NewErrorf(errors.NotImplemented, "file locking is not supported on this platform")
+}
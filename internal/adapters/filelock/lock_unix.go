@@ -0,0 +1,35 @@
+//go:build unix
+
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// Lock takes an exclusive lock on the file at path, creating it when missing, and waits for
+// other processes to release theirs. Lock files are never removed, since removing one while
+// another process waits on it would let a third lock a new file at the same path.
+func Lock(path string) (unlock func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to create directory for %s", path)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to lock %s", path)
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, errors.WrapError(err, errors.Internal, "failed to lock %s", path)
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,176 @@
+// Package fpe implements the FF1 and FF3-1 format-preserving encryption modes of NIST
+// SP 800-38G Rev. 1 over decimal digits.
+package fpe
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	radix = 10
+	// minLength keeps the domain at a million values or more, as SP 800-38G requires.
+	minLength = 6
+	// maxTweakLength bounds FF1 tweaks, which may otherwise be of any length.
+	maxTweakLength = 256
+)
+
+// FF1 encrypts strings of decimal digits with FF1. Tweaks may be of any length up to 256
+// bytes, including none.
+type FF1 struct {
+	block cipher.Block
+}
+
+// NewFF1 creates an FF1 cipher from an AES key of 16, 24 or 32 bytes.
+func NewFF1(key []byte) (*FF1, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.InvalidArgument, "invalid FF1 key")
+	}
+
+	return &FF1{block: block}, nil
+}
+
+func (f *FF1) Encrypt(tweak []byte, digits string) (string, error) {
+	return f.cipher(tweak, digits, true)
+}
+
+func (f *FF1) Decrypt(tweak []byte, digits string) (string, error) {
+	return f.cipher(tweak, digits, false)
+}
+
+func (f *FF1) cipher(tweak []byte, digits string, encrypt bool) (string, error) {
+	if err := checkDigits(digits); err != nil {
+		return "", err
+	}
+	if len(tweak) > maxTweakLength {
+		return "", errors.NewErrorf(errors.InvalidArgument, "FF1 tweaks have at most %d bytes", maxTweakLength)
+	}
+
+	n := len(digits)
+	u := n / 2
+	v := n - u
+	a, b := digits[:u], digits[u:]
+
+	// b bytes hold any number of v digits; d bytes of pseudorandom output feed each round.
+	byteLen := (bitLength(v) + 7) / 8
+	d := 4*((byteLen+3)/4) + 4
+
+	p := []byte{1, 2, 1, 0, 0, radix, 10, byte(u)}
+	p = binary.BigEndian.AppendUint32(p, uint32(n))
+	p = binary.BigEndian.AppendUint32(p, uint32(len(tweak)))
+
+	padding := (16 - (len(tweak)+byteLen+1)%16) % 16
+	q := make([]byte, len(tweak)+padding+1+byteLen)
+	copy(q, tweak)
+
+	modU, modV := pow10(u), pow10(v)
+
+	for step := range 10 {
+		i := step
+		if !encrypt {
+			i = 9 - step
+		}
+
+		// The half fed to the round function is B when encrypting and A when decrypting.
+		fed := b
+		if !encrypt {
+			fed = a
+		}
+		q[len(tweak)+padding] = byte(i)
+		num(fed).FillBytes(q[len(q)-byteLen:])
+
+		y := new(big.Int).SetBytes(f.expand(f.prf(append(p[:len(p):len(p)], q...)), d))
+
+		m, mod := u, modU
+		if i%2 == 1 {
+			m, mod = v, modV
+		}
+
+		if encrypt {
+			c := y.Add(y, num(a))
+			a, b = b, str(c.Mod(c, mod), m)
+		} else {
+			c := new(big.Int).Sub(num(b), y)
+			b, a = a, str(c.Mod(c, mod), m)
+		}
+	}
+
+	return a + b, nil
+}
+
+// prf is CBC-MAC over data, a whole number of blocks.
+func (f *FF1) prf(data []byte) []byte {
+	y := make([]byte, aes.BlockSize)
+	for i := 0; i < len(data); i += aes.BlockSize {
+		for j := range aes.BlockSize {
+			y[j] ^= data[i+j]
+		}
+		f.block.Encrypt(y, y)
+	}
+
+	return y
+}
+
+// expand stretches r to d bytes with r || CIPH(r xor [1]) || CIPH(r xor [2]) ...
+func (f *FF1) expand(r []byte, d int) []byte {
+	s := append([]byte{}, r...)
+	for j := 1; len(s) < d; j++ {
+		block := append([]byte{}, r...)
+		counter := binary.BigEndian.AppendUint64(make([]byte, 8), uint64(j))
+		for k := range block {
+			block[k] ^= counter[k]
+		}
+		f.block.Encrypt(block, block)
+		s = append(s, block...)
+	}
+
+	return s[:d]
+}
+
+func checkDigits(digits string) error {
+	if len(digits) < minLength {
+		return errors.NewErrorf(errors.InvalidArgument, "at least %d digits are needed", minLength)
+	}
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return errors.NewErrorf(errors.InvalidArgument, "only digits can be encrypted")
+		}
+	}
+
+	return nil
+}
+
+// bitLength is the number of bits needed for any number of n digits.
+func bitLength(n int) int {
+	return new(big.Int).Sub(pow10(n), big.NewInt(1)).BitLen()
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(radix), big.NewInt(int64(n)), nil)
+}
+
+// num is the value of a string of digits, most significant first.
+func num(digits string) *big.Int {
+	n, _ := new(big.Int).SetString(digits, radix)
+	if n == nil {
+		return new(big.Int)
+	}
+
+	return n
+}
+
+// str writes x as m digits, most significant first.
+func str(x *big.Int, m int) string {
+	s := x.Text(radix)
+	for len(s) < m {
+		s = "0" + s
+	}
+
+	return s
+}
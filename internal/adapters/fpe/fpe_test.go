@@ -0,0 +1,136 @@
+package fpe
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
+
+func TestFF1(t *testing.T) {
+	// Samples from NIST's examples for SP 800-38G.
+	samples := []struct {
+		name, key, tweak, plaintext, ciphertext string
+	}{
+		{"Sample 1", "2B7E151628AED2A6ABF7158809CF4F3C", "", "0123456789", "2433477484"},
+		{"Sample 2", "2B7E151628AED2A6ABF7158809CF4F3C", "39383736353433323130", "0123456789", "6124200773"},
+		{"Sample 4", "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F", "", "0123456789", "2830668132"},
+		{"Sample 7", "2B7E151628AED2A6ABF7158809CF4F3CEF4359D8D580AA4F7F036D6F04FC6A94", "", "0123456789", "6657667009"},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.name, func(t *testing.T) {
+			ff1, err := NewFF1(mustHex(t, sample.key))
+			require.NoError(t, err)
+
+			ciphertext, err := ff1.Encrypt(mustHex(t, sample.tweak), sample.plaintext)
+			require.NoError(t, err)
+			assert.Equal(t, sample.ciphertext, ciphertext)
+
+			plaintext, err := ff1.Decrypt(mustHex(t, sample.tweak), ciphertext)
+			require.NoError(t, err)
+			assert.Equal(t, sample.plaintext, plaintext)
+		})
+	}
+}
+
+func TestFF3(t *testing.T) {
+	// Samples from NIST's examples for FF3, which FF3-1 runs with a 64-bit tweak.
+	samples := []struct {
+		name, key, tweak, plaintext, ciphertext string
+	}{
+		{"Sample 1", "EF4359D8D580AA4F7F036D6F04FC6A94", "D8E7920AFA330A73", "890121234567890000", "750918814058654607"},
+		{"Sample 2", "EF4359D8D580AA4F7F036D6F04FC6A94", "9A768A92F60E12D8", "890121234567890000", "018989839189395384"},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.name, func(t *testing.T) {
+			ff3, err := NewFF31(mustHex(t, sample.key))
+			require.NoError(t, err)
+
+			ciphertext, err := ff3.cipher(mustHex(t, sample.tweak), sample.plaintext, true)
+			require.NoError(t, err)
+			assert.Equal(t, sample.ciphertext, ciphertext)
+
+			plaintext, err := ff3.cipher(mustHex(t, sample.tweak), ciphertext, false)
+			require.NoError(t, err)
+			assert.Equal(t, sample.plaintext, plaintext)
+		})
+	}
+}
+
+func TestFF31(t *testing.T) {
+	key := mustHex(t, "EF4359D8D580AA4F7F036D6F04FC6A94")
+	ff31, err := NewFF31(key)
+	require.NoError(t, err)
+
+	t.Run("Round Trip", func(t *testing.T) {
+		for _, plaintext := range []string{"000000", "1234567", "890121234567890000", "12345678901234567890123456789012345678901234567890123456"} {
+			ciphertext, err := ff31.Encrypt(mustHex(t, "D8E7920AFA330A"), plaintext)
+			require.NoError(t, err)
+			assert.Len(t, ciphertext, len(plaintext))
+			assert.NotEqual(t, plaintext, ciphertext)
+
+			decrypted, err := ff31.Decrypt(mustHex(t, "D8E7920AFA330A"), ciphertext)
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, decrypted)
+		}
+	})
+
+	t.Run("Tweaks", func(t *testing.T) {
+		a, err := ff31.Encrypt(mustHex(t, "D8E7920AFA330A"), "890121234567890000")
+		require.NoError(t, err)
+		b, err := ff31.Encrypt(mustHex(t, "9A768A92F60E12"), "890121234567890000")
+		require.NoError(t, err)
+		assert.NotEqual(t, a, b)
+
+		none, err := ff31.Encrypt(nil, "890121234567890000")
+		require.NoError(t, err)
+		zeros, err := ff31.Encrypt(make([]byte, FF3TweakLength), "890121234567890000")
+		require.NoError(t, err)
+		assert.Equal(t, zeros, none)
+
+		_, err = ff31.Encrypt(mustHex(t, "D8E7920AFA330A73"), "890121234567890000")
+		require.Error(t, err, "FF3-1 tweaks are 56 bits")
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		for _, digits := range []string{"12345", "12345a", "123456789012345678901234567890123456789012345678901234567"} {
+			_, err := ff31.Encrypt(nil, digits)
+			assert.Error(t, err, digits)
+		}
+
+		_, err := NewFF31([]byte("short"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFF1RoundTrip(t *testing.T) {
+	ff1, err := NewFF1(mustHex(t, "2B7E151628AED2A6ABF7158809CF4F3C"))
+	require.NoError(t, err)
+
+	for _, plaintext := range []string{"000000", "1234567", "4111111111111111", "4111111111111111111"} {
+		ciphertext, err := ff1.Encrypt([]byte("tweak"), plaintext)
+		require.NoError(t, err)
+		assert.Len(t, ciphertext, len(plaintext))
+
+		decrypted, err := ff1.Decrypt([]byte("tweak"), ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+
+		other, err := ff1.Decrypt([]byte("other"), ciphertext)
+		require.NoError(t, err)
+		assert.NotEqual(t, plaintext, other, "the tweak is needed to decrypt")
+	}
+
+	_, err = ff1.Encrypt(make([]byte, maxTweakLength+1), "123456")
+	assert.Error(t, err)
+}
@@ -0,0 +1,203 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check reports whether a dependency is usable. It is run periodically with a timeout.
+type Check func(ctx context.Context) error
+
+// panicError reports a check that panicked instead of returning.
+type panicError struct {
+	value any
+}
+
+func (e panicError) Error() string {
+	return fmt.Sprintf("check panicked: %v", e.value)
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Monitor runs the registered checks periodically and publishes the result on the gRPC
+// health server. A service is serving when all the checks it depends on pass, and the
+// overall status, for the empty service name, when every check passes.
+type Monitor struct {
+	server   *health.Server
+	interval time.Duration
+	timeout  time.Duration
+	logger   *zap.Logger
+
+	mu           sync.RWMutex
+	checks       []namedCheck
+	services     map[string][]string
+	results      map[string]error
+	checked      bool
+	shuttingDown bool
+}
+
+func NewMonitor(server *health.Server, interval, timeout time.Duration, logger *zap.Logger) *Monitor {
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &Monitor{
+		server:   server,
+		interval: interval,
+		timeout:  timeout,
+		logger:   logger,
+		services: make(map[string][]string),
+		results:  make(map[string]error),
+	}
+}
+
+func (m *Monitor) Register(name string, check Check) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checks = append(m.checks, namedCheck{name: name, check: check})
+}
+
+// Service reports service as serving only while the named checks pass.
+func (m *Monitor) Service(service string, checks ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.services[service] = checks
+	m.server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks immediately and then on every interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.CheckNow(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckNow runs every check once and updates the published statuses.
+func (m *Monitor) CheckNow(ctx context.Context) {
+	m.mu.RLock()
+	checks := m.checks
+	m.mu.RUnlock()
+
+	results := make(map[string]error, len(checks))
+	for _, c := range checks {
+		results[c.name] = m.run(ctx, c.check)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, err := range results {
+		previous, seen := m.results[name]
+
+		switch {
+		case err != nil && (!seen || previous == nil):
+			m.logger.Warn("health check failing", zap.String("check", name), zap.Error(err))
+		case err == nil && seen && previous != nil:
+			m.logger.Info("health check recovered", zap.String("check", name))
+		}
+	}
+
+	m.results = results
+	m.checked = true
+	m.publish()
+}
+
+// Shutdown reports every service as not serving from now on, so load balancers stop
+// sending new work while in-flight requests drain.
+func (m *Monitor) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.shuttingDown = true
+	m.server.Shutdown()
+}
+
+// Ready reports whether every check passed on the last run, with the result of each check.
+func (m *Monitor) Ready() (bool, map[string]error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	results := make(map[string]error, len(m.results))
+	ready := m.checked && !m.shuttingDown
+
+	for name, err := range m.results {
+		results[name] = err
+		if err != nil {
+			ready = false
+		}
+	}
+
+	return ready, results
+}
+
+func (m *Monitor) run(ctx context.Context, check Check) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError{r}
+		}
+	}()
+
+	return check(ctx)
+}
+
+func (m *Monitor) publish() {
+	if m.shuttingDown {
+		return
+	}
+
+	m.server.SetServingStatus("", servingStatus(m.allPassing()))
+
+	for service, checks := range m.services {
+		m.server.SetServingStatus(service, servingStatus(m.passing(checks)))
+	}
+}
+
+func (m *Monitor) allPassing() bool {
+	for _, err := range m.results {
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// passing reports whether the named checks have run and passed.
+func (m *Monitor) passing(names []string) bool {
+	for _, name := range names {
+		if err, ok := m.results[name]; !ok || err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func servingStatus(serving bool) healthpb.HealthCheckResponse_ServingStatus {
+	if serving {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const cardsService = "cards.v1.CardsService"
+
+type toggle struct {
+	failing atomic.Bool
+}
+
+func (c *toggle) Check(context.Context) error {
+	if c.failing.Load() {
+		return stderrors.New("dial tcp 10.0.0.5:6379: connection refused")
+	}
+
+	return nil
+}
+
+func setupMonitor(t *testing.T) (*Monitor, *toggle, healthpb.HealthClient) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthSrv)
+
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		server.Stop()
+		conn.Close()
+	})
+
+	store := &toggle{}
+	monitor := NewMonitor(healthSrv, time.Hour, time.Second, zap.NewNop())
+	monitor.Register("bin_lookup", func(context.Context) error { return nil })
+	monitor.Register("rate_limit_store", store.Check)
+	monitor.Service(cardsService, "bin_lookup")
+	monitor.Service("cards.v1.UsageService", "rate_limit_store")
+
+	return monitor, store, healthpb.NewHealthClient(conn)
+}
+
+func serving(t *testing.T, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+
+	return resp.Status
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Not Serving Until Checked", func(t *testing.T) {
+		_, _, client := setupMonitor(t)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, serving(t, client, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, serving(t, client, cardsService))
+	})
+
+	t.Run("Per Service Status", func(t *testing.T) {
+		monitor, store, client := setupMonitor(t)
+
+		monitor.CheckNow(ctx)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, serving(t, client, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, serving(t, client, cardsService))
+
+		store.failing.Store(true)
+		monitor.CheckNow(ctx)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, serving(t, client, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, serving(t, client, cardsService))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, serving(t, client, "cards.v1.UsageService"))
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		monitor, store, client := setupMonitor(t)
+		monitor.CheckNow(ctx)
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "cards.v1.UsageService"})
+		require.NoError(t, err)
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+		store.failing.Store(true)
+		monitor.CheckNow(ctx)
+
+		resp, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	})
+
+	t.Run("Slow And Panicking Checks Fail", func(t *testing.T) {
+		monitor := NewMonitor(health.NewServer(), time.Hour, 10*time.Millisecond, zap.NewNop())
+		monitor.Register("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		monitor.Register("broken", func(context.Context) error { panic("nil store") })
+
+		monitor.CheckNow(ctx)
+
+		ready, results := monitor.Ready()
+		assert.False(t, ready)
+		assert.ErrorIs(t, results["slow"], context.DeadlineExceeded)
+		assert.EqualError(t, results["broken"], "check panicked: nil store")
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		monitor, _, client := setupMonitor(t)
+		monitor.CheckNow(ctx)
+
+		monitor.Shutdown()
+		monitor.CheckNow(ctx)
+
+		ready, _ := monitor.Ready()
+		assert.False(t, ready)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, serving(t, client, cardsService))
+	})
+}
+
+func TestHandler(t *testing.T) {
+	monitor, store, _ := setupMonitor(t)
+	handler := Handler(monitor)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	assert.Equal(t, 200, get("/livez").Code)
+	assert.Equal(t, 503, get("/readyz").Code, "not ready before the first check")
+
+	monitor.CheckNow(context.Background())
+	rec := get("/readyz")
+	assert.Equal(t, 200, rec.Code)
+	assert.JSONEq(t, `{"status": "ok", "checks": {"bin_lookup": "ok", "rate_limit_store": "ok"}}`, rec.Body.String())
+
+	store.failing.Store(true)
+	monitor.CheckNow(context.Background())
+	rec = get("/readyz")
+	assert.Equal(t, 503, rec.Code)
+	assert.JSONEq(t, `{"status": "unavailable", "checks": {"bin_lookup": "ok", "rate_limit_store": "failing"}}`, rec.Body.String())
+	assert.Equal(t, 200, get("/livez").Code)
+}
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func serveHealth(t *testing.T, network, address string) (*health.Server, string) {
+	lis, err := net.Listen(network, address)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthSrv)
+
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return healthSrv, lis.Addr().String()
+}
+
+func TestProbe(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("TCP", func(t *testing.T) {
+		healthSrv, addr := serveHealth(t, "tcp", "127.0.0.1:0")
+		healthSrv.SetServingStatus(cardsService, healthpb.HealthCheckResponse_SERVING)
+
+		st, err := Probe(ctx, addr, cardsService, insecure.NewCredentials())
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, st)
+
+		healthSrv.SetServingStatus(cardsService, healthpb.HealthCheckResponse_NOT_SERVING)
+
+		st, err = Probe(ctx, addr, cardsService, insecure.NewCredentials())
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, st)
+	})
+
+	t.Run("Unix Socket", func(t *testing.T) {
+		socket := filepath.Join(t.TempDir(), "grpc.sock")
+		serveHealth(t, "unix", socket)
+
+		st, err := Probe(ctx, "unix://"+socket, "", insecure.NewCredentials())
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, st)
+	})
+
+	t.Run("Unknown Service", func(t *testing.T) {
+		_, addr := serveHealth(t, "tcp", "127.0.0.1:0")
+
+		_, err := Probe(ctx, addr, "cards.v1.Missing", insecure.NewCredentials())
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Nothing Listening", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+
+		_, err := Probe(ctx, "unix://"+filepath.Join(t.TempDir(), "missing.sock"), "", insecure.NewCredentials())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	statusOK      = "ok"
+	statusFailing = "failing"
+)
+
+type report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// NewServer serves /livez, which succeeds while the process can answer HTTP, and /readyz,
+// which succeeds only while every health check passes and the service is not shutting down.
+// Reports name the failing checks but not their errors, which the monitor logs instead, as
+// they may describe internal addresses and the like.
+func NewServer(port int, monitor *Monitor) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%v", port),
+		Handler:           Handler(monitor),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func Handler(monitor *Monitor) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /livez", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, report{Status: statusOK})
+	})
+
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		ready, results := monitor.Ready()
+
+		rep := report{Status: statusOK, Checks: make(map[string]string, len(results))}
+		for name, err := range results {
+			rep.Checks[name] = statusOK
+			if err != nil {
+				rep.Checks[name] = statusFailing
+			}
+		}
+
+		code := http.StatusOK
+		if !ready {
+			rep.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+		}
+
+		writeReport(w, code, rep)
+	})
+
+	return mux
+}
+
+func writeReport(w http.ResponseWriter, code int, rep report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(rep)
+}
@@ -0,0 +1,435 @@
+package keys
+
+import (
+	"cards-service/internal/adapters/filelock"
+	"cards-service/internal/core/domain"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	keySize = 32
+	// passphraseIterations follows the OWASP recommendation for PBKDF2-HMAC-SHA256.
+	passphraseIterations = 600_000
+)
+
+// keyring is the layout of the keyring file.
+type keyring struct {
+	// Salt derives the key sealing the keys from the passphrase. Without one, the keys are
+	// stored as they are and the file itself must be protected.
+	Salt    []byte         `json:"salt,omitempty"`
+	Current int            `json:"current"`
+	Keys    []keyringEntry `json:"keys"`
+}
+
+type keyringEntry struct {
+	Version   int       `json:"version"`
+	Key       []byte    `json:"key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LocalKeyManager keeps the versions of the key encryption key in a local keyring file,
+// optionally sealed with a passphrase. The file is created with a first version when missing.
+// Rotating adds a version to the file; running managers pick it up on Refresh, and at once
+// when they meet an envelope wrapped by a version they do not know yet.
+type LocalKeyManager struct {
+	path       string
+	passphrase string
+
+	mu      sync.RWMutex
+	keks    map[int]cipher.AEAD
+	current int
+	modTime time.Time
+}
+
+func NewLocalKeyManager(path string, passphrase string) (*LocalKeyManager, error) {
+	m := &LocalKeyManager{path: path, passphrase: passphrase}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := m.create(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *LocalKeyManager) Encrypt(ctx context.Context, purpose string, plaintext []byte, aad []byte) (*domain.Envelope, error) {
+	dek := randomBytes(keySize)
+
+	ciphertext, err := seal(dek, plaintext, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	version, kek := m.current, m.keks[m.current]
+	m.mu.RUnlock()
+
+	return &domain.Envelope{
+		KeyVersion: version,
+		WrappedKey: sealWith(kek, dek, wrapAAD(version, purpose)),
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+func (m *LocalKeyManager) Decrypt(ctx context.Context, purpose string, envelope *domain.Envelope, aad []byte) ([]byte, error) {
+	dek, err := m.unwrap(purpose, envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(dek, envelope.Ciphertext, aad)
+}
+
+func (m *LocalKeyManager) Rewrap(ctx context.Context, purpose string, envelope *domain.Envelope) (*domain.Envelope, error) {
+	dek, err := m.unwrap(purpose, envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	version, kek := m.current, m.keks[m.current]
+	m.mu.RUnlock()
+
+	return &domain.Envelope{
+		KeyVersion: version,
+		WrappedKey: sealWith(kek, dek, wrapAAD(version, purpose)),
+		Ciphertext: envelope.Ciphertext,
+	}, nil
+}
+
+func (m *LocalKeyManager) CurrentVersion() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.current
+}
+
+// Rotate adds a key version to the keyring file and makes it current. The file is locked
+// and read again first, so versions added by other processes are kept.
+func (m *LocalKeyManager) Rotate(ctx context.Context) (int, error) {
+	unlock, err := lockKeyring(m.path)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	kr, err := m.read()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := m.addVersion(kr); err != nil {
+		return 0, err
+	}
+	if err := m.save(kr); err != nil {
+		return 0, err
+	}
+	if err := m.load(); err != nil {
+		return 0, err
+	}
+
+	return kr.Current, nil
+}
+
+// Refresh reloads the keyring file when it has changed.
+func (m *LocalKeyManager) Refresh(ctx context.Context) error {
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to read keyring")
+	}
+
+	m.mu.RLock()
+	unchanged := info.ModTime().Equal(m.modTime)
+	m.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	return m.load()
+}
+
+// Run refreshes the keyring every interval until ctx is done. Failed refreshes are reported
+// to onError and keep the current keys.
+func (m *LocalKeyManager) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Refresh(ctx); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (m *LocalKeyManager) unwrap(purpose string, envelope *domain.Envelope) ([]byte, error) {
+	m.mu.RLock()
+	kek, ok := m.keks[envelope.KeyVersion]
+	m.mu.RUnlock()
+
+	if !ok {
+		// The version may have been added since the keyring was loaded.
+		if err := m.load(); err != nil {
+			return nil, err
+		}
+
+		m.mu.RLock()
+		kek, ok = m.keks[envelope.KeyVersion]
+		m.mu.RUnlock()
+
+		if !ok {
+			return nil, errors.NewErrorf(errors.FailedDependency, "key version %d is not in the keyring", envelope.KeyVersion)
+		}
+	}
+
+	dek, err := openWith(kek, envelope.WrappedKey, wrapAAD(envelope.KeyVersion, purpose))
+	if err != nil {
+		return nil, err
+	}
+
+	return dek, nil
+}
+
+func (m *LocalKeyManager) load() error {
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to read keyring")
+	}
+
+	kr, err := m.read()
+	if err != nil {
+		return err
+	}
+
+	sealer, err := m.sealer(kr)
+	if err != nil {
+		return err
+	}
+
+	keks := make(map[int]cipher.AEAD, len(kr.Keys))
+	for _, entry := range kr.Keys {
+		key := entry.Key
+		if sealer != nil {
+			if key, err = openWith(sealer, entry.Key, versionAAD(entry.Version)); err != nil {
+				return errors.NewErrorf(errors.Internal, "failed to unseal key version %d: wrong passphrase?", entry.Version)
+			}
+		}
+
+		if keks[entry.Version], err = newAEAD(key); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := keks[kr.Current]; !ok {
+		return errors.NewErrorf(errors.Internal, "keyring has no current key version %d", kr.Current)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keks, m.current, m.modTime = keks, kr.Current, info.ModTime()
+
+	return nil
+}
+
+// lockKeyring takes an exclusive lock on the keyring at path so changes to the file do not
+// overwrite each other. The lock is held on a file next to the keyring, which is replaced on
+// every save.
+func lockKeyring(path string) (unlock func(), err error) {
+	return filelock.Lock(path + ".lock")
+}
+
+// create writes a keyring with a first version, unless another process created one while
+// this one waited for the lock.
+func (m *LocalKeyManager) create() error {
+	unlock, err := lockKeyring(m.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(m.path); !os.IsNotExist(err) {
+		return nil
+	}
+
+	kr := &keyring{}
+	if m.passphrase != "" {
+		kr.Salt = randomBytes(keySize)
+	}
+	if err := m.addVersion(kr); err != nil {
+		return err
+	}
+
+	return m.save(kr)
+}
+
+func (m *LocalKeyManager) read() (*keyring, error) {
+	data, err := os.ReadFile(m.path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to read keyring")
+	}
+
+	var kr keyring
+	if err := json.Unmarshal(data, &kr); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to parse keyring")
+	}
+
+	return &kr, nil
+}
+
+// save writes the keyring next to the file and renames it over, so readers never see part
+// of one.
+func (m *LocalKeyManager) save(kr *keyring) error {
+	data, err := json.MarshalIndent(kr, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to encode keyring")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o700); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to create keyring directory")
+	}
+
+	tmp := m.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to write keyring")
+	}
+	if err := os.Rename(tmp, m.path); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to write keyring")
+	}
+
+	return nil
+}
+
+// addVersion adds a random key to the keyring as its current version.
+func (m *LocalKeyManager) addVersion(kr *keyring) error {
+	version := 1
+	for _, entry := range kr.Keys {
+		version = max(version, entry.Version+1)
+	}
+
+	key := randomBytes(keySize)
+
+	sealer, err := m.sealer(kr)
+	if err != nil {
+		return err
+	}
+	if sealer != nil {
+		key = sealWith(sealer, key, versionAAD(version))
+	}
+
+	kr.Keys = append(kr.Keys, keyringEntry{Version: version, Key: key, CreatedAt: time.Now().UTC()})
+	kr.Current = version
+
+	return nil
+}
+
+// sealer returns the cipher sealing the keys of the keyring, or nil when they are stored as
+// they are.
+func (m *LocalKeyManager) sealer(kr *keyring) (cipher.AEAD, error) {
+	switch {
+	case len(kr.Salt) == 0 && m.passphrase == "":
+		return nil, nil
+	case len(kr.Salt) == 0:
+		return nil, errors.NewErrorf(errors.Internal, "keyring is not sealed with a passphrase")
+	case m.passphrase == "":
+		return nil, errors.NewErrorf(errors.Internal, "keyring is sealed with a passphrase")
+	}
+
+	key, err := pbkdf2.Key(sha256.New, m.passphrase, kr.Salt, passphraseIterations, keySize)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to derive keyring key")
+	}
+
+	return newAEAD(key)
+}
+
+func wrapAAD(version int, purpose string) []byte {
+	return []byte("v" + strconv.Itoa(version) + "/" + purpose)
+}
+
+func versionAAD(version int) []byte {
+	return []byte("v" + strconv.Itoa(version))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "invalid key")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "invalid key")
+	}
+
+	return aead, nil
+}
+
+func seal(key []byte, plaintext []byte, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealWith(aead, plaintext, aad), nil
+}
+
+func open(key []byte, ciphertext []byte, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return openWith(aead, ciphertext, aad)
+}
+
+// sealWith encrypts under a random nonce, which leads the ciphertext.
+func sealWith(aead cipher.AEAD, plaintext []byte, aad []byte) []byte {
+	nonce := randomBytes(aead.NonceSize())
+	return aead.Seal(nonce, nonce, plaintext, aad)
+}
+
+func openWith(aead cipher.AEAD, ciphertext []byte, aad []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.NewErrorf(errors.InvalidArgument, "ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, aad)
+	if err != nil {
+		return nil, errors.NewErrorf(errors.InvalidArgument, "failed to decrypt: wrong key or data")
+	}
+
+	return plaintext, nil
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	// crypto/rand.Read never fails.
+	_, _ = rand.Read(b)
+
+	return b
+}
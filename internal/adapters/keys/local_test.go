@@ -0,0 +1,132 @@
+package keys
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalKeyManager(t *testing.T) {
+	ctx := context.Background()
+	pan := []byte("4111111111111111")
+	aad := []byte("card-token")
+
+	t.Run("Encrypt And Decrypt", func(t *testing.T) {
+		m, err := NewLocalKeyManager(filepath.Join(t.TempDir(), "keyring.json"), "")
+		require.NoError(t, err)
+		assert.Equal(t, 1, m.CurrentVersion())
+
+		env, err := m.Encrypt(ctx, domain.KeyPurposeWalletPAN, pan, aad)
+		require.NoError(t, err)
+		assert.Equal(t, 1, env.KeyVersion)
+		assert.NotContains(t, string(env.Ciphertext), string(pan))
+
+		other, err := m.Encrypt(ctx, domain.KeyPurposeWalletPAN, pan, aad)
+		require.NoError(t, err)
+		assert.NotEqual(t, env.WrappedKey, other.WrappedKey, "every record has its own data key")
+
+		got, err := m.Decrypt(ctx, domain.KeyPurposeWalletPAN, env, aad)
+		require.NoError(t, err)
+		assert.Equal(t, pan, got)
+
+		_, err = m.Decrypt(ctx, domain.KeyPurposeWalletPAN, env, []byte("other-token"))
+		require.Error(t, err, "the ciphertext is bound to its record")
+		_, err = m.Decrypt(ctx, "other_purpose", env, aad)
+		require.Error(t, err, "the data key is bound to its purpose")
+	})
+
+	t.Run("Rotate And Rewrap", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		m, err := NewLocalKeyManager(path, "")
+		require.NoError(t, err)
+
+		env, err := m.Encrypt(ctx, domain.KeyPurposeWalletPAN, pan, aad)
+		require.NoError(t, err)
+
+		// Another process rotates the keys, as the rotate-keys command does.
+		rotator, err := NewLocalKeyManager(path, "")
+		require.NoError(t, err)
+		version, err := rotator.Rotate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+
+		rewrapped, err := rotator.Rewrap(ctx, domain.KeyPurposeWalletPAN, env)
+		require.NoError(t, err)
+		assert.Equal(t, 2, rewrapped.KeyVersion)
+		assert.Equal(t, env.Ciphertext, rewrapped.Ciphertext, "the data is not encrypted again")
+
+		got, err := m.Decrypt(ctx, domain.KeyPurposeWalletPAN, rewrapped, aad)
+		require.NoError(t, err, "unknown versions are loaded on demand")
+		assert.Equal(t, pan, got)
+
+		got, err = rotator.Decrypt(ctx, domain.KeyPurposeWalletPAN, env, aad)
+		require.NoError(t, err, "earlier versions still decrypt")
+		assert.Equal(t, pan, got)
+
+		require.NoError(t, m.Refresh(ctx))
+		assert.Equal(t, 2, m.CurrentVersion())
+	})
+
+	t.Run("Concurrent Rotations Keep Every Version", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m, err := NewLocalKeyManager(path, "")
+				assert.NoError(t, err)
+				_, err = m.Rotate(ctx)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		m, err := NewLocalKeyManager(path, "")
+		require.NoError(t, err)
+		assert.Equal(t, 6, m.CurrentVersion())
+	})
+
+	t.Run("Passphrase", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		m, err := NewLocalKeyManager(path, "correct horse battery staple")
+		require.NoError(t, err)
+
+		env, err := m.Encrypt(ctx, domain.KeyPurposeWalletPAN, pan, aad)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"salt"`)
+
+		_, err = NewLocalKeyManager(path, "wrong")
+		require.Error(t, err)
+		_, err = NewLocalKeyManager(path, "")
+		require.Error(t, err)
+
+		reopened, err := NewLocalKeyManager(path, "correct horse battery staple")
+		require.NoError(t, err)
+		got, err := reopened.Decrypt(ctx, domain.KeyPurposeWalletPAN, env, aad)
+		require.NoError(t, err)
+		assert.Equal(t, pan, got)
+	})
+
+	t.Run("Unknown Version", func(t *testing.T) {
+		m, err := NewLocalKeyManager(filepath.Join(t.TempDir(), "keyring.json"), "")
+		require.NoError(t, err)
+
+		env, err := m.Encrypt(ctx, domain.KeyPurposeWalletPAN, pan, aad)
+		require.NoError(t, err)
+		env.KeyVersion = 7
+
+		_, err = m.Decrypt(ctx, domain.KeyPurposeWalletPAN, env, aad)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "cards"
+
+// Recorder exposes domain metrics for card validations to Prometheus.
+type Recorder struct {
+	validations *prometheus.CounterVec
+	binLookups  *prometheus.CounterVec
+	riskSignals *prometheus.CounterVec
+	batchSizes  prometheus.Histogram
+
+	binCacheLookups   *prometheus.CounterVec
+	binCacheEvictions prometheus.Counter
+}
+
+func NewRecorder(reg prometheus.Registerer) *Recorder {
+	r := &Recorder{
+		validations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "validations_total",
+				Help:      "Card number validations by network, outcome and rejection reason.",
+			},
+			[]string{"network", "outcome", "reason"},
+		),
+		binLookups: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "bin_lookups_total",
+				Help:      "BIN lookups by result.",
+			},
+			[]string{"result"},
+		),
+		riskSignals: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "risk_signals_total",
+				Help:      "Risk signals raised during validations, by signal.",
+			},
+			[]string{"signal"},
+		),
+		batchSizes: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "validation_batch_size",
+				Help:      "Card numbers per batch validation.",
+				Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000},
+			},
+		),
+		binCacheLookups: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "bin_cache_lookups_total",
+				Help:      "BIN cache lookups by result: hit, negative_hit or miss.",
+			},
+			[]string{"result"},
+		),
+		binCacheEvictions: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "bin_cache_evictions_total",
+				Help:      "BINs evicted from the cache to stay within its size.",
+			},
+		),
+	}
+
+	reg.MustRegister(r.validations, r.binLookups, r.riskSignals, r.batchSizes, r.binCacheLookups, r.binCacheEvictions)
+
+	return r
+}
+
+func (r *Recorder) ValidationCompleted(network string, outcome string, reason string) {
+	r.validations.WithLabelValues(network, outcome, reason).Inc()
+}
+
+func (r *Recorder) BinLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	r.binLookups.WithLabelValues(result).Inc()
+}
+
+func (r *Recorder) RiskSignal(signal string) {
+	r.riskSignals.WithLabelValues(signal).Inc()
+}
+
+func (r *Recorder) ValidationBatch(size int) {
+	r.batchSizes.Observe(float64(size))
+}
+
+func (r *Recorder) BinCacheLookup(result string) {
+	r.binCacheLookups.WithLabelValues(result).Inc()
+}
+
+func (r *Recorder) BinCacheEvicted() {
+	r.binCacheEvictions.Inc()
+}
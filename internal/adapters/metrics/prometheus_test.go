@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	recorder := NewRecorder(reg)
+
+	recorder.ValidationCompleted("VISA", "accepted", "")
+	recorder.ValidationCompleted("VISA", "accepted", "")
+	recorder.ValidationCompleted("UNKNOWN", "rejected", "luhn_check_failed")
+	recorder.BinLookup(true)
+	recorder.BinLookup(false)
+	recorder.BinLookup(true)
+	recorder.RiskSignal("card_velocity_exceeded")
+	recorder.ValidationBatch(20)
+	recorder.BinCacheLookup("hit")
+	recorder.BinCacheLookup("miss")
+	recorder.BinCacheLookup("hit")
+	recorder.BinCacheEvicted()
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(recorder.validations.WithLabelValues("VISA", "accepted", "")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.validations.WithLabelValues("UNKNOWN", "rejected", "luhn_check_failed")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(recorder.binLookups.WithLabelValues("hit")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.binLookups.WithLabelValues("miss")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.riskSignals.WithLabelValues("card_velocity_exceeded")))
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.batchSizes))
+	assert.Equal(t, float64(2), testutil.ToFloat64(recorder.binCacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.binCacheLookups.WithLabelValues("miss")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.binCacheEvictions))
+}
+
+func TestServer(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	recorder := NewRecorder(reg)
+	recorder.ValidationCompleted("AMEX", "accepted", "")
+	recorder.ValidationBatch(20)
+	recorder.ValidationBatch(3)
+
+	srv := NewServer(9090, reg)
+	assert.Equal(t, ":9090", srv.Addr)
+
+	rec := httptest.NewRecorder()
+	srv.Handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 200, rec.Code)
+	assert.Contains(t, string(body), `cards_validations_total{network="AMEX",outcome="accepted",reason=""} 1`)
+	assert.Contains(t, string(body), `cards_validation_batch_size_bucket{le="5"} 1`)
+	assert.Contains(t, string(body), "cards_validation_batch_size_sum 23")
+}
@@ -0,0 +1,318 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// minRefreshInterval limits refreshes triggered by tokens signed with unknown key IDs, and
+// attempts to refresh stale keys after one has failed.
+const minRefreshInterval = 30 * time.Second
+
+// backgroundRefreshTimeout bounds refreshes without a deadline of their own, such as those
+// of stale keys, which no request waits for.
+const backgroundRefreshTimeout = 10 * time.Second
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refreshCall is a refresh in progress, which concurrent callers wait for instead of
+// starting their own.
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// KeySet holds the public keys from a JWKS document loaded from a file or URL.
+type KeySet struct {
+	source     string
+	client     *http.Client
+	refreshTTL time.Duration
+
+	mu          sync.RWMutex
+	keys        map[string]any
+	refreshedAt time.Time
+	attemptedAt time.Time
+	refreshing  *refreshCall
+}
+
+// NewKeySet loads the JWKS at source, which may be a local path or an http(s) URL.
+// Keys are refreshed once refreshTTL has elapsed or when an unknown key ID is seen.
+func NewKeySet(ctx context.Context, source string, refreshTTL time.Duration, client *http.Client) (*KeySet, error) {
+	ks := &KeySet{source: source, client: client, refreshTTL: refreshTTL}
+
+	if err := ks.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return ks, nil
+}
+
+// Key returns the public key with the given key ID. Stale keys are served while they are
+// refreshed in the background, so callers do not wait on the JWKS source, nor are rejected
+// while it is down.
+func (ks *KeySet) Key(ctx context.Context, kid string) (any, error) {
+	key, ok, stale, retry := ks.lookup(kid)
+
+	switch {
+	case ok:
+		if stale && retry {
+			go ks.refresh(context.Background())
+		}
+		return key, nil
+	case !retry:
+		return nil, errors.NewErrorf(errors.Unauthenticated, "unknown signing key %q", kid)
+	}
+
+	// The key may have been added since the last refresh.
+	if err := ks.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if key, ok, _, _ := ks.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, errors.NewErrorf(errors.Unauthenticated, "unknown signing key %q", kid)
+}
+
+// Check reports whether signing keys are available, refreshing them once they are stale.
+// Stale keys still count, as Key keeps serving them while the source is unreachable.
+func (ks *KeySet) Check(ctx context.Context) error {
+	_, _, stale, retry := ks.lookup("")
+
+	var err error
+	if stale && retry {
+		err = ks.refresh(ctx)
+	}
+
+	ks.mu.RLock()
+	loaded := len(ks.keys)
+	ks.mu.RUnlock()
+
+	if loaded == 0 {
+		if err != nil {
+			return err
+		}
+		return errors.NewErrorf(errors.ServiceUnavailable, "no signing keys loaded from %s", ks.source)
+	}
+
+	return nil
+}
+
+// lookup returns the key with the given ID, whether the keys are due for a refresh, and
+// whether enough time has passed since the last attempt to make another.
+func (ks *KeySet) lookup(kid string) (key any, ok, stale, retry bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	key, ok = ks.keys[kid]
+
+	return key, ok, time.Since(ks.refreshedAt) >= ks.refreshTTL, time.Since(ks.attemptedAt) >= minRefreshInterval
+}
+
+// refresh loads the keys again. Only one refresh runs at a time; callers arriving while one
+// is in progress wait for its result.
+func (ks *KeySet) refresh(ctx context.Context) error {
+	ks.mu.Lock()
+	if call := ks.refreshing; call != nil {
+		ks.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return errors.WrapError(ctx.Err(), errors.ServiceUnavailable, "gave up waiting for the JWKS")
+		}
+	}
+
+	call := &refreshCall{done: make(chan struct{})}
+	ks.refreshing = call
+	ks.attemptedAt = time.Now()
+	ks.mu.Unlock()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, backgroundRefreshTimeout)
+		defer cancel()
+	}
+
+	call.err = ks.load(ctx)
+
+	ks.mu.Lock()
+	ks.refreshing = nil
+	ks.mu.Unlock()
+	close(call.done)
+
+	return call.err
+}
+
+func (ks *KeySet) load(ctx context.Context) error {
+	data, err := ks.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.keys = keys
+	ks.refreshedAt = time.Now()
+
+	return nil
+}
+
+func (ks *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	if !isURL(ks.source) {
+		data, err := os.ReadFile(ks.source)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to read JWKS file")
+		}
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.source, nil)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to build JWKS request")
+	}
+
+	resp, err := ks.client.Do(req)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ServiceUnavailable, "failed to fetch JWKS")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.NewErrorf(errors.ServiceUnavailable, "JWKS endpoint returned %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ServiceUnavailable, "failed to read JWKS response")
+	}
+
+	return data, nil
+}
+
+func parseJWKS(data []byte) (map[string]any, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to parse JWKS")
+	}
+
+	keys := make(map[string]any, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "invalid JWKS key %q", jwk.Kid)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.NewErrorf(errors.Internal, "JWKS has no signing keys")
+	}
+
+	return keys, nil
+}
+
+func (jwk jsonWebKey) publicKey() (any, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid base64url integer")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
@@ -0,0 +1,166 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet(t *testing.T) {
+	ctx := context.Background()
+	keys := newTestKeys(t)
+
+	t.Run("From URL", func(t *testing.T) {
+		var hits atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			w.Write(keys.jwks(t))
+		}))
+		defer server.Close()
+
+		ks, err := NewKeySet(ctx, server.URL, time.Hour, server.Client())
+		require.NoError(t, err)
+
+		_, err = ks.Key(ctx, "rsa-1")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), hits.Load())
+
+		// Unknown key IDs do not trigger refreshes more often than minRefreshInterval.
+		_, err = ks.Key(ctx, "missing")
+		require.Error(t, err)
+		assert.Equal(t, int32(1), hits.Load())
+
+		ks.attemptedAt = time.Now().Add(-time.Minute)
+		_, err = ks.Key(ctx, "missing")
+		require.Error(t, err)
+		assert.Equal(t, int32(2), hits.Load())
+	})
+
+	t.Run("Endpoint Down", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		_, err := NewKeySet(ctx, server.URL, time.Hour, server.Client())
+		require.Error(t, err)
+	})
+
+	t.Run("Serves Stale Keys When Refresh Fails", func(t *testing.T) {
+		path := keys.writeJWKS(t)
+
+		ks, err := NewKeySet(ctx, path, time.Minute, http.DefaultClient)
+		require.NoError(t, err)
+
+		require.NoError(t, os.Remove(path))
+		ks.refreshedAt = time.Now().Add(-time.Hour)
+		ks.attemptedAt = ks.refreshedAt
+
+		_, err = ks.Key(ctx, "ec-1")
+		require.NoError(t, err)
+	})
+
+	t.Run("Refreshes Once At A Time And Not After Every Failure", func(t *testing.T) {
+		var hits atomic.Int32
+		var down atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			if down.Load() {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.Write(keys.jwks(t))
+		}))
+		defer server.Close()
+
+		ks, err := NewKeySet(ctx, server.URL, time.Minute, server.Client())
+		require.NoError(t, err)
+
+		down.Store(true)
+		ks.refreshedAt = time.Now().Add(-time.Hour)
+		ks.attemptedAt = ks.refreshedAt
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, ks.Check(ctx))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(2), hits.Load(), "concurrent checks share one refresh")
+
+		for range 5 {
+			_, err := ks.Key(ctx, "rsa-1")
+			require.NoError(t, err)
+			require.NoError(t, ks.Check(ctx))
+		}
+		assert.Equal(t, int32(2), hits.Load(), "the failed attempt holds off the next for minRefreshInterval")
+	})
+
+	t.Run("Picks Up Rotated Keys", func(t *testing.T) {
+		path := keys.writeJWKS(t)
+
+		ks, err := NewKeySet(ctx, path, time.Minute, http.DefaultClient)
+		require.NoError(t, err)
+
+		rotated := newTestKeys(t)
+		require.NoError(t, os.WriteFile(path, rotated.jwks(t), 0600))
+		ks.refreshedAt = time.Now().Add(-time.Hour)
+		ks.attemptedAt = ks.refreshedAt
+
+		key, err := ks.Key(ctx, "rsa-1")
+		require.NoError(t, err)
+		assert.Equal(t, &keys.rsa.PublicKey, key, "the stale key is served while it is refreshed")
+
+		assert.Eventually(t, func() bool {
+			key, err := ks.Key(ctx, "rsa-1")
+			return err == nil && assert.ObjectsAreEqual(&rotated.rsa.PublicKey, key)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		path := keys.writeJWKS(t)
+
+		ks, err := NewKeySet(ctx, path, time.Minute, http.DefaultClient)
+		require.NoError(t, err)
+		require.NoError(t, ks.Check(ctx))
+
+		require.NoError(t, os.Remove(path))
+		ks.refreshedAt = time.Now().Add(-time.Hour)
+		ks.attemptedAt = ks.refreshedAt
+		assert.NoError(t, ks.Check(ctx), "stale keys are still usable")
+
+		ks.keys = nil
+		assert.Error(t, ks.Check(ctx))
+	})
+
+	t.Run("Invalid Documents", func(t *testing.T) {
+		docs := []string{
+			`not json`,
+			`{"keys": []}`,
+			`{"keys": [{"kty": "EC", "kid": "a", "crv": "P-192", "x": "AA", "y": "AA"}]}`,
+			`{"keys": [{"kty": "oct", "kid": "a", "k": "c2VjcmV0"}]}`,
+			`{"keys": [{"kty": "RSA", "kid": "a", "n": "", "e": "AQAB"}]}`,
+		}
+
+		for _, doc := range docs {
+			path := filepath.Join(t.TempDir(), "jwks.json")
+			require.NoError(t, os.WriteFile(path, []byte(doc), 0600))
+
+			_, err := NewKeySet(ctx, path, time.Minute, http.DefaultClient)
+			assert.Error(t, err, doc)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package oidc
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type VerifierConfig struct {
+	Issuer    string
+	Audience  string
+	ClockSkew time.Duration
+	// AppClaim names the claim that identifies the calling app, such as azp or client_id.
+	AppClaim string
+}
+
+// Verifier validates JWT access tokens against a JWKS and maps their claims to a principal.
+type Verifier struct {
+	keys   *KeySet
+	config VerifierConfig
+	parser *jwt.Parser
+}
+
+func NewVerifier(keys *KeySet, config VerifierConfig) *Verifier {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(config.Issuer),
+		jwt.WithAudience(config.Audience),
+		jwt.WithLeeway(config.ClockSkew),
+		jwt.WithExpirationRequired(),
+	)
+
+	return &Verifier{keys: keys, config: config, parser: parser}
+}
+
+func (v *Verifier) Verify(ctx context.Context, token string) (*domain.Principal, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Unauthenticated, "invalid bearer token")
+	}
+
+	subject, _ := claims.GetSubject()
+
+	principal := &domain.Principal{
+		Subject: subject,
+		AppID:   stringClaim(claims, v.config.AppClaim),
+		Scopes:  scopes(claims),
+	}
+	if principal.AppID == "" {
+		principal.AppID = subject
+	}
+
+	return principal, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// scopes reads the space separated OAuth scope claim, falling back to an scp array.
+func scopes(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+
+	var out []string
+	switch scp := claims["scp"].(type) {
+	case string:
+		out = strings.Fields(scp)
+	case []any:
+		for _, s := range scp {
+			if s, ok := s.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+
+	return out
+}
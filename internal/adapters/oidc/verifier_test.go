@@ -0,0 +1,199 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "cards-service"
+)
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return &testKeys{rsa: rsaKey, ec: ecKey}
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (k *testKeys) jwks(t *testing.T) []byte {
+	doc := map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": "rsa-1", "use": "sig",
+				"n": b64(k.rsa.N.Bytes()), "e": b64(big.NewInt(int64(k.rsa.E)).Bytes()),
+			},
+			{
+				"kty": "EC", "kid": "ec-1", "crv": "P-256",
+				"x": b64(k.ec.X.FillBytes(make([]byte, 32))), "y": b64(k.ec.Y.FillBytes(make([]byte, 32))),
+			},
+			{"kty": "RSA", "kid": "enc-1", "use": "enc"},
+		},
+	}
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	return data
+}
+
+func (k *testKeys) writeJWKS(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, k.jwks(t), 0600))
+
+	return path
+}
+
+func (k *testKeys) sign(t *testing.T, kid string, claims jwt.MapClaims) string {
+	var token *jwt.Token
+	var key any
+
+	if kid == "ec-1" {
+		token, key = jwt.NewWithClaims(jwt.SigningMethodES256, claims), k.ec
+	} else {
+		token, key = jwt.NewWithClaims(jwt.SigningMethodRS256, claims), k.rsa
+	}
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"sub":   "service-account-1",
+		"azp":   "checkout-app",
+		"scope": "cards.validate cards.usage",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func newTestVerifier(t *testing.T, keys *testKeys) *Verifier {
+	ks, err := NewKeySet(context.Background(), keys.writeJWKS(t), time.Hour, http.DefaultClient)
+	require.NoError(t, err)
+
+	return NewVerifier(ks, VerifierConfig{
+		Issuer:    testIssuer,
+		Audience:  testAudience,
+		ClockSkew: 30 * time.Second,
+		AppClaim:  "azp",
+	})
+}
+
+func TestVerifier(t *testing.T) {
+	ctx := context.Background()
+	keys := newTestKeys(t)
+	verifier := newTestVerifier(t, keys)
+
+	t.Run("Valid RSA Token", func(t *testing.T) {
+		principal, err := verifier.Verify(ctx, keys.sign(t, "rsa-1", validClaims()))
+		require.NoError(t, err)
+
+		assert.Equal(t, "service-account-1", principal.Subject)
+		assert.Equal(t, "checkout-app", principal.AppID)
+		assert.Equal(t, []string{"cards.validate", "cards.usage"}, principal.Scopes)
+	})
+
+	t.Run("Valid EC Token With Scope Array", func(t *testing.T) {
+		claims := validClaims()
+		delete(claims, "scope")
+		delete(claims, "azp")
+		claims["scp"] = []string{"cards.validate"}
+
+		principal, err := verifier.Verify(ctx, keys.sign(t, "ec-1", claims))
+		require.NoError(t, err)
+
+		assert.Equal(t, "service-account-1", principal.AppID)
+		assert.Equal(t, []string{"cards.validate"}, principal.Scopes)
+	})
+
+	t.Run("Expiry Within Clock Skew", func(t *testing.T) {
+		claims := validClaims()
+		claims["exp"] = time.Now().Add(-10 * time.Second).Unix()
+
+		_, err := verifier.Verify(ctx, keys.sign(t, "rsa-1", claims))
+		require.NoError(t, err)
+	})
+
+	t.Run("Audience In List", func(t *testing.T) {
+		claims := validClaims()
+		claims["aud"] = []string{"other-service", testAudience}
+
+		_, err := verifier.Verify(ctx, keys.sign(t, "rsa-1", claims))
+		require.NoError(t, err)
+	})
+
+	t.Run("Rejected Tokens", func(t *testing.T) {
+		tests := map[string]func(jwt.MapClaims){
+			"Expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+			"Missing Expiry": func(c jwt.MapClaims) { delete(c, "exp") },
+			"Wrong Audience": func(c jwt.MapClaims) { c["aud"] = "other-service" },
+			"Wrong Issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+			"Not Yet Valid":  func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(time.Minute).Unix() },
+		}
+
+		for name, mutate := range tests {
+			t.Run(name, func(t *testing.T) {
+				claims := validClaims()
+				mutate(claims)
+
+				_, err := verifier.Verify(ctx, keys.sign(t, "rsa-1", claims))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid bearer token")
+			})
+		}
+	})
+
+	t.Run("Unknown Key", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, keys.sign(t, "rsa-2", validClaims()))
+		require.Error(t, err)
+	})
+
+	t.Run("Signed By Another Key", func(t *testing.T) {
+		other := newTestKeys(t)
+
+		_, err := verifier.Verify(ctx, other.sign(t, "rsa-1", validClaims()))
+		require.Error(t, err)
+	})
+
+	t.Run("Unsigned Token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims())
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = verifier.Verify(ctx, signed)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,222 @@
+package ratelimit
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// idleBucketTTL is how long an untouched bucket is kept before it is swept.
+const idleBucketTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens earned since the bucket was last touched and reports how long the
+// caller should wait for a whole token, or zero when one is available.
+func (b *bucket) refill(policy domain.RateLimitPolicy, now time.Time) time.Duration {
+	burst := burstOf(policy)
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*policy.RequestsPerSecond)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		return 0
+	}
+
+	wait := (1 - b.tokens) / policy.RequestsPerSecond
+	return time.Duration(math.Ceil(wait * float64(time.Second)))
+}
+
+type quotaCounter struct {
+	dayEnds   time.Time
+	monthEnds time.Time
+	daily     int64
+	monthly   int64
+	last      time.Time
+}
+
+func (q *quotaCounter) roll(now time.Time) {
+	nextDay, nextMonth := domain.QuotaWindows(now)
+
+	if !now.Before(q.dayEnds) {
+		q.daily = 0
+		q.dayEnds = nextDay
+	}
+
+	if !now.Before(q.monthEnds) {
+		q.monthly = 0
+		q.monthEnds = nextMonth
+	}
+}
+
+// MemoryLimiter keeps token buckets and quota counters in process memory.
+// It is suitable for a single replica; use RedisLimiter to share state.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	limits    *domain.RateLimits
+	apps      map[string]*bucket
+	peers     map[string]*bucket
+	quotas    map[string]*quotaCounter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryLimiter(limits *domain.RateLimits) *MemoryLimiter {
+	return &MemoryLimiter{
+		limits: limits,
+		apps:   make(map[string]*bucket),
+		peers:  make(map[string]*bucket),
+		quotas: make(map[string]*quotaCounter),
+		now:    time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, appID string, peer string) (*domain.LimitDecision, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	// Every limit is checked before any is consumed, so a rejected request costs nothing.
+	var buckets []*bucket
+
+	if peer != "" && l.limits.Peer.RequestsPerSecond > 0 {
+		b := l.bucketFor(l.peers, peer, l.limits.Peer, now)
+		if wait := b.refill(l.limits.Peer, now); wait > 0 {
+			return &domain.LimitDecision{Reason: domain.ReasonPeerRateLimited, RetryAfter: wait}, nil
+		}
+		buckets = append(buckets, b)
+	}
+
+	policy := l.limits.ForApp(appID)
+	if policy.RequestsPerSecond > 0 {
+		b := l.bucketFor(l.apps, appID, policy, now)
+		if wait := b.refill(policy, now); wait > 0 {
+			return &domain.LimitDecision{Reason: domain.ReasonAppRateLimited, RetryAfter: wait}, nil
+		}
+		buckets = append(buckets, b)
+	}
+
+	// Requests are only counted for apps with a quota.
+	var counter *quotaCounter
+	if policy.DailyQuota > 0 || policy.MonthlyQuota > 0 {
+		counter = l.quotaFor(appID, now)
+		if policy.DailyQuota > 0 && counter.daily >= policy.DailyQuota {
+			return &domain.LimitDecision{Reason: domain.ReasonDailyQuotaExceeded, RetryAfter: counter.dayEnds.Sub(now)}, nil
+		}
+
+		if policy.MonthlyQuota > 0 && counter.monthly >= policy.MonthlyQuota {
+			return &domain.LimitDecision{Reason: domain.ReasonMonthlyQuotaExceeded, RetryAfter: counter.monthEnds.Sub(now)}, nil
+		}
+	}
+
+	for _, b := range buckets {
+		b.tokens--
+	}
+	if counter != nil {
+		counter.daily++
+		counter.monthly++
+		counter.last = now
+	}
+
+	return &domain.LimitDecision{Allowed: true}, nil
+}
+
+func (l *MemoryLimiter) Usage(ctx context.Context, appID string) (*domain.Usage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	policy := l.limits.ForApp(appID)
+
+	// Reading usage does not keep a counter for apps that have made no requests.
+	counter, ok := l.quotas[appID]
+	if !ok {
+		counter = &quotaCounter{}
+	}
+	counter.roll(l.now())
+
+	return &domain.Usage{
+		AppID:             appID,
+		RequestsPerSecond: policy.RequestsPerSecond,
+		Burst:             policy.Burst,
+		Daily:             domain.QuotaUsage{Used: counter.daily, Limit: policy.DailyQuota, ResetsAt: counter.dayEnds},
+		Monthly:           domain.QuotaUsage{Used: counter.monthly, Limit: policy.MonthlyQuota, ResetsAt: counter.monthEnds},
+	}, nil
+}
+
+func (l *MemoryLimiter) SetLimits(limits *domain.RateLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limits = limits
+}
+
+func (l *MemoryLimiter) bucketFor(buckets map[string]*bucket, key string, policy domain.RateLimitPolicy, now time.Time) *bucket {
+	b, ok := buckets[key]
+	if !ok {
+		b = &bucket{tokens: burstOf(policy), last: now}
+		buckets[key] = b
+	}
+
+	return b
+}
+
+func (l *MemoryLimiter) quotaFor(appID string, now time.Time) *quotaCounter {
+	counter, ok := l.quotas[appID]
+	if !ok {
+		counter = &quotaCounter{}
+		l.quotas[appID] = counter
+	}
+	counter.roll(now)
+
+	return counter
+}
+
+// sweep drops buckets that have been idle long enough to have refilled completely, and idle
+// quota counters that no longer count toward a quota, which keeps the maps keyed by
+// client-supplied values from growing without bound.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleBucketTTL {
+		return
+	}
+	l.lastSweep = now
+
+	for _, buckets := range []map[string]*bucket{l.apps, l.peers} {
+		for key, b := range buckets {
+			if now.Sub(b.last) > idleBucketTTL {
+				delete(buckets, key)
+			}
+		}
+	}
+
+	for appID, counter := range l.quotas {
+		if now.Sub(counter.last) <= idleBucketTTL {
+			continue
+		}
+
+		// A counter is only needed while its count toward a quota is above zero.
+		counter.roll(now)
+		policy := l.limits.ForApp(appID)
+		if (counter.daily == 0 || policy.DailyQuota == 0) && (counter.monthly == 0 || policy.MonthlyQuota == 0) {
+			delete(l.quotas, appID)
+		}
+	}
+}
+
+// burstOf returns the bucket capacity, defaulting to one second worth of tokens.
+func burstOf(policy domain.RateLimitPolicy) float64 {
+	if policy.Burst > 0 {
+		return float64(policy.Burst)
+	}
+
+	return math.Max(1, math.Ceil(policy.RequestsPerSecond))
+}
@@ -0,0 +1,262 @@
+package ratelimit
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 31, 23, 59, 0, 0, time.UTC)}
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("App Token Bucket", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App: domain.RateLimitPolicy{RequestsPerSecond: 1, Burst: 2},
+		})
+		limiter.now = clock.Now
+
+		for range 2 {
+			decision, err := limiter.Allow(ctx, "app-1", "")
+			require.NoError(t, err)
+			assert.True(t, decision.Allowed)
+		}
+
+		decision, err := limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonAppRateLimited, decision.Reason)
+		assert.Equal(t, time.Second, decision.RetryAfter)
+
+		decision, err = limiter.Allow(ctx, "app-2", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "buckets are per app")
+
+		clock.Advance(time.Second)
+		decision, err = limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	})
+
+	t.Run("Peer Token Bucket", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			Peer: domain.RateLimitPolicy{RequestsPerSecond: 1, Burst: 1},
+		})
+		limiter.now = clock.Now
+
+		decision, err := limiter.Allow(ctx, "app-1", "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		decision, err = limiter.Allow(ctx, "app-2", "10.0.0.1")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonPeerRateLimited, decision.Reason)
+	})
+
+	t.Run("Per App Overrides", func(t *testing.T) {
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App:  domain.RateLimitPolicy{DailyQuota: 1},
+			Apps: map[string]domain.RateLimitPolicy{"vip": {DailyQuota: 3}},
+		})
+
+		allowed := 0
+		for range 5 {
+			decision, err := limiter.Allow(ctx, "vip", "")
+			require.NoError(t, err)
+			if decision.Allowed {
+				allowed++
+			}
+		}
+
+		assert.Equal(t, 3, allowed)
+	})
+
+	t.Run("Quotas Reset With Their Window", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App: domain.RateLimitPolicy{DailyQuota: 1, MonthlyQuota: 2},
+		})
+		limiter.now = clock.Now
+
+		decision, err := limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		decision, err = limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonDailyQuotaExceeded, decision.Reason)
+		assert.Equal(t, time.Minute, decision.RetryAfter)
+
+		// Crossing midnight starts a new day and, on the 1st, a new month.
+		clock.Advance(2 * time.Minute)
+		decision, err = limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		usage, err := limiter.Usage(ctx, "app-1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), usage.Daily.Used)
+		assert.Equal(t, int64(1), usage.Monthly.Used)
+		assert.Equal(t, time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC), usage.Daily.ResetsAt)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), usage.Monthly.ResetsAt)
+	})
+
+	t.Run("Monthly Quota", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App: domain.RateLimitPolicy{MonthlyQuota: 1},
+		})
+		limiter.now = clock.Now
+
+		clock.Advance(2 * time.Minute)
+		decision, err := limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		clock.Advance(24 * time.Hour)
+		decision, err = limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonMonthlyQuotaExceeded, decision.Reason)
+	})
+
+	t.Run("Limits Can Change At Runtime", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App: domain.RateLimitPolicy{DailyQuota: 1},
+		})
+		limiter.now = clock.Now
+
+		decision, err := limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		limiter.SetLimits(&domain.RateLimits{App: domain.RateLimitPolicy{DailyQuota: 2}})
+
+		decision, err = limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "usage is kept across changes")
+
+		decision, err = limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+	})
+
+	t.Run("Rejected Requests Use Up No Limit", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App:  domain.RateLimitPolicy{RequestsPerSecond: 1, Burst: 1, DailyQuota: 1},
+			Peer: domain.RateLimitPolicy{RequestsPerSecond: 1, Burst: 1},
+		})
+		limiter.now = clock.Now
+
+		decision, err := limiter.Allow(ctx, "app-1", "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		// app-1 is out of tokens, so the peer keeps its own for app-2.
+		decision, err = limiter.Allow(ctx, "app-1", "10.0.0.2")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReasonAppRateLimited, decision.Reason)
+
+		decision, err = limiter.Allow(ctx, "app-2", "10.0.0.2")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		// app-1 is out of quota, so neither its bucket nor the peer's is drawn on.
+		clock.Advance(time.Second)
+		decision, err = limiter.Allow(ctx, "app-1", "10.0.0.3")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReasonDailyQuotaExceeded, decision.Reason)
+
+		decision, err = limiter.Allow(ctx, "app-3", "10.0.0.3")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, 1.0, limiter.apps["app-1"].tokens)
+	})
+
+	t.Run("Idle Entries Are Swept", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App:  domain.RateLimitPolicy{RequestsPerSecond: 1},
+			Peer: domain.RateLimitPolicy{RequestsPerSecond: 1},
+		})
+		limiter.now = clock.Now
+
+		for i, appID := range []string{"app-1", "app-2", "app-3"} {
+			decision, err := limiter.Allow(ctx, appID, fmt.Sprintf("10.0.0.%d", i))
+			require.NoError(t, err)
+			require.True(t, decision.Allowed)
+		}
+		_, err := limiter.Usage(ctx, "unknown")
+		require.NoError(t, err)
+		assert.Empty(t, limiter.quotas, "apps without a quota are not counted")
+
+		// The next request sweeps what the others left behind.
+		clock.Advance(idleBucketTTL + time.Minute)
+		_, err = limiter.Allow(ctx, "app-4", "10.0.0.9")
+		require.NoError(t, err)
+		assert.Len(t, limiter.apps, 1)
+		assert.Len(t, limiter.peers, 1)
+	})
+
+	t.Run("Idle Quota Counters Are Swept Once Their Count Lapses", func(t *testing.T) {
+		clock := newClock()
+		limiter := NewMemoryLimiter(&domain.RateLimits{
+			App: domain.RateLimitPolicy{DailyQuota: 10},
+			Apps: map[string]domain.RateLimitPolicy{
+				"monthly": {MonthlyQuota: 10},
+			},
+		})
+		limiter.now = clock.Now
+
+		for _, appID := range []string{"daily", "monthly"} {
+			decision, err := limiter.Allow(ctx, appID, "")
+			require.NoError(t, err)
+			require.True(t, decision.Allowed)
+		}
+		assert.Len(t, limiter.quotas, 2)
+
+		// The day and the month end a minute later, so both counts lapse.
+		clock.Advance(idleBucketTTL + time.Minute)
+		_, err := limiter.Allow(ctx, "other", "")
+		require.NoError(t, err)
+		assert.Len(t, limiter.quotas, 1)
+		assert.Contains(t, limiter.quotas, "other")
+
+		// The next counts last until the end of February, and only the daily one lapses.
+		for _, appID := range []string{"daily", "monthly"} {
+			_, err := limiter.Allow(ctx, appID, "")
+			require.NoError(t, err)
+		}
+		clock.Advance(24 * time.Hour)
+		_, err = limiter.Allow(ctx, "other", "")
+		require.NoError(t, err)
+		assert.Contains(t, limiter.quotas, "monthly")
+		assert.NotContains(t, limiter.quotas, "daily")
+	})
+}
@@ -0,0 +1,232 @@
+package ratelimit
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills and takes from a bucket stored as a hash.
+// It returns {allowed, retry_after_ms}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+
+return {allowed, wait}
+`)
+
+// refundScript gives back a token taken for a request that a later check rejected.
+var refundScript = redis.NewScript(`
+local tokens = tonumber(redis.call('HGET', KEYS[1], 'tokens'))
+if tokens ~= nil then
+  redis.call('HSET', KEYS[1], 'tokens', tostring(math.min(tonumber(ARGV[1]), tokens + 1)))
+end
+return 0
+`)
+
+// quotaScript checks the daily and monthly counters and increments both when neither is exhausted.
+// It returns 0 when allowed, 1 when the daily quota is exhausted and 2 for the monthly quota.
+var quotaScript = redis.NewScript(`
+local daily = tonumber(redis.call('GET', KEYS[1]) or '0')
+local monthly = tonumber(redis.call('GET', KEYS[2]) or '0')
+
+if tonumber(ARGV[1]) > 0 and daily >= tonumber(ARGV[1]) then
+  return 1
+end
+
+if tonumber(ARGV[2]) > 0 and monthly >= tonumber(ARGV[2]) then
+  return 2
+end
+
+redis.call('INCR', KEYS[1])
+redis.call('EXPIREAT', KEYS[1], ARGV[3])
+redis.call('INCR', KEYS[2])
+redis.call('EXPIREAT', KEYS[2], ARGV[4])
+
+return 0
+`)
+
+// RedisLimiter shares token buckets and quota counters between replicas through Redis.
+type RedisLimiter struct {
+	client redis.UniversalClient
+	limits atomic.Pointer[domain.RateLimits]
+	prefix string
+	now    func() time.Time
+}
+
+func NewRedisLimiter(client redis.UniversalClient, limits *domain.RateLimits, prefix string) *RedisLimiter {
+	l := &RedisLimiter{client: client, prefix: prefix, now: time.Now}
+	l.limits.Store(limits)
+
+	return l
+}
+
+// Check reports whether Redis is reachable.
+func (l *RedisLimiter) Check(ctx context.Context) error {
+	if err := l.client.Ping(ctx).Err(); err != nil {
+		return errors.WrapError(err, errors.ServiceUnavailable, "rate limit store unreachable")
+	}
+
+	return nil
+}
+
+func (l *RedisLimiter) SetLimits(limits *domain.RateLimits) {
+	l.limits.Store(limits)
+}
+
+// Allow takes from the peer bucket, the app bucket and the quotas in turn. The buckets live
+// in different cluster slots, so they cannot be updated by one script; tokens taken for a
+// request that a later check rejects are given back instead, so a rejected request costs
+// nothing.
+func (l *RedisLimiter) Allow(ctx context.Context, appID string, peer string) (decision *domain.LimitDecision, err error) {
+	now := l.now()
+	limits := l.limits.Load()
+
+	var taken []refund
+	defer func() {
+		if err == nil && decision.Allowed {
+			return
+		}
+		for _, r := range taken {
+			if rerr := refundScript.Run(ctx, l.client, []string{r.key}, burstOf(r.policy)).Err(); rerr != nil && err == nil {
+				err = errors.WrapError(rerr, errors.ServiceUnavailable, "failed to refund rate limit bucket")
+			}
+		}
+	}()
+
+	if peer != "" && limits.Peer.RequestsPerSecond > 0 {
+		key := fmt.Sprintf("%s:peer:%s", l.prefix, peer)
+		ok, wait, err := l.take(ctx, key, limits.Peer, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return &domain.LimitDecision{Reason: domain.ReasonPeerRateLimited, RetryAfter: wait}, nil
+		}
+		taken = append(taken, refund{key, limits.Peer})
+	}
+
+	policy := limits.ForApp(appID)
+	if policy.RequestsPerSecond > 0 {
+		key := fmt.Sprintf("%s:app:{%s}:bucket", l.prefix, appID)
+		ok, wait, err := l.take(ctx, key, policy, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return &domain.LimitDecision{Reason: domain.ReasonAppRateLimited, RetryAfter: wait}, nil
+		}
+		taken = append(taken, refund{key, policy})
+	}
+
+	nextDay, nextMonth := domain.QuotaWindows(now)
+	dailyKey, monthlyKey := l.quotaKeys(appID, now)
+
+	res, err := quotaScript.Run(
+		ctx, l.client, []string{dailyKey, monthlyKey},
+		policy.DailyQuota, policy.MonthlyQuota, nextDay.Unix(), nextMonth.Unix(),
+	).Int()
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ServiceUnavailable, "failed to update quota counters")
+	}
+
+	switch res {
+	case 1:
+		return &domain.LimitDecision{Reason: domain.ReasonDailyQuotaExceeded, RetryAfter: nextDay.Sub(now)}, nil
+	case 2:
+		return &domain.LimitDecision{Reason: domain.ReasonMonthlyQuotaExceeded, RetryAfter: nextMonth.Sub(now)}, nil
+	}
+
+	return &domain.LimitDecision{Allowed: true}, nil
+}
+
+func (l *RedisLimiter) Usage(ctx context.Context, appID string) (*domain.Usage, error) {
+	now := l.now()
+	policy := l.limits.Load().ForApp(appID)
+	nextDay, nextMonth := domain.QuotaWindows(now)
+	dailyKey, monthlyKey := l.quotaKeys(appID, now)
+
+	values, err := l.client.MGet(ctx, dailyKey, monthlyKey).Result()
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ServiceUnavailable, "failed to read quota counters")
+	}
+
+	return &domain.Usage{
+		AppID:             appID,
+		RequestsPerSecond: policy.RequestsPerSecond,
+		Burst:             policy.Burst,
+		Daily:             domain.QuotaUsage{Used: counterValue(values[0]), Limit: policy.DailyQuota, ResetsAt: nextDay},
+		Monthly:           domain.QuotaUsage{Used: counterValue(values[1]), Limit: policy.MonthlyQuota, ResetsAt: nextMonth},
+	}, nil
+}
+
+func (l *RedisLimiter) take(ctx context.Context, key string, policy domain.RateLimitPolicy, now time.Time) (bool, time.Duration, error) {
+	res, err := tokenBucketScript.Run(
+		ctx, l.client, []string{key},
+		policy.RequestsPerSecond, burstOf(policy), now.UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, errors.WrapError(err, errors.ServiceUnavailable, "failed to update rate limit bucket")
+	}
+
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// refund names a bucket a token was taken from.
+type refund struct {
+	key    string
+	policy domain.RateLimitPolicy
+}
+
+// quotaKeys share a hash tag so both counters land on the same cluster slot.
+func (l *RedisLimiter) quotaKeys(appID string, now time.Time) (string, string) {
+	now = now.UTC()
+
+	return fmt.Sprintf("%s:app:{%s}:daily:%s", l.prefix, appID, now.Format("20060102")),
+		fmt.Sprintf("%s:app:{%s}:monthly:%s", l.prefix, appID, now.Format("200601"))
+}
+
+func counterValue(v any) int64 {
+	s, ok := v.(string)
+	if !ok {
+		return 0
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
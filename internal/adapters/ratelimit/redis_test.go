@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisLimiter(t *testing.T, limits *domain.RateLimits) (*RedisLimiter, *fakeClock) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	clock := newClock()
+	mr.SetTime(clock.now)
+
+	limiter := NewRedisLimiter(client, limits, "cards-test")
+	limiter.now = clock.Now
+
+	return limiter, clock
+}
+
+func TestRedisLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Token Buckets", func(t *testing.T) {
+		limiter, clock := newRedisLimiter(t, &domain.RateLimits{
+			App:  domain.RateLimitPolicy{RequestsPerSecond: 2, Burst: 1},
+			Peer: domain.RateLimitPolicy{RequestsPerSecond: 10, Burst: 1},
+		})
+
+		decision, err := limiter.Allow(ctx, "app-1", "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		decision, err = limiter.Allow(ctx, "app-1", "10.0.0.2")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonAppRateLimited, decision.Reason)
+		assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+
+		decision, err = limiter.Allow(ctx, "app-2", "10.0.0.1")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonPeerRateLimited, decision.Reason)
+
+		clock.Advance(time.Second)
+		decision, err = limiter.Allow(ctx, "app-1", "10.0.0.2")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	})
+
+	t.Run("Quotas And Usage", func(t *testing.T) {
+		limiter, _ := newRedisLimiter(t, &domain.RateLimits{
+			App: domain.RateLimitPolicy{DailyQuota: 2, MonthlyQuota: 10},
+		})
+
+		for range 3 {
+			_, err := limiter.Allow(ctx, "app-1", "")
+			require.NoError(t, err)
+		}
+
+		decision, err := limiter.Allow(ctx, "app-1", "")
+		require.NoError(t, err)
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, domain.ReasonDailyQuotaExceeded, decision.Reason)
+
+		usage, err := limiter.Usage(ctx, "app-1")
+		require.NoError(t, err)
+		assert.Equal(t, "app-1", usage.AppID)
+		assert.Equal(t, int64(2), usage.Daily.Used)
+		assert.Equal(t, int64(2), usage.Daily.Limit)
+		assert.Equal(t, int64(2), usage.Monthly.Used)
+		assert.Equal(t, int64(10), usage.Monthly.Limit)
+	})
+
+	t.Run("Rejected Requests Use Up No Limit", func(t *testing.T) {
+		limiter, clock := newRedisLimiter(t, &domain.RateLimits{
+			App:  domain.RateLimitPolicy{RequestsPerSecond: 1, Burst: 1, DailyQuota: 1},
+			Peer: domain.RateLimitPolicy{RequestsPerSecond: 1, Burst: 1},
+		})
+
+		decision, err := limiter.Allow(ctx, "app-1", "10.0.0.1")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+
+		decision, err = limiter.Allow(ctx, "app-1", "10.0.0.2")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReasonAppRateLimited, decision.Reason)
+
+		decision, err = limiter.Allow(ctx, "app-2", "10.0.0.2")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "the peer token is given back")
+
+		clock.Advance(time.Second)
+		decision, err = limiter.Allow(ctx, "app-1", "10.0.0.3")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReasonDailyQuotaExceeded, decision.Reason)
+
+		decision, err = limiter.Allow(ctx, "app-3", "10.0.0.3")
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed, "the peer token is given back")
+	})
+}
+
+func TestRedisLimiterCheck(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	limiter := NewRedisLimiter(client, &domain.RateLimits{}, "cards-test")
+	require.NoError(t, limiter.Check(context.Background()))
+
+	mr.Close()
+	assert.Error(t, limiter.Check(context.Background()))
+}
@@ -0,0 +1,225 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core masks card numbers in messages and fields before they reach the wrapped core's encoder.
+type Core struct {
+	zapcore.Core
+}
+
+// NewCore wraps core so that nothing it encodes can contain a full card number.
+func NewCore(core zapcore.Core) zapcore.Core {
+	return &Core{Core: core}
+}
+
+// WrapCore is a logger option that installs the redacting core.
+func WrapCore() zap.Option {
+	return zap.WrapCore(NewCore)
+}
+
+func (c *Core) With(fields []zapcore.Field) zapcore.Core {
+	return &Core{Core: c.Core.With(Fields(fields))}
+}
+
+func (c *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The wrapped core is asked first so its sampling decisions still apply, but the
+	// entry is written through this core so it is redacted.
+	if c.Core.Check(ent, nil) == nil {
+		return ce
+	}
+
+	return ce.AddCore(ent, c)
+}
+
+func (c *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = PANs(ent.Message)
+	ent.Stack = PANs(ent.Stack)
+
+	return c.Core.Write(ent, Fields(fields))
+}
+
+// Fields returns a copy of fields with card numbers masked.
+func Fields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = field(f)
+	}
+
+	return redacted
+}
+
+func field(f zapcore.Field) zapcore.Field {
+	switch f.Type {
+	case zapcore.StringType:
+		f.String = PANs(f.String)
+	case zapcore.ByteStringType:
+		f.Interface = []byte(PANs(string(f.Interface.([]byte))))
+	case zapcore.ErrorType:
+		return zap.String(f.Key, PANs(stringify(f.Interface, func() string { return f.Interface.(error).Error() })))
+	case zapcore.StringerType:
+		return zap.String(f.Key, PANs(stringify(f.Interface, func() string { return f.Interface.(fmt.Stringer).String() })))
+	case zapcore.Int64Type:
+		if s := strconv.FormatInt(f.Integer, 10); PANs(s) != s {
+			return zap.String(f.Key, PANs(s))
+		}
+	case zapcore.Uint64Type:
+		if s := strconv.FormatUint(uint64(f.Integer), 10); PANs(s) != s {
+			return zap.String(f.Key, PANs(s))
+		}
+	case zapcore.ReflectType:
+		f.Interface = reflected(f.Interface)
+	case zapcore.ObjectMarshalerType:
+		f.Interface = object{f.Interface.(zapcore.ObjectMarshaler)}
+	case zapcore.ArrayMarshalerType:
+		f.Interface = array{f.Interface.(zapcore.ArrayMarshaler)}
+	}
+
+	return f
+}
+
+// stringify returns what format makes of v, guarding against panics the way zap's own
+// encoder does: a nil v, or a nil pointer whose method panics, reads "<nil>".
+func stringify(v any, format func() string) (str string) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rv := reflect.ValueOf(v); !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil()) {
+				str = "<nil>"
+				return
+			}
+
+			str = fmt.Sprintf("<PANIC=%v>", r)
+		}
+	}()
+
+	return format()
+}
+
+// reflected masks card numbers in the JSON form of v, falling back to a string when
+// masking a bare number leaves the document invalid.
+func reflected(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("<unencodable %T>", v)
+	}
+
+	masked := PANs(string(data))
+	if masked == string(data) {
+		return v
+	}
+
+	if json.Valid([]byte(masked)) {
+		return json.RawMessage(masked)
+	}
+
+	return masked
+}
+
+type object struct {
+	zapcore.ObjectMarshaler
+}
+
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.ObjectMarshaler.MarshalLogObject(objectEncoder{enc})
+}
+
+type array struct {
+	zapcore.ArrayMarshaler
+}
+
+func (a array) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return a.ArrayMarshaler.MarshalLogArray(arrayEncoder{enc})
+}
+
+// objectEncoder masks the values nested objects add to the underlying encoder.
+type objectEncoder struct {
+	zapcore.ObjectEncoder
+}
+
+func (e objectEncoder) AddString(key, value string) {
+	e.ObjectEncoder.AddString(key, PANs(value))
+}
+
+func (e objectEncoder) AddByteString(key string, value []byte) {
+	e.ObjectEncoder.AddByteString(key, []byte(PANs(string(value))))
+}
+
+func (e objectEncoder) AddInt64(key string, value int64) {
+	if s := strconv.FormatInt(value, 10); PANs(s) != s {
+		e.ObjectEncoder.AddString(key, PANs(s))
+		return
+	}
+
+	e.ObjectEncoder.AddInt64(key, value)
+}
+
+func (e objectEncoder) AddUint64(key string, value uint64) {
+	if s := strconv.FormatUint(value, 10); PANs(s) != s {
+		e.ObjectEncoder.AddString(key, PANs(s))
+		return
+	}
+
+	e.ObjectEncoder.AddUint64(key, value)
+}
+
+func (e objectEncoder) AddReflected(key string, value any) error {
+	return e.ObjectEncoder.AddReflected(key, reflected(value))
+}
+
+func (e objectEncoder) AddObject(key string, value zapcore.ObjectMarshaler) error {
+	return e.ObjectEncoder.AddObject(key, object{value})
+}
+
+func (e objectEncoder) AddArray(key string, value zapcore.ArrayMarshaler) error {
+	return e.ObjectEncoder.AddArray(key, array{value})
+}
+
+// arrayEncoder masks the values nested arrays append to the underlying encoder.
+type arrayEncoder struct {
+	zapcore.ArrayEncoder
+}
+
+func (e arrayEncoder) AppendString(value string) {
+	e.ArrayEncoder.AppendString(PANs(value))
+}
+
+func (e arrayEncoder) AppendByteString(value []byte) {
+	e.ArrayEncoder.AppendByteString([]byte(PANs(string(value))))
+}
+
+func (e arrayEncoder) AppendInt64(value int64) {
+	if s := strconv.FormatInt(value, 10); PANs(s) != s {
+		e.ArrayEncoder.AppendString(PANs(s))
+		return
+	}
+
+	e.ArrayEncoder.AppendInt64(value)
+}
+
+func (e arrayEncoder) AppendUint64(value uint64) {
+	if s := strconv.FormatUint(value, 10); PANs(s) != s {
+		e.ArrayEncoder.AppendString(PANs(s))
+		return
+	}
+
+	e.ArrayEncoder.AppendUint64(value)
+}
+
+func (e arrayEncoder) AppendReflected(value any) error {
+	return e.ArrayEncoder.AppendReflected(reflected(value))
+}
+
+func (e arrayEncoder) AppendObject(value zapcore.ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(object{value})
+}
+
+func (e arrayEncoder) AppendArray(value zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(array{value})
+}
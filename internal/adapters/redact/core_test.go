@@ -0,0 +1,118 @@
+package redact
+
+import (
+	"bytes"
+	stderrors "errors"
+	"testing"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const pan = "4111111111111111"
+
+type payload struct {
+	CardNumber string `json:"card_number"`
+	Number     int64  `json:"number"`
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "card_number:\"" + pan + "\"" }
+
+type panicking struct{}
+
+func (*panicking) String() string { panic("broken") }
+
+type card struct {
+	number string
+}
+
+func (c card) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("number", c.number)
+	enc.AddInt64("raw", 4012888888881881)
+	return enc.AddArray("history", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		arr.AppendString(c.number)
+		return nil
+	}))
+}
+
+func newLogger(buf *bytes.Buffer) *zap.Logger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	core := zapcore.NewCore(encoder, zapcore.AddSync(buf), zapcore.DebugLevel)
+
+	return zap.New(core, WrapCore())
+}
+
+func TestCore(t *testing.T) {
+	t.Run("PANs Never Reach The Encoder", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf).With(zap.String("grpc.request.content", "card_number:"+pan))
+
+		logger.Error(
+			"invalid card "+pan,
+			zap.String("string", pan),
+			zap.ByteString("bytes", []byte(pan)),
+			zap.Error(stderrors.New("lookup failed for "+pan)),
+			zap.Stringer("stringer", stringer{}),
+			zap.Int64("int", 4111111111111111),
+			zap.Uint64("uint", 4111111111111111),
+			zap.Any("reflected", payload{CardNumber: pan}),
+			zap.Any("reflected_number", payload{Number: 5555555555554444}),
+			zap.Object("object", card{number: pan}),
+			zap.Strings("strings", []string{pan}),
+		)
+
+		out := buf.String()
+		assert.NotContains(t, out, pan)
+		assert.NotContains(t, out, "4012888888881881")
+		assert.NotContains(t, out, "5555555555554444")
+		assert.Contains(t, out, "411111******1111")
+		assert.Contains(t, out, `"card_number":"411111******1111"`)
+		assert.Contains(t, out, `"grpc.request.content":"card_number:411111******1111"`)
+	})
+
+	t.Run("Other Values Are Untouched", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf)
+
+		logger.Info("request finished", zap.Int64("grpc.time_ms", 12), zap.Any("meta", payload{CardNumber: "n/a", Number: 42}))
+
+		assert.Contains(t, buf.String(), `"grpc.time_ms":12`)
+		assert.Contains(t, buf.String(), `"meta":{"card_number":"n/a","number":42}`)
+	})
+
+	t.Run("Nil Errors And Stringers Do Not Panic", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf)
+
+		var err *errors.Error
+		var s *panicking
+		assert.NotPanics(t, func() {
+			logger.Error("failed", zap.Error(err), zap.Stringer("nil", nil), zap.Stringer("typed_nil", s), zap.Stringer("panics", &panicking{}))
+		})
+
+		assert.Contains(t, buf.String(), `"error":"<nil>"`)
+		assert.Contains(t, buf.String(), `"nil":"<nil>"`)
+		assert.Contains(t, buf.String(), `"typed_nil":"<nil>"`)
+		assert.Contains(t, buf.String(), `"panics":"<PANIC=broken>"`)
+	})
+
+	t.Run("Respects Levels And Sampling", func(t *testing.T) {
+		var buf bytes.Buffer
+		encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+		core := zapcore.NewCore(encoder, zapcore.AddSync(&buf), zapcore.InfoLevel)
+		sampled := zapcore.NewSamplerWithOptions(core, 1e9, 1, 0)
+		logger := zap.New(NewCore(sampled))
+
+		logger.Debug("dropped " + pan)
+		logger.Info("kept " + pan)
+		logger.Info("kept " + pan)
+
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("kept")))
+		assert.NotContains(t, buf.String(), "dropped")
+		assert.NotContains(t, buf.String(), pan)
+	})
+}
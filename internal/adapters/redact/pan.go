@@ -0,0 +1,138 @@
+package redact
+
+const (
+	minPANDigits = 12
+	maxPANDigits = 19
+
+	// visibleBIN and visibleLast are the digits PCI DSS allows to be displayed.
+	visibleBIN  = 6
+	visibleLast = 4
+)
+
+// PANs masks every Luhn-valid sequence of 12 to 19 digits in s, keeping the BIN and last four.
+// Digits may be grouped with single spaces or dashes, as card numbers are often written, and
+// a card number may sit anywhere in a longer run of digits.
+func PANs(s string) string {
+	if countDigits(s) < minPANDigits {
+		return s
+	}
+
+	out := []byte(s)
+	masked := false
+
+	for start := 0; start < len(s); {
+		if !isDigit(s[start]) {
+			start++
+			continue
+		}
+
+		end := groupedRunEnd(s, start)
+		if maskRun(out, start, end) {
+			masked = true
+		}
+
+		start = end
+	}
+
+	if !masked {
+		return s
+	}
+
+	return string(out)
+}
+
+// groupedRunEnd returns the end of the run of digits starting at start, allowing single
+// separators between digit groups.
+func groupedRunEnd(s string, start int) int {
+	end := start
+	for end < len(s) {
+		if isDigit(s[end]) {
+			end++
+			continue
+		}
+
+		if isSeparator(s[end]) && end+1 < len(s) && isDigit(s[end+1]) {
+			end++
+			continue
+		}
+
+		break
+	}
+
+	return end
+}
+
+// maskRun slides over the digits of out[start:end] and masks, in place, every card number
+// found among them. Windows that are card numbers may overlap, and all of them are masked, so
+// a card number is masked even when it shares digits with another Luhn-valid window.
+func maskRun(out []byte, start, end int) bool {
+	var positions []int
+	digits := make([]byte, 0, end-start)
+	for i := start; i < end; i++ {
+		if isDigit(out[i]) {
+			positions = append(positions, i)
+			digits = append(digits, out[i])
+		}
+	}
+
+	var hidden []bool
+	for i := 0; i+minPANDigits <= len(digits); i++ {
+		for n := minPANDigits; n <= maxPANDigits && i+n <= len(digits); n++ {
+			if !luhnValid(digits[i : i+n]) {
+				continue
+			}
+
+			if hidden == nil {
+				hidden = make([]bool, len(digits))
+			}
+			for j := i + visibleBIN; j < i+n-visibleLast; j++ {
+				hidden[j] = true
+			}
+		}
+	}
+
+	for j, hide := range hidden {
+		if hide {
+			out[positions[j]] = '*'
+		}
+	}
+
+	return hidden != nil
+}
+
+func luhnValid(digits []byte) bool {
+	sum := 0
+	for i := range digits {
+		digit := int(digits[len(digits)-1-i] - '0')
+
+		if i%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if isDigit(s[i]) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSeparator(c byte) bool {
+	return c == ' ' || c == '-'
+}
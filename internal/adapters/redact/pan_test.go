@@ -0,0 +1,36 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPANs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Bare Card Number", "4111111111111111", "411111******1111"},
+		{"Embedded In Message", "card 5555555555554444 declined", "card 555555******4444 declined"},
+		{"Amex", "378282246310005", "378282*****0005"},
+		{"Nineteen Digits", "6011000000000000001", "601100*********0001"},
+		{"Spaces Between Groups", "pan=4111 1111 1111 1111.", "pan=4111 11** **** 1111."},
+		{"Dashes Between Groups", "4111-1111-1111-1111", "4111-11**-****-1111"},
+		{"Several Numbers", "4111111111111111,4012888888881881", "411111******1111,401288******1881"},
+		{"Card Followed By Other Digits", "4111111111111111-2025", "411111********11-2025"},
+		{"Card Followed By Another Group", "4111 1111 1111 1111 123", "4111 11** **** **11 123"},
+		{"Card Inside A Longer Run", "ref 9941111111111111111188 ok", "ref 994111************1188 ok"},
+		{"Card Inside An Overlong Run", "41111111111111111111111", "411111******11111111111"},
+		{"Luhn Invalid", "4111111111111114", "4111111111111114"},
+		{"Too Short", "41111111113", "41111111113"},
+		{"No Digits", "validation failed", "validation failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PANs(tt.input))
+		})
+	}
+}
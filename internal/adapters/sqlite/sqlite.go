@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+)
+
+// Open opens the SQLite database at path, creating it and its directory when missing, and
+// applies the schema statements, which must be idempotent. The database uses write-ahead
+// logging and waits for locks held by other connections or processes.
+func Open(path string, schema ...string) (*sql.DB, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to create database directory")
+		}
+	}
+
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(wal)")
+	params.Add("_pragma", "foreign_keys(on)")
+	params.Add("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to open database")
+	}
+
+	for _, stmt := range schema {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, errors.WrapError(err, errors.Internal, "failed to apply database schema")
+		}
+	}
+
+	return db, nil
+}
+
+// Check reports whether the database can be queried.
+func Check(ctx context.Context, db *sql.DB) error {
+	if err := db.PingContext(ctx); err != nil {
+		return errors.WrapError(err, errors.ServiceUnavailable, "database unavailable")
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestNewProvider(t *testing.T) {
+
+	t.Run("File Exporter", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "spans.json")
+
+		shutdown, err := NewProvider(context.Background(), Config{
+			ServiceName:    "cards-service",
+			ServiceVersion: "1.2.3",
+			Exporter:       "file",
+			FilePath:       path,
+			SampleRatio:    1,
+		})
+		require.NoError(t, err)
+
+		_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+		span.End()
+
+		require.NoError(t, shutdown(context.Background()))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"Name":"test-span"`)
+		assert.Contains(t, string(data), "cards-service")
+		assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, otel.GetTextMapPropagator().Fields())
+	})
+
+	t.Run("Unwritable File", func(t *testing.T) {
+		_, err := NewProvider(context.Background(), Config{
+			Exporter: "file",
+			FilePath: filepath.Join(t.TempDir(), "missing", "spans.json"),
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+package velocity
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often counters that have left their window are dropped.
+const sweepInterval = time.Minute
+
+// counter approximates a sliding window from the counts of the current fixed window and the
+// one before it, weighting the previous count by how much of it the sliding window still covers.
+type counter struct {
+	window   time.Duration
+	start    time.Time
+	current  int64
+	previous int64
+}
+
+func (c *counter) add(now time.Time) int64 {
+	elapsed := now.Sub(c.start)
+
+	switch {
+	case elapsed >= 2*c.window:
+		c.previous, c.current = 0, 0
+		c.start = now.Truncate(c.window)
+	case elapsed >= c.window:
+		c.previous, c.current = c.current, 0
+		c.start = c.start.Add(c.window)
+	}
+
+	c.current++
+
+	overlap := 1 - float64(now.Sub(c.start))/float64(c.window)
+	return c.current + int64(math.Ceil(float64(c.previous)*overlap))
+}
+
+// MemoryStore keeps velocity counters in process memory. Each key needs constant space,
+// whatever its rate. It is suitable for a single replica.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*counter)}
+}
+
+func (s *MemoryStore) Add(ctx context.Context, key string, now time.Time, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	c, ok := s.counters[key]
+	if !ok || c.window != window {
+		c = &counter{window: window, start: now.Truncate(window)}
+		s.counters[key] = c
+	}
+
+	return c.add(now), nil
+}
+
+// Len returns the number of keys being counted.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.counters)
+}
+
+// sweep drops counters whose events have all left the window, which keeps cards seen once
+// from accumulating.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, c := range s.counters {
+		if now.Sub(c.start) >= 2*c.window {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package velocity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Counts Within Window", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		for i := range 5 {
+			count, err := store.Add(ctx, "card:a", start.Add(time.Duration(i)*time.Second), time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, int64(i+1), count)
+		}
+
+		count, err := store.Add(ctx, "card:b", start, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count, "keys are counted separately")
+	})
+
+	t.Run("Slides", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		for range 10 {
+			_, _ = store.Add(ctx, "bin:411111", start.Add(50*time.Second), time.Minute)
+		}
+
+		// A quarter into the next window, three quarters of the previous count still apply.
+		count, err := store.Add(ctx, "bin:411111", start.Add(75*time.Second), time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1+8), count)
+
+		count, err = store.Add(ctx, "bin:411111", start.Add(119*time.Second), time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2+1), count)
+
+		count, err = store.Add(ctx, "bin:411111", start.Add(10*time.Minute), time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Sweeps Idle Keys", func(t *testing.T) {
+		store := NewMemoryStore()
+
+		_, _ = store.Add(ctx, "card:a", start, time.Minute)
+		_, _ = store.Add(ctx, "card:b", start, time.Hour)
+		assert.Equal(t, 2, store.Len())
+
+		_, _ = store.Add(ctx, "card:c", start.Add(5*time.Minute), time.Minute)
+		assert.Equal(t, 2, store.Len())
+	})
+}
@@ -0,0 +1,234 @@
+package wallet
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+type customerKey struct {
+	appID      string
+	customerID string
+}
+
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// MemoryRepository keeps saved cards in process memory, for tests and single-replica
+// development setups. Cards are lost on restart. Events published with status changes go to
+// events, which may be nil when there are none.
+type MemoryRepository struct {
+	mu     sync.Mutex
+	cards  map[customerKey][]domain.WalletCard
+	leases map[string]lease
+	events ports.EventPublisher
+}
+
+func NewMemoryRepository(events ports.EventPublisher) *MemoryRepository {
+	return &MemoryRepository{
+		cards:  make(map[customerKey][]domain.WalletCard),
+		leases: make(map[string]lease),
+		events: events,
+	}
+}
+
+func (r *MemoryRepository) Add(ctx context.Context, card *domain.WalletCard, maxCards int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{card.AppID, card.CustomerID}
+	saved := r.cards[key]
+	if slices.ContainsFunc(saved, func(c domain.WalletCard) bool { return c.Fingerprint == card.Fingerprint }) {
+		return errDuplicateCard()
+	}
+	if maxCards > 0 && len(saved) >= maxCards {
+		return errTooManyCards(len(saved))
+	}
+
+	card.Default = !slices.ContainsFunc(saved, func(c domain.WalletCard) bool { return c.Default })
+	r.cards[key] = append(saved, *card)
+	return nil
+}
+
+func (r *MemoryRepository) List(ctx context.Context, appID string, customerID string) ([]domain.WalletCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cards := slices.Clone(r.cards[customerKey{appID, customerID}])
+	slices.SortStableFunc(cards, func(a, b domain.WalletCard) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	return cards, nil
+}
+
+func (r *MemoryRepository) Get(ctx context.Context, appID string, customerID string, token string) (*domain.WalletCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cards := r.cards[customerKey{appID, customerID}]
+	i := slices.IndexFunc(cards, func(c domain.WalletCard) bool { return c.Token == token })
+	if i < 0 {
+		return nil, errCardNotFound(token)
+	}
+
+	card := cards[i]
+	return &card, nil
+}
+
+func (r *MemoryRepository) Remove(ctx context.Context, appID string, customerID string, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := customerKey{appID, customerID}
+	n := len(r.cards[key])
+	r.cards[key] = slices.DeleteFunc(r.cards[key], func(c domain.WalletCard) bool { return c.Token == token })
+	if len(r.cards[key]) == n {
+		return errCardNotFound(token)
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) SetDefault(ctx context.Context, appID string, customerID string, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cards := r.cards[customerKey{appID, customerID}]
+	if !slices.ContainsFunc(cards, func(c domain.WalletCard) bool { return c.Token == token }) {
+		return errCardNotFound(token)
+	}
+
+	for i := range cards {
+		cards[i].Default = cards[i].Token == token
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) ListExpiring(ctx context.Context, appID string, before time.Time) ([]domain.WalletCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var cards []domain.WalletCard
+	for key, saved := range r.cards {
+		if appID != "" && key.appID != appID {
+			continue
+		}
+		for _, card := range saved {
+			if card.Expired(before) {
+				cards = append(cards, card)
+			}
+		}
+	}
+
+	slices.SortFunc(cards, func(a, b domain.WalletCard) int {
+		if c := domain.ExpiryEnd(a.ExpiryMonth, a.ExpiryYear).Compare(domain.ExpiryEnd(b.ExpiryMonth, b.ExpiryYear)); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Token, b.Token)
+	})
+
+	return cards, nil
+}
+
+// SetStatus publishes the event while holding the lock and changes the status only once it
+// is published, so a failed publish leaves the card as it was.
+func (r *MemoryRepository) SetStatus(ctx context.Context, appID string, customerID string, token string, status string, event *domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cards := r.cards[customerKey{appID, customerID}]
+	i := slices.IndexFunc(cards, func(c domain.WalletCard) bool { return c.Token == token })
+	if i < 0 {
+		return errCardNotFound(token)
+	}
+
+	if event != nil && r.events != nil {
+		if err := r.events.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	cards[i].Status = status
+	return nil
+}
+
+func (r *MemoryRepository) StaleEnvelopes(ctx context.Context, version int, limit int) (map[string]*domain.Envelope, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	envelopes := make(map[string]*domain.Envelope)
+	for _, cards := range r.cards {
+		for _, card := range cards {
+			if len(envelopes) == limit {
+				return envelopes, nil
+			}
+			if card.PANEnvelope != nil && card.PANEnvelope.KeyVersion < version {
+				envelopes[card.Token] = card.PANEnvelope
+			}
+		}
+	}
+
+	return envelopes, nil
+}
+
+func (r *MemoryRepository) ReplaceEnvelope(ctx context.Context, id string, old *domain.Envelope, rewrapped *domain.Envelope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, cards := range r.cards {
+		for i := range cards {
+			if cards[i].Token == id && cards[i].PANEnvelope != nil && cards[i].PANEnvelope.String() == old.String() {
+				cards[i].PANEnvelope = rewrapped
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if current, ok := r.leases[name]; ok && current.holder != holder && now.Before(current.expiresAt) {
+		return false, nil
+	}
+
+	r.leases[name] = lease{holder: holder, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (r *MemoryRepository) Release(ctx context.Context, name string, holder string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.leases[name].holder == holder {
+		delete(r.leases, name)
+	}
+
+	return nil
+}
+
+func errCardNotFound(token string) error {
+	return errors.NewErrorf(errors.NotFound, "card %s not found", token)
+}
+
+func errDuplicateCard() error {
+	return errors.NewErrorf(errors.Conflict, "card is already saved")
+}
+
+func errTooManyCards(count int) error {
+	return errors.NewErrorf(errors.QuotaExceeded, "customer already has %d saved cards", count)
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// FileReporter writes each expiry report as JSON to <dir>/<app_id>/expiry-<date>.json, for
+// apps to collect from shared storage. A later report of the same day replaces the earlier.
+type FileReporter struct {
+	dir string
+}
+
+func NewFileReporter(dir string) *FileReporter {
+	return &FileReporter{dir: dir}
+}
+
+func (r *FileReporter) Report(ctx context.Context, report *domain.ExpiryReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to encode expiry report")
+	}
+
+	dir := filepath.Join(r.dir, appDirName(report.AppID))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to create report directory")
+	}
+
+	path := filepath.Join(dir, "expiry-"+report.GeneratedAt.UTC().Format(time.DateOnly)+".json")
+
+	// Written next to the report and renamed, so readers never see part of one.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to write expiry report")
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to write expiry report")
+	}
+
+	return nil
+}
+
+// appDirName turns an app ID, which is not trusted to be a safe file name, into one.
+func appDirName(appID string) string {
+	name := url.PathEscape(appID)
+	if strings.Trim(name, ".") == "" {
+		// Empty, . or .. would not name a directory of its own.
+		name = "_" + name
+	}
+
+	return name
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReporter(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	reporter := NewFileReporter(dir)
+
+	report := &domain.ExpiryReport{
+		AppID:       "checkout",
+		GeneratedAt: time.Date(2025, 6, 15, 3, 0, 0, 0, time.UTC),
+		Cards:       []domain.ExpiringCard{{Token: "a", MaskedPAN: "411111******1111", Status: domain.CardStatusExpiring}},
+	}
+	require.NoError(t, reporter.Report(ctx, report))
+
+	data, err := os.ReadFile(filepath.Join(dir, "checkout", "expiry-2025-06-15.json"))
+	require.NoError(t, err)
+
+	var got domain.ExpiryReport
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, *report, got)
+
+	t.Run("Unsafe App IDs", func(t *testing.T) {
+		require.NoError(t, reporter.Report(ctx, &domain.ExpiryReport{AppID: "../escape", GeneratedAt: report.GeneratedAt}))
+		require.NoError(t, reporter.Report(ctx, &domain.ExpiryReport{AppID: "..", GeneratedAt: report.GeneratedAt}))
+
+		assert.FileExists(t, filepath.Join(dir, "..%2Fescape", "expiry-2025-06-15.json"))
+		assert.FileExists(t, filepath.Join(dir, "_..", "expiry-2025-06-15.json"))
+	})
+}
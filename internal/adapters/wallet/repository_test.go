@@ -0,0 +1,350 @@
+package wallet
+
+import (
+	"cards-service/internal/adapters/events"
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	testRepository(t, func() ports.WalletRepository { return NewMemoryRepository(nil) })
+}
+
+func TestSQLiteRepository(t *testing.T) {
+	testRepository(t, func() ports.WalletRepository {
+		repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "wallet.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { repo.Close() })
+
+		return repo
+	})
+}
+
+func TestStatusEvents(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	newEvent := func(id string) *domain.Event {
+		event, err := domain.NewEvent(id, domain.EventCardExpired, createdAt, "checkout", domain.ExpiringCard{Token: "a"})
+		require.NoError(t, err)
+
+		return event
+	}
+
+	t.Run("SQLite Stores Them With The Change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "wallet.db")
+		repo, err := NewSQLiteRepository(path)
+		require.NoError(t, err)
+		defer repo.Close()
+
+		outbox, err := events.NewOutbox(path)
+		require.NoError(t, err)
+		defer outbox.Close()
+
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+		require.NoError(t, repo.SetStatus(ctx, "checkout", "cus-1", "a", domain.CardStatusInactive, newEvent("e-1")))
+
+		err = repo.SetStatus(ctx, "checkout", "cus-2", "a", domain.CardStatusInactive, newEvent("e-2"))
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+
+		pending, err := outbox.Pending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pending, "the event of a failed change is not stored")
+
+		broker := events.NewMemoryBroker()
+		relay := events.NewRelay(outbox, broker, events.RelayConfig{Interval: time.Second, BatchSize: 10, MaxAttempts: 1}, zap.NewNop())
+
+		delivered, err := relay.Deliver(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, "e-1", broker.Events()[0].ID)
+	})
+
+	t.Run("Memory Publishes Them Before The Change", func(t *testing.T) {
+		outbox, err := events.NewOutbox(filepath.Join(t.TempDir(), "outbox.db"))
+		require.NoError(t, err)
+
+		repo := NewMemoryRepository(outbox)
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+		require.NoError(t, repo.SetStatus(ctx, "checkout", "cus-1", "a", domain.CardStatusExpiring, newEvent("e-1")))
+
+		pending, err := outbox.Pending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, pending)
+
+		require.NoError(t, outbox.Close())
+		require.Error(t, repo.SetStatus(ctx, "checkout", "cus-1", "a", domain.CardStatusInactive, newEvent("e-2")))
+
+		card, err := repo.Get(ctx, "checkout", "cus-1", "a")
+		require.NoError(t, err)
+		assert.Equal(t, domain.CardStatusExpiring, card.Status, "the status is kept when publishing fails")
+	})
+}
+
+func testCard(token string, customerID string, fingerprint string, createdAt time.Time) *domain.WalletCard {
+	return &domain.WalletCard{
+		Token:       token,
+		AppID:       "checkout",
+		CustomerID:  customerID,
+		Fingerprint: fingerprint,
+		MaskedPAN:   "411111******1111",
+		Brand:       "VISA",
+		Badge:       "https://dummy.com/card-provider-icons/visa.png",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+		Status:      domain.CardStatusActive,
+		CreatedAt:   createdAt,
+	}
+}
+
+// testRepository checks the behavior every repository shares.
+func testRepository(t *testing.T, newRepo func() ports.WalletRepository) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Add And Get", func(t *testing.T) {
+		repo := newRepo()
+		card := testCard("a", "cus-1", "fp-1", createdAt)
+		card.Default = true
+
+		require.NoError(t, repo.Add(ctx, card, 0))
+
+		got, err := repo.Get(ctx, "checkout", "cus-1", "a")
+		require.NoError(t, err)
+		assert.Equal(t, card, got)
+	})
+
+	t.Run("Duplicate Cards", func(t *testing.T) {
+		repo := newRepo()
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+
+		err := repo.Add(ctx, testCard("b", "cus-1", "fp-1", createdAt), 0)
+		require.Error(t, err)
+		assert.Equal(t, errors.Conflict, err.(*errors.Error).ErrCode)
+
+		require.NoError(t, repo.Add(ctx, testCard("c", "cus-2", "fp-1", createdAt), 0), "other customers may save the same card")
+	})
+
+	t.Run("Card Limit And Default", func(t *testing.T) {
+		repo := newRepo()
+		first := testCard("a", "cus-1", "fp-1", createdAt)
+		second := testCard("b", "cus-1", "fp-2", createdAt)
+		require.NoError(t, repo.Add(ctx, first, 2))
+		require.NoError(t, repo.Add(ctx, second, 2))
+		assert.True(t, first.Default, "the first card is the default")
+		assert.False(t, second.Default)
+
+		err := repo.Add(ctx, testCard("c", "cus-1", "fp-3", createdAt), 2)
+		require.Error(t, err)
+		assert.Equal(t, errors.QuotaExceeded, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("Concurrent Adds", func(t *testing.T) {
+		repo := newRepo()
+
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = repo.Add(ctx, testCard(fmt.Sprint("card-", i), "cus-1", fmt.Sprint("fp-", i), createdAt), 3)
+			}()
+		}
+		wg.Wait()
+
+		cards, err := repo.List(ctx, "checkout", "cus-1")
+		require.NoError(t, err)
+		assert.Len(t, cards, 3)
+		defaults := 0
+		for _, card := range cards {
+			if card.Default {
+				defaults++
+			}
+		}
+		assert.Equal(t, 1, defaults)
+	})
+
+	t.Run("Scoped By App And Customer", func(t *testing.T) {
+		repo := newRepo()
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+
+		_, err := repo.Get(ctx, "checkout", "cus-2", "a")
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+		_, err = repo.Get(ctx, "other-app", "cus-1", "a")
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+
+		err = repo.Remove(ctx, "other-app", "cus-1", "a")
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+		err = repo.SetDefault(ctx, "checkout", "cus-2", "a")
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+
+		cards, err := repo.List(ctx, "other-app", "cus-1")
+		require.NoError(t, err)
+		assert.Empty(t, cards)
+	})
+
+	t.Run("List Oldest First", func(t *testing.T) {
+		repo := newRepo()
+		require.NoError(t, repo.Add(ctx, testCard("b", "cus-1", "fp-2", createdAt.Add(time.Hour)), 0))
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+
+		cards, err := repo.List(ctx, "checkout", "cus-1")
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		assert.Equal(t, "a", cards[0].Token)
+		assert.Equal(t, "b", cards[1].Token)
+	})
+
+	t.Run("Set Default", func(t *testing.T) {
+		repo := newRepo()
+		first := testCard("a", "cus-1", "fp-1", createdAt)
+		first.Default = true
+		require.NoError(t, repo.Add(ctx, first, 0))
+		require.NoError(t, repo.Add(ctx, testCard("b", "cus-1", "fp-2", createdAt.Add(time.Hour)), 0))
+
+		require.NoError(t, repo.SetDefault(ctx, "checkout", "cus-1", "b"))
+
+		cards, err := repo.List(ctx, "checkout", "cus-1")
+		require.NoError(t, err)
+		assert.False(t, cards[0].Default)
+		assert.True(t, cards[1].Default)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		repo := newRepo()
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+
+		require.NoError(t, repo.Remove(ctx, "checkout", "cus-1", "a"))
+
+		err := repo.Remove(ctx, "checkout", "cus-1", "a")
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+		require.NoError(t, repo.Add(ctx, testCard("b", "cus-1", "fp-1", createdAt), 0), "removed cards can be saved again")
+	})
+
+	t.Run("List Expiring", func(t *testing.T) {
+		repo := newRepo()
+		expiring := func(token string, appID string, month int, year int) *domain.WalletCard {
+			card := testCard(token, "cus-1", "fp-"+token, createdAt)
+			card.AppID = appID
+			card.ExpiryMonth = month
+			card.ExpiryYear = year
+			return card
+		}
+		require.NoError(t, repo.Add(ctx, expiring("b", "checkout", 7, 2025), 0))
+		require.NoError(t, repo.Add(ctx, expiring("a", "checkout", 12, 2024), 0))
+		require.NoError(t, repo.Add(ctx, expiring("c", "billing", 6, 2025), 0))
+		require.NoError(t, repo.Add(ctx, expiring("d", "checkout", 8, 2025), 0))
+
+		// July cards expire at the start of August.
+		before := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+		cards, err := repo.ListExpiring(ctx, "checkout", before)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		assert.Equal(t, "a", cards[0].Token)
+		assert.Equal(t, "b", cards[1].Token)
+
+		cards, err = repo.ListExpiring(ctx, "", before)
+		require.NoError(t, err)
+		require.Len(t, cards, 3)
+		assert.Equal(t, "c", cards[1].Token)
+
+		cards, err = repo.ListExpiring(ctx, "checkout", before.Add(-time.Millisecond))
+		require.NoError(t, err)
+		assert.Len(t, cards, 1)
+	})
+
+	t.Run("Set Status", func(t *testing.T) {
+		repo := newRepo()
+		require.NoError(t, repo.Add(ctx, testCard("a", "cus-1", "fp-1", createdAt), 0))
+
+		require.NoError(t, repo.SetStatus(ctx, "checkout", "cus-1", "a", domain.CardStatusInactive, nil))
+
+		card, err := repo.Get(ctx, "checkout", "cus-1", "a")
+		require.NoError(t, err)
+		assert.Equal(t, domain.CardStatusInactive, card.Status)
+
+		err = repo.SetStatus(ctx, "other-app", "cus-1", "a", domain.CardStatusActive, nil)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("Lease", func(t *testing.T) {
+		lease, ok := newRepo().(ports.Lease)
+		require.True(t, ok)
+
+		acquired, err := lease.Acquire(ctx, "job", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = lease.Acquire(ctx, "job", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired, "another replica holds the lease")
+
+		acquired, err = lease.Acquire(ctx, "job", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired, "the holder renews it")
+
+		require.NoError(t, lease.Release(ctx, "job", "replica-2"))
+		acquired, err = lease.Acquire(ctx, "job", "replica-2", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired, "only the holder releases it")
+
+		require.NoError(t, lease.Release(ctx, "job", "replica-1"))
+		acquired, err = lease.Acquire(ctx, "job", "replica-2", -time.Second)
+		require.NoError(t, err)
+		assert.True(t, acquired)
+
+		acquired, err = lease.Acquire(ctx, "job", "replica-1", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired, "expired leases are taken over")
+	})
+
+	t.Run("Envelopes", func(t *testing.T) {
+		repo := newRepo()
+		store, ok := repo.(ports.EnvelopeStore)
+		require.True(t, ok)
+
+		old := &domain.Envelope{KeyVersion: 1, WrappedKey: []byte("wrapped"), Ciphertext: []byte("sealed")}
+		card := testCard("a", "cus-1", "fp-1", createdAt)
+		card.PANEnvelope = old
+		require.NoError(t, repo.Add(ctx, card, 0))
+		require.NoError(t, repo.Add(ctx, testCard("b", "cus-1", "fp-2", createdAt), 0))
+
+		got, err := repo.Get(ctx, "checkout", "cus-1", "a")
+		require.NoError(t, err)
+		assert.Equal(t, old, got.PANEnvelope)
+
+		envelopes, err := store.StaleEnvelopes(ctx, 2, 10)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*domain.Envelope{"a": old}, envelopes, "cards without an envelope are left out")
+
+		envelopes, err = store.StaleEnvelopes(ctx, 1, 10)
+		require.NoError(t, err)
+		assert.Empty(t, envelopes)
+
+		rewrapped := &domain.Envelope{KeyVersion: 2, WrappedKey: []byte("rewrapped"), Ciphertext: old.Ciphertext}
+		require.NoError(t, store.ReplaceEnvelope(ctx, "a", old, rewrapped))
+
+		other := &domain.Envelope{KeyVersion: 3, WrappedKey: []byte("other"), Ciphertext: old.Ciphertext}
+		require.NoError(t, store.ReplaceEnvelope(ctx, "a", old, other), "envelopes that changed are kept")
+
+		got, err = repo.Get(ctx, "checkout", "cus-1", "a")
+		require.NoError(t, err)
+		assert.Equal(t, rewrapped, got.PANEnvelope)
+
+		envelopes, err = store.StaleEnvelopes(ctx, 2, 10)
+		require.NoError(t, err)
+		assert.Empty(t, envelopes)
+	})
+}
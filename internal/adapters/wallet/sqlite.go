@@ -0,0 +1,380 @@
+package wallet
+
+import (
+	"cards-service/internal/adapters/events"
+	"cards-service/internal/adapters/sqlite"
+	"cards-service/internal/core/domain"
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS wallet_cards (
+		token        TEXT PRIMARY KEY,
+		app_id       TEXT NOT NULL,
+		customer_id  TEXT NOT NULL,
+		fingerprint  TEXT NOT NULL,
+		masked_pan   TEXT NOT NULL,
+		brand        TEXT NOT NULL,
+		badge        TEXT NOT NULL,
+		expiry_month INTEGER NOT NULL,
+		expiry_year  INTEGER NOT NULL,
+		is_default   INTEGER NOT NULL DEFAULT 0,
+		status       TEXT NOT NULL DEFAULT 'active',
+		created_at   INTEGER NOT NULL,
+		pan_envelope    TEXT NOT NULL DEFAULT '',
+		pan_key_version INTEGER NOT NULL DEFAULT 0,
+		UNIQUE (app_id, customer_id, fingerprint)
+	)`,
+	`CREATE INDEX IF NOT EXISTS wallet_cards_customer ON wallet_cards (app_id, customer_id, created_at)`,
+	`CREATE INDEX IF NOT EXISTS wallet_cards_expiry ON wallet_cards (expiry_year, expiry_month)`,
+	`CREATE INDEX IF NOT EXISTS wallet_cards_pan_key ON wallet_cards (pan_key_version) WHERE pan_envelope != ''`,
+	`CREATE TABLE IF NOT EXISTS job_leases (
+		name       TEXT PRIMARY KEY,
+		holder     TEXT NOT NULL,
+		expires_at INTEGER NOT NULL
+	)`,
+}
+
+const columns = `token, app_id, customer_id, fingerprint, masked_pan, brand, badge, expiry_month, expiry_year, is_default, status, created_at, pan_envelope`
+
+// SQLiteRepository keeps saved cards in a SQLite database. Events published with status
+// changes wait in the outbox table of the same database, for an events.Outbox opened on it
+// to relay. Times are Unix milliseconds.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(path string) (*SQLiteRepository, error) {
+	db, err := sqlite.Open(path, slices.Concat(schema, events.Schema)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLiteRepository{db: db}, nil
+}
+
+// Add counts the cards of the customer and inserts the new one in a single transaction,
+// which holds the write lock from the start, so concurrent saves cannot exceed maxCards or
+// both become the default.
+func (r *SQLiteRepository) Add(ctx context.Context, card *domain.WalletCard, maxCards int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to save card")
+	}
+	defer tx.Rollback()
+
+	var count int
+	var hasDefault bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(MAX(is_default), 0) FROM wallet_cards WHERE app_id = ? AND customer_id = ?`,
+		card.AppID, card.CustomerID,
+	).Scan(&count, &hasDefault)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to save card")
+	}
+	if maxCards > 0 && count >= maxCards {
+		return errTooManyCards(count)
+	}
+
+	card.Default = !hasDefault
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO wallet_cards (`+columns+`, pan_key_version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		card.Token, card.AppID, card.CustomerID, card.Fingerprint, card.MaskedPAN, card.Brand, card.Badge,
+		card.ExpiryMonth, card.ExpiryYear, card.Default, card.Status, card.CreatedAt.UnixMilli(),
+		encodeEnvelope(card.PANEnvelope), envelopeVersion(card.PANEnvelope),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return errDuplicateCard()
+		}
+		return errors.WrapError(err, errors.Internal, "failed to save card")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to save card")
+	}
+
+	return nil
+}
+
+func (r *SQLiteRepository) List(ctx context.Context, appID string, customerID string) ([]domain.WalletCard, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+columns+` FROM wallet_cards WHERE app_id = ? AND customer_id = ? ORDER BY created_at, token`,
+		appID, customerID,
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list cards")
+	}
+
+	return scanCards(rows)
+}
+
+func (r *SQLiteRepository) Get(ctx context.Context, appID string, customerID string, token string) (*domain.WalletCard, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+columns+` FROM wallet_cards WHERE app_id = ? AND customer_id = ? AND token = ?`,
+		appID, customerID, token,
+	)
+
+	card, err := scanCard(row)
+	if err == sql.ErrNoRows {
+		return nil, errCardNotFound(token)
+	}
+
+	return card, err
+}
+
+func (r *SQLiteRepository) Remove(ctx context.Context, appID string, customerID string, token string) error {
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM wallet_cards WHERE app_id = ? AND customer_id = ? AND token = ?`,
+		appID, customerID, token,
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to remove card")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errCardNotFound(token)
+	}
+
+	return nil
+}
+
+func (r *SQLiteRepository) SetDefault(ctx context.Context, appID string, customerID string, token string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to set default card")
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM wallet_cards WHERE app_id = ? AND customer_id = ? AND token = ?)`,
+		appID, customerID, token,
+	).Scan(&exists)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to set default card")
+	}
+	if !exists {
+		return errCardNotFound(token)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE wallet_cards SET is_default = (token = ?) WHERE app_id = ? AND customer_id = ?`,
+		token, appID, customerID,
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to set default card")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to set default card")
+	}
+
+	return nil
+}
+
+func (r *SQLiteRepository) ListExpiring(ctx context.Context, appID string, before time.Time) ([]domain.WalletCard, error) {
+	// A card has expired by before when its expiry month is earlier than the month before
+	// falls in.
+	before = before.UTC()
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+columns+` FROM wallet_cards
+		WHERE (? = '' OR app_id = ?) AND expiry_year * 12 + expiry_month < ?
+		ORDER BY expiry_year, expiry_month, created_at, token`,
+		appID, appID, before.Year()*12+int(before.Month()),
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list cards")
+	}
+
+	return scanCards(rows)
+}
+
+// SetStatus updates the card and stores the event in the outbox in a single transaction.
+func (r *SQLiteRepository) SetStatus(ctx context.Context, appID string, customerID string, token string, status string, event *domain.Event) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to update card")
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE wallet_cards SET status = ? WHERE app_id = ? AND customer_id = ? AND token = ?`,
+		status, appID, customerID, token,
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to update card")
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errCardNotFound(token)
+	}
+
+	if event != nil {
+		if err := events.Store(ctx, tx, event, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to update card")
+	}
+
+	return nil
+}
+
+func (r *SQLiteRepository) StaleEnvelopes(ctx context.Context, version int, limit int) (map[string]*domain.Envelope, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT token, pan_envelope FROM wallet_cards
+		WHERE pan_envelope != '' AND pan_key_version < ?
+		ORDER BY token LIMIT ?`,
+		version, limit,
+	)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list card number envelopes")
+	}
+	defer rows.Close()
+
+	envelopes := make(map[string]*domain.Envelope)
+	for rows.Next() {
+		var token, encoded string
+		if err := rows.Scan(&token, &encoded); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to list card number envelopes")
+		}
+
+		envelope, err := domain.ParseEnvelope(encoded)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to read card %s", token)
+		}
+		envelopes[token] = envelope
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list card number envelopes")
+	}
+
+	return envelopes, nil
+}
+
+func (r *SQLiteRepository) ReplaceEnvelope(ctx context.Context, id string, old *domain.Envelope, rewrapped *domain.Envelope) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE wallet_cards SET pan_envelope = ?, pan_key_version = ? WHERE token = ? AND pan_envelope = ?`,
+		rewrapped.String(), rewrapped.KeyVersion, id, old.String(),
+	)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to update card %s", id)
+	}
+
+	return nil
+}
+
+// Acquire takes the lease when nobody holds it or it has expired, so replicas sharing the
+// database elect one of them to run a job.
+func (r *SQLiteRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO job_leases (name, holder, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+		WHERE job_leases.holder = excluded.holder OR job_leases.expires_at <= ?`,
+		name, holder, now.Add(ttl).UnixMilli(), now.UnixMilli(),
+	)
+	if err != nil {
+		return false, errors.WrapError(err, errors.Internal, "failed to acquire lease %s", name)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.WrapError(err, errors.Internal, "failed to acquire lease %s", name)
+	}
+
+	return n > 0, nil
+}
+
+func (r *SQLiteRepository) Release(ctx context.Context, name string, holder string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM job_leases WHERE name = ? AND holder = ?`, name, holder)
+	if err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to release lease %s", name)
+	}
+
+	return nil
+}
+
+// Check reports whether the database can be queried.
+func (r *SQLiteRepository) Check(ctx context.Context) error {
+	return sqlite.Check(ctx, r.db)
+}
+
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func encodeEnvelope(envelope *domain.Envelope) string {
+	if envelope == nil {
+		return ""
+	}
+
+	return envelope.String()
+}
+
+func envelopeVersion(envelope *domain.Envelope) int {
+	if envelope == nil {
+		return 0
+	}
+
+	return envelope.KeyVersion
+}
+
+func scanCards(rows *sql.Rows) ([]domain.WalletCard, error) {
+	defer rows.Close()
+
+	var cards []domain.WalletCard
+	for rows.Next() {
+		card, err := scanCard(rows)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, *card)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to list cards")
+	}
+
+	return cards, nil
+}
+
+func scanCard(row scanner) (*domain.WalletCard, error) {
+	var card domain.WalletCard
+	var createdAt int64
+	var envelope string
+
+	err := row.Scan(
+		&card.Token, &card.AppID, &card.CustomerID, &card.Fingerprint, &card.MaskedPAN, &card.Brand, &card.Badge,
+		&card.ExpiryMonth, &card.ExpiryYear, &card.Default, &card.Status, &createdAt, &envelope,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to read card")
+	}
+	card.CreatedAt = time.UnixMilli(createdAt).UTC()
+
+	if envelope != "" {
+		if card.PANEnvelope, err = domain.ParseEnvelope(envelope); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to read card %s", card.Token)
+		}
+	}
+
+	return &card, nil
+}
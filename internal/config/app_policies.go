@@ -0,0 +1,44 @@
+package config
+
+import (
+	"cards-service/internal/core/domain"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// appPolicy is an entry in the app policies file, listed for the same reason as the rate
+// limit overrides.
+type appPolicy struct {
+	AppID            string `mapstructure:"app_id"`
+	domain.AppPolicy `mapstructure:",squash"`
+}
+
+type appPoliciesFile struct {
+	Apps []appPolicy `mapstructure:"apps"`
+}
+
+// loadAppPolicies reads the cards each app accepts from a YAML, TOML or JSON file.
+func loadAppPolicies(path string) (map[string]domain.AppPolicy, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to load app policies file")
+	}
+
+	var file appPoliciesFile
+	if err := v.Unmarshal(&file); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to unmarshal app policies file")
+	}
+
+	policies := make(map[string]domain.AppPolicy, len(file.Apps))
+	for _, app := range file.Apps {
+		if app.AppID == "" {
+			return nil, errors.NewErrorf(errors.InvalidArgument, "app policies file has an entry without app_id")
+		}
+		policies[app.AppID] = app.AppPolicy
+	}
+
+	return policies, nil
+}
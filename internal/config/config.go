@@ -1,32 +1,329 @@
 package config
 
 import (
+	"cards-service/internal/core/domain"
 	"cards-service/internal/core/ports"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/mwinyimoha/commons/pkg/errors"
 	"github.com/spf13/viper"
 )
 
+// Config holds every setting of the service. Fields tagged `dynamic:"true"` can change while
+// the service runs, see Reloader; all other settings only take effect on restart.
 type Config struct {
+	ConfigFile     string `mapstructure:"CONFIG_FILE"`
 	ServiceName    string `mapstructure:"SERVICE_NAME" validate:"required"`
 	ServiceVersion string `mapstructure:"SERVICE_VERSION" validate:"required"`
 	AppID          string `mapstructure:"APP_ID"`
 	Debug          bool   `mapstructure:"DEBUG"`
 	ServerPort     int    `mapstructure:"SERVER_PORT" validate:"required,min=1,max=65535"`
+	ServerSocket   string `mapstructure:"SERVER_SOCKET"`
 	DefaultTimeout int    `mapstructure:"DEFAULT_TIMEOUT" validate:"required,min=1"`
+	MethodTimeouts string `mapstructure:"METHOD_TIMEOUTS"`
+	LogLevel       string `mapstructure:"LOG_LEVEL" validate:"oneof=debug info warn error" dynamic:"true"`
+	LogRedactPANs  bool   `mapstructure:"LOG_REDACT_PANS"`
+	ConfigWatch    int    `mapstructure:"CONFIG_WATCH_INTERVAL" validate:"min=0"`
+	ShutdownDelay  int    `mapstructure:"SHUTDOWN_DELAY" validate:"min=0"`
+	DrainTimeout   int    `mapstructure:"DRAIN_TIMEOUT" validate:"min=1"`
+
+	// The x-app-id header is set by the API gateway, but clients that reach the service
+	// directly can send it too, so it is ignored unless every request comes through the gateway.
+	TrustAppIDHeader bool `mapstructure:"TRUST_APP_ID_HEADER"`
+
+	MetricsEnabled bool `mapstructure:"METRICS_ENABLED"`
+	MetricsPort    int  `mapstructure:"METRICS_PORT" validate:"required,min=1,max=65535,nefield=ServerPort"`
+
+	// The admin server is not authenticated, so it listens on loopback unless ADMIN_ADDR says
+	// otherwise.
+	AdminEnabled bool   `mapstructure:"ADMIN_ENABLED"`
+	AdminAddr    string `mapstructure:"ADMIN_ADDR" validate:"required,ip|hostname"`
+	AdminPort    int    `mapstructure:"ADMIN_PORT" validate:"required,min=1,max=65535,nefield=ServerPort,nefield=MetricsPort"`
+
+	HealthHTTPEnabled   bool `mapstructure:"HEALTH_HTTP_ENABLED"`
+	HealthPort          int  `mapstructure:"HEALTH_PORT" validate:"required,min=1,max=65535,nefield=ServerPort,nefield=MetricsPort,nefield=AdminPort"`
+	HealthCheckInterval int  `mapstructure:"HEALTH_CHECK_INTERVAL" validate:"min=1"`
+	HealthCheckTimeout  int  `mapstructure:"HEALTH_CHECK_TIMEOUT" validate:"min=1"`
+
+	BinTableFile        string `mapstructure:"BIN_TABLE_FILE"`
+	BinCacheEnabled     bool   `mapstructure:"BIN_CACHE_ENABLED"`
+	BinCacheSize        int    `mapstructure:"BIN_CACHE_SIZE" validate:"min=1"`
+	BinCacheTTL         int    `mapstructure:"BIN_CACHE_TTL" validate:"min=1"`
+	BinCacheNegativeTTL int    `mapstructure:"BIN_CACHE_NEGATIVE_TTL" validate:"min=0"`
+	BinCacheFallbackTTL int    `mapstructure:"BIN_CACHE_FALLBACK_TTL" validate:"min=0"`
+
+	BinProviderURL              string `mapstructure:"BIN_PROVIDER_URL" validate:"omitempty,url"`
+	BinProviderAPIKey           string `mapstructure:"BIN_PROVIDER_API_KEY" secret:"true"`
+	BinProviderRetries          int    `mapstructure:"BIN_PROVIDER_RETRIES" validate:"min=0"`
+	BinProviderTimeoutMS        int    `mapstructure:"BIN_PROVIDER_TIMEOUT_MS" validate:"min=1"`
+	BinProviderBreakerThreshold int    `mapstructure:"BIN_PROVIDER_BREAKER_THRESHOLD" validate:"min=1"`
+	BinProviderBreakerCooldown  int    `mapstructure:"BIN_PROVIDER_BREAKER_COOLDOWN" validate:"min=1"`
+
+	// BIN rules are managed over gRPC, so enabling them requires JWT: listing rules takes the
+	// cards.bin_rules.read scope by default, creating and deleting them cards.bin_rules.write.
+	BinRulesEnabled bool   `mapstructure:"BIN_RULES_ENABLED"`
+	BinRulesDB      string `mapstructure:"BIN_RULES_DB" validate:"required_if=BinRulesEnabled true"`
+	BinRulesRefresh int    `mapstructure:"BIN_RULES_REFRESH" validate:"min=1"`
+
+	TracingEnabled      bool    `mapstructure:"TRACING_ENABLED"`
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER" validate:"oneof=otlp stdout file"`
+	TracingOTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingOTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingFile         string  `mapstructure:"TRACING_FILE" validate:"required_if=TracingExporter file"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO" validate:"gte=0,lte=1"`
+
+	TLSEnabled      bool   `mapstructure:"TLS_ENABLED"`
+	TLSCertFile     string `mapstructure:"TLS_CERT_FILE" validate:"required_if=TLSEnabled true"`
+	TLSKeyFile      string `mapstructure:"TLS_KEY_FILE" validate:"required_if=TLSEnabled true"`
+	TLSMinVersion   string `mapstructure:"TLS_MIN_VERSION" validate:"oneof=1.2 1.3"`
+	TLSClientAuth   string `mapstructure:"TLS_CLIENT_AUTH" validate:"oneof=none optional require"`
+	TLSClientCAFile string `mapstructure:"TLS_CLIENT_CA_FILE" validate:"required_unless=TLSClientAuth none"`
+
+	JWTEnabled      bool   `mapstructure:"JWT_ENABLED"`
+	JWTRequired     bool   `mapstructure:"JWT_REQUIRED"`
+	JWTJWKSSource   string `mapstructure:"JWT_JWKS_SOURCE" validate:"required_if=JWTEnabled true"`
+	JWTJWKSRefresh  int    `mapstructure:"JWT_JWKS_REFRESH" validate:"min=1"`
+	JWTIssuer       string `mapstructure:"JWT_ISSUER" validate:"required_if=JWTEnabled true"`
+	JWTAudience     string `mapstructure:"JWT_AUDIENCE" validate:"required_if=JWTEnabled true"`
+	JWTClockSkew    int    `mapstructure:"JWT_CLOCK_SKEW" validate:"min=0"`
+	JWTAppClaim     string `mapstructure:"JWT_APP_CLAIM" validate:"required"`
+	JWTMethodScopes string `mapstructure:"JWT_METHOD_SCOPES"`
+
+	// Behind a load balancer every request comes from the same peer, so peers are not
+	// limited unless PEER_RATE_LIMIT is set.
+	RateLimitEnabled  bool    `mapstructure:"RATE_LIMIT_ENABLED"`
+	RateLimitStore    string  `mapstructure:"RATE_LIMIT_STORE" validate:"oneof=memory redis"`
+	RateLimitRedisURL string  `mapstructure:"RATE_LIMIT_REDIS_URL" validate:"required_if=RateLimitStore redis" secret:"true"`
+	RateLimitsFile    string  `mapstructure:"RATE_LIMITS_FILE"`
+	AppRateLimit      float64 `mapstructure:"APP_RATE_LIMIT" validate:"gte=0" dynamic:"true"`
+	AppRateBurst      int     `mapstructure:"APP_RATE_BURST" validate:"gte=0" dynamic:"true"`
+	AppDailyQuota     int64   `mapstructure:"APP_DAILY_QUOTA" validate:"gte=0" dynamic:"true"`
+	AppMonthlyQuota   int64   `mapstructure:"APP_MONTHLY_QUOTA" validate:"gte=0" dynamic:"true"`
+	PeerRateLimit     float64 `mapstructure:"PEER_RATE_LIMIT" validate:"gte=0" dynamic:"true"`
+	PeerRateBurst     int     `mapstructure:"PEER_RATE_BURST" validate:"gte=0" dynamic:"true"`
+
+	VelocityEnabled    bool   `mapstructure:"VELOCITY_ENABLED"`
+	VelocityMode       string `mapstructure:"VELOCITY_MODE" validate:"oneof=flag reject"`
+	VelocityCardMax    int64  `mapstructure:"VELOCITY_CARD_MAX" validate:"gte=0"`
+	VelocityCardWindow int    `mapstructure:"VELOCITY_CARD_WINDOW" validate:"min=1"`
+	VelocityBINMax     int64  `mapstructure:"VELOCITY_BIN_MAX" validate:"gte=0"`
+	VelocityBINWindow  int    `mapstructure:"VELOCITY_BIN_WINDOW" validate:"min=1"`
+	VelocityAppMax     int64  `mapstructure:"VELOCITY_APP_MAX" validate:"gte=0"`
+	VelocityAppWindow  int    `mapstructure:"VELOCITY_APP_WINDOW" validate:"min=1"`
+	VelocityPeerMax    int64  `mapstructure:"VELOCITY_PEER_MAX" validate:"gte=0"`
+	VelocityPeerWindow int    `mapstructure:"VELOCITY_PEER_WINDOW" validate:"min=1"`
+
+	AppPoliciesFile   string `mapstructure:"APP_POLICIES_FILE"`
+	CardRuleCostLimit uint64 `mapstructure:"CARD_RULE_COST_LIMIT" validate:"min=1"`
+
+	EventsEnabled       bool   `mapstructure:"EVENTS_ENABLED"`
+	EventsOutboxDB      string `mapstructure:"EVENTS_OUTBOX_DB" validate:"required_if=EventsEnabled true"`
+	EventsSink          string `mapstructure:"EVENTS_SINK" validate:"oneof=file webhook memory"`
+	EventsFile          string `mapstructure:"EVENTS_FILE" validate:"required_if=EventsSink file"`
+	EventsWebhookURL    string `mapstructure:"EVENTS_WEBHOOK_URL" validate:"required_if=EventsSink webhook,omitempty,url"`
+	EventsWebhookSecret string `mapstructure:"EVENTS_WEBHOOK_SECRET" validate:"required_if=EventsSink webhook" secret:"true"`
+	EventsPollInterval  int    `mapstructure:"EVENTS_POLL_INTERVAL" validate:"min=1"`
+	EventsBatchSize     int    `mapstructure:"EVENTS_BATCH_SIZE" validate:"min=1"`
+	EventsMaxAttempts   int    `mapstructure:"EVENTS_MAX_ATTEMPTS" validate:"min=1"`
+	EventsMaxBackoff    int    `mapstructure:"EVENTS_MAX_BACKOFF" validate:"min=1"`
+
+	// The wallet identifies duplicate cards by fingerprint, so it needs FINGERPRINT_KEY, and
+	// tells apps apart by the app of their token, so it needs JWT.
+	WalletEnabled  bool   `mapstructure:"WALLET_ENABLED"`
+	WalletStore    string `mapstructure:"WALLET_STORE" validate:"oneof=memory sqlite"`
+	WalletDB       string `mapstructure:"WALLET_DB" validate:"required_if=WalletStore sqlite"`
+	WalletMaxCards int    `mapstructure:"WALLET_MAX_CARDS" validate:"gte=0"`
+
+	// KEY_MANAGER=local keeps the card numbers of saved cards, encrypted with keys from the
+	// keyring file, optionally sealed with KEYRING_PASSPHRASE.
+	KeyManager        string `mapstructure:"KEY_MANAGER" validate:"oneof=none local"`
+	KeyringFile       string `mapstructure:"KEYRING_FILE" validate:"required_if=KeyManager local"`
+	KeyringPassphrase string `mapstructure:"KEYRING_PASSPHRASE" secret:"true"`
+
+	// Format-preserving encryption needs exactly one key: FPE_KEY, an AES key in hex, or
+	// FPE_KEY_ENVELOPE, the same sealed by the key manager, as printed by "fpe keygen". It
+	// also needs JWT: EncryptPAN and DecryptPAN take the cards.fpe.encrypt and
+	// cards.fpe.decrypt scopes by default.
+	FPEEnabled     bool   `mapstructure:"FPE_ENABLED"`
+	FPEKey         string `mapstructure:"FPE_KEY" validate:"omitempty,hexadecimal,len=32|len=48|len=64" secret:"true"`
+	FPEKeyEnvelope string `mapstructure:"FPE_KEY_ENVELOPE"`
+
+	// The expiry job checks the cards saved in the wallet, so it needs WALLET_ENABLED, and
+	// EVENTS_ENABLED to notify apps through events.
+	ExpiryJobEnabled bool   `mapstructure:"EXPIRY_JOB_ENABLED"`
+	ExpirySchedule   string `mapstructure:"EXPIRY_SCHEDULE" validate:"required"`
+	ExpiryWindowDays int    `mapstructure:"EXPIRY_WINDOW_DAYS" validate:"min=0,max=366"`
+	ExpiryNotify     string `mapstructure:"EXPIRY_NOTIFY" validate:"oneof=events report"`
+	ExpiryReportDir  string `mapstructure:"EXPIRY_REPORT_DIR" validate:"required_if=ExpiryNotify report"`
+	ExpiryLeaseTTL   int    `mapstructure:"EXPIRY_LEASE_TTL" validate:"min=1"`
+
+	// Card fingerprints take FINGERPRINT_KEY, or FINGERPRINT_KEY_ENVELOPE, the same sealed by
+	// the key manager, as printed by "fingerprint-key seal". The command-line tools record
+	// their use of the keys in AUDIT_CLI_FILE, since only the server continues AUDIT_FILE.
+	AuditEnabled           bool   `mapstructure:"AUDIT_ENABLED"`
+	AuditFile              string `mapstructure:"AUDIT_FILE" validate:"required_if=AuditEnabled true"`
+	AuditCLIFile           string `mapstructure:"AUDIT_CLI_FILE" validate:"required_if=AuditEnabled true"`
+	AuditMaxSizeMB         int    `mapstructure:"AUDIT_MAX_SIZE_MB" validate:"min=1"`
+	FingerprintKey         string `mapstructure:"FINGERPRINT_KEY" validate:"omitempty,min=32" secret:"true"`
+	FingerprintKeyEnvelope string `mapstructure:"FINGERPRINT_KEY_ENVELOPE"`
+
+	// AppRateLimits holds per-app overrides loaded from RATE_LIMITS_FILE.
+	AppRateLimits map[string]domain.RateLimitPolicy `mapstructure:"-" validate:"dive" dynamic:"true"`
+	// AppPolicies holds the cards each app accepts, loaded from APP_POLICIES_FILE.
+	AppPolicies map[string]domain.AppPolicy `mapstructure:"-" validate:"dive" dynamic:"true"`
+	// MethodScopes holds the scopes each RPC requires, parsed from JWT_METHOD_SCOPES.
+	MethodScopes map[string][]string `mapstructure:"-"`
+	// MethodDeadlines holds per-RPC overrides of DEFAULT_TIMEOUT, parsed from METHOD_TIMEOUTS.
+	MethodDeadlines map[string]time.Duration `mapstructure:"-"`
+	// ExpiryCron holds the schedule of the expiry job, parsed from EXPIRY_SCHEDULE.
+	ExpiryCron *domain.Schedule `mapstructure:"-"`
 }
 
-func New(val ports.AppValidator) (*Config, error) {
+// New loads the configuration from, in increasing order of precedence: defaults, a .env
+// file in the working directory, the file named by CONFIG_FILE, environment variables and
+// command-line flags. Any KEY may also be read from the file named by KEY_FILE.
+func New(val ports.AppValidator, args ...string) (*Config, error) {
 	v := viper.New()
 	v.SetConfigType("env")
 
+	v.SetDefault("CONFIG_FILE", "")
 	v.SetDefault("SERVICE_NAME", "")
 	v.SetDefault("SERVICE_VERSION", "0.1.0")
 	v.SetDefault("APP_ID", "")
-	v.SetDefault("DEBUG", true)
+	v.SetDefault("DEBUG", false)
 	v.SetDefault("SERVER_PORT", 8080)
+	v.SetDefault("SERVER_SOCKET", "")
 	v.SetDefault("DEFAULT_TIMEOUT", 10)
+	v.SetDefault("METHOD_TIMEOUTS", "")
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("LOG_REDACT_PANS", true)
+	v.SetDefault("CONFIG_WATCH_INTERVAL", 5)
+	v.SetDefault("SHUTDOWN_DELAY", 5)
+	v.SetDefault("DRAIN_TIMEOUT", 20)
+	v.SetDefault("TRUST_APP_ID_HEADER", false)
+
+	v.SetDefault("METRICS_ENABLED", true)
+	v.SetDefault("METRICS_PORT", 9464)
+
+	v.SetDefault("ADMIN_ENABLED", false)
+	v.SetDefault("ADMIN_ADDR", "127.0.0.1")
+	v.SetDefault("ADMIN_PORT", 9465)
+
+	v.SetDefault("HEALTH_HTTP_ENABLED", true)
+	v.SetDefault("HEALTH_PORT", 8081)
+	v.SetDefault("HEALTH_CHECK_INTERVAL", 10)
+	v.SetDefault("HEALTH_CHECK_TIMEOUT", 2)
+
+	v.SetDefault("BIN_TABLE_FILE", "")
+	v.SetDefault("BIN_CACHE_ENABLED", true)
+	v.SetDefault("BIN_CACHE_SIZE", 10000)
+	v.SetDefault("BIN_CACHE_TTL", 3600)
+	v.SetDefault("BIN_CACHE_NEGATIVE_TTL", 300)
+	v.SetDefault("BIN_CACHE_FALLBACK_TTL", 60)
+
+	v.SetDefault("BIN_PROVIDER_URL", "")
+	v.SetDefault("BIN_PROVIDER_API_KEY", "")
+	v.SetDefault("BIN_PROVIDER_RETRIES", 2)
+	v.SetDefault("BIN_PROVIDER_TIMEOUT_MS", 1000)
+	v.SetDefault("BIN_PROVIDER_BREAKER_THRESHOLD", 5)
+	v.SetDefault("BIN_PROVIDER_BREAKER_COOLDOWN", 30)
+
+	v.SetDefault("BIN_RULES_ENABLED", false)
+	v.SetDefault("BIN_RULES_DB", "data/bin_rules.db")
+	v.SetDefault("BIN_RULES_REFRESH", 30)
+
+	v.SetDefault("TRACING_ENABLED", false)
+	v.SetDefault("TRACING_EXPORTER", "otlp")
+	v.SetDefault("TRACING_OTLP_ENDPOINT", "")
+	v.SetDefault("TRACING_OTLP_INSECURE", false)
+	v.SetDefault("TRACING_FILE", "")
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+
+	v.SetDefault("TLS_ENABLED", false)
+	v.SetDefault("TLS_CERT_FILE", "")
+	v.SetDefault("TLS_KEY_FILE", "")
+	v.SetDefault("TLS_MIN_VERSION", "1.2")
+	v.SetDefault("TLS_CLIENT_AUTH", "none")
+	v.SetDefault("TLS_CLIENT_CA_FILE", "")
+
+	v.SetDefault("JWT_ENABLED", false)
+	v.SetDefault("JWT_REQUIRED", false)
+	v.SetDefault("JWT_JWKS_SOURCE", "")
+	v.SetDefault("JWT_JWKS_REFRESH", 300)
+	v.SetDefault("JWT_ISSUER", "")
+	v.SetDefault("JWT_AUDIENCE", "")
+	v.SetDefault("JWT_CLOCK_SKEW", 30)
+	v.SetDefault("JWT_APP_CLAIM", "azp")
+	v.SetDefault("JWT_METHOD_SCOPES", "")
+
+	v.SetDefault("RATE_LIMIT_ENABLED", true)
+	v.SetDefault("RATE_LIMIT_STORE", "memory")
+	v.SetDefault("RATE_LIMIT_REDIS_URL", "")
+	v.SetDefault("RATE_LIMITS_FILE", "")
+	v.SetDefault("APP_RATE_LIMIT", 50.0)
+	v.SetDefault("APP_RATE_BURST", 100)
+	v.SetDefault("APP_DAILY_QUOTA", 0)
+	v.SetDefault("APP_MONTHLY_QUOTA", 0)
+	v.SetDefault("PEER_RATE_LIMIT", 0.0)
+	v.SetDefault("PEER_RATE_BURST", 0)
+
+	v.SetDefault("VELOCITY_ENABLED", false)
+	v.SetDefault("VELOCITY_MODE", "flag")
+	v.SetDefault("VELOCITY_CARD_MAX", 5)
+	v.SetDefault("VELOCITY_CARD_WINDOW", 3600)
+	v.SetDefault("VELOCITY_BIN_MAX", 0)
+	v.SetDefault("VELOCITY_BIN_WINDOW", 60)
+	v.SetDefault("VELOCITY_APP_MAX", 0)
+	v.SetDefault("VELOCITY_APP_WINDOW", 60)
+	v.SetDefault("VELOCITY_PEER_MAX", 0)
+	v.SetDefault("VELOCITY_PEER_WINDOW", 60)
+
+	v.SetDefault("APP_POLICIES_FILE", "")
+	v.SetDefault("CARD_RULE_COST_LIMIT", 1000)
+
+	v.SetDefault("EVENTS_ENABLED", false)
+	v.SetDefault("EVENTS_OUTBOX_DB", "data/outbox.db")
+	v.SetDefault("EVENTS_SINK", "file")
+	v.SetDefault("EVENTS_FILE", "events/validations.ndjson")
+	v.SetDefault("EVENTS_WEBHOOK_URL", "")
+	v.SetDefault("EVENTS_WEBHOOK_SECRET", "")
+	v.SetDefault("EVENTS_POLL_INTERVAL", 1)
+	v.SetDefault("EVENTS_BATCH_SIZE", 100)
+	v.SetDefault("EVENTS_MAX_ATTEMPTS", 10)
+	v.SetDefault("EVENTS_MAX_BACKOFF", 300)
+
+	v.SetDefault("WALLET_ENABLED", false)
+	v.SetDefault("WALLET_STORE", "sqlite")
+	v.SetDefault("WALLET_DB", "data/wallet.db")
+	v.SetDefault("WALLET_MAX_CARDS", 20)
+
+	v.SetDefault("KEY_MANAGER", "none")
+	v.SetDefault("KEYRING_FILE", "data/keyring.json")
+	v.SetDefault("KEYRING_PASSPHRASE", "")
+
+	v.SetDefault("FPE_ENABLED", false)
+	v.SetDefault("FPE_KEY", "")
+	v.SetDefault("FPE_KEY_ENVELOPE", "")
+
+	v.SetDefault("EXPIRY_JOB_ENABLED", false)
+	v.SetDefault("EXPIRY_SCHEDULE", "0 3 * * *")
+	v.SetDefault("EXPIRY_WINDOW_DAYS", 30)
+	v.SetDefault("EXPIRY_NOTIFY", "report")
+	v.SetDefault("EXPIRY_REPORT_DIR", "reports/expiry")
+	v.SetDefault("EXPIRY_LEASE_TTL", 600)
+
+	v.SetDefault("AUDIT_ENABLED", false)
+	v.SetDefault("AUDIT_FILE", "audit/validations.log")
+	v.SetDefault("AUDIT_CLI_FILE", "audit/cli.log")
+	v.SetDefault("AUDIT_MAX_SIZE_MB", 100)
+	v.SetDefault("FINGERPRINT_KEY", "")
+	v.SetDefault("FINGERPRINT_KEY_ENVELOPE", "")
+
+	flags, err := bindFlags(v, args)
+	if err != nil {
+		return nil, err
+	}
 
 	v.AutomaticEnv()
 
@@ -37,11 +334,55 @@ func New(val ports.AppValidator) (*Config, error) {
 		}
 	}
 
+	if path := v.GetString("CONFIG_FILE"); path != "" {
+		if err := mergeConfigFile(v, path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := resolveSecretFiles(v, flags); err != nil {
+		return nil, err
+	}
+
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, errors.WrapError(err, errors.Internal, "failed to unmarshal config")
 	}
 
+	if cfg.RateLimitsFile != "" {
+		overrides, err := loadRateLimits(cfg.RateLimitsFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.AppRateLimits = overrides
+	}
+
+	if cfg.AppPoliciesFile != "" {
+		policies, err := loadAppPolicies(cfg.AppPoliciesFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.AppPolicies = policies
+	}
+
+	methodScopes, err := parseMethodScopes(cfg.JWTMethodScopes)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MethodScopes = methodScopes
+
+	methodDeadlines, err := parseMethodTimeouts(cfg.MethodTimeouts)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MethodDeadlines = methodDeadlines
+
+	expiryCron, err := domain.ParseSchedule(cfg.ExpirySchedule)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ExpiryCron = expiryCron
+
 	if err := cfg.validate(val); err != nil {
 		return nil, err
 	}
@@ -49,6 +390,56 @@ func New(val ports.AppValidator) (*Config, error) {
 	return &cfg, nil
 }
 
+// RateLimits builds the limiter policies from the defaults and the per-app overrides.
+func (c *Config) RateLimits() *domain.RateLimits {
+	return &domain.RateLimits{
+		App: domain.RateLimitPolicy{
+			RequestsPerSecond: c.AppRateLimit,
+			Burst:             c.AppRateBurst,
+			DailyQuota:        c.AppDailyQuota,
+			MonthlyQuota:      c.AppMonthlyQuota,
+		},
+		Peer: domain.RateLimitPolicy{
+			RequestsPerSecond: c.PeerRateLimit,
+			Burst:             c.PeerRateBurst,
+		},
+		Apps: c.AppRateLimits,
+	}
+}
+
+// VelocityLimits converts the VELOCITY_* settings into the limits the service checks.
+func (c *Config) VelocityLimits() domain.VelocityLimits {
+	limit := func(max int64, window int) domain.VelocityLimit {
+		return domain.VelocityLimit{Max: max, Window: time.Duration(window) * time.Second}
+	}
+
+	return domain.VelocityLimits{
+		Card: limit(c.VelocityCardMax, c.VelocityCardWindow),
+		BIN:  limit(c.VelocityBINMax, c.VelocityBINWindow),
+		App:  limit(c.VelocityAppMax, c.VelocityAppWindow),
+		Peer: limit(c.VelocityPeerMax, c.VelocityPeerWindow),
+	}
+}
+
+// EffectiveLogLevel is LOG_LEVEL, lowered to debug when DEBUG is set.
+func (c *Config) EffectiveLogLevel() string {
+	if c.Debug {
+		return "debug"
+	}
+
+	return c.LogLevel
+}
+
+// Timeout is the deadline applied to requests that arrive without one.
+func (c *Config) Timeout() time.Duration {
+	return time.Duration(c.DefaultTimeout) * time.Second
+}
+
+// BinProviderTimeout bounds each call to the BIN provider.
+func (c *Config) BinProviderTimeout() time.Duration {
+	return time.Duration(c.BinProviderTimeoutMS) * time.Millisecond
+}
+
 func (c *Config) validate(v ports.AppValidator) error {
 	if err := v.Struct(c); err != nil {
 		if verr, ok := err.(validator.ValidationErrors); ok {
@@ -59,5 +450,55 @@ func (c *Config) validate(v ports.AppValidator) error {
 		return errors.WrapError(err, errors.Internal, "config validation failed")
 	}
 
+	var violations []*errors.FieldViolation
+	if c.JWTMethodScopes != "" && !c.JWTEnabled {
+		violations = append(violations, &errors.FieldViolation{Field: "JWTMethodScopes", Description: "requires JWT to be enabled"})
+	}
+	if c.BinRulesEnabled && !c.JWTEnabled {
+		violations = append(violations, &errors.FieldViolation{Field: "BinRulesEnabled", Description: "requires JWT to be enabled, which guards the rule admin RPCs"})
+	}
+	if c.WalletEnabled && !c.JWTEnabled {
+		violations = append(violations, &errors.FieldViolation{Field: "WalletEnabled", Description: "requires JWT to be enabled, which identifies the app that owns a wallet"})
+	}
+	// Every attempt must give up early enough for the local BIN table to answer instead.
+	if c.BinProviderURL != "" && time.Duration(c.BinProviderRetries+1)*c.BinProviderTimeout() >= c.Timeout() {
+		violations = append(violations, &errors.FieldViolation{Field: "BinProviderTimeoutMS", Description: "with retries must add up to less than DEFAULT_TIMEOUT"})
+	}
+	fingerprinted := c.FingerprintKey != "" || c.FingerprintKeyEnvelope != ""
+	if c.FingerprintKey != "" && c.FingerprintKeyEnvelope != "" {
+		violations = append(violations, &errors.FieldViolation{Field: "FingerprintKey", Description: "only one of FINGERPRINT_KEY and FINGERPRINT_KEY_ENVELOPE is allowed"})
+	}
+	if c.FingerprintKeyEnvelope != "" && c.KeyManager == "none" {
+		violations = append(violations, &errors.FieldViolation{Field: "FingerprintKeyEnvelope", Description: "requires a key manager"})
+	}
+	if c.AuditEnabled && !fingerprinted {
+		violations = append(violations, &errors.FieldViolation{Field: "FingerprintKey", Description: "is required when audit is enabled"})
+	}
+	if c.WalletEnabled && !fingerprinted {
+		violations = append(violations, &errors.FieldViolation{Field: "FingerprintKey", Description: "is required when the wallet is enabled"})
+	}
+	if c.VelocityEnabled && c.VelocityCardMax > 0 && !fingerprinted {
+		violations = append(violations, &errors.FieldViolation{Field: "FingerprintKey", Description: "is required for per-card velocity limits"})
+	}
+	if c.FPEEnabled && !c.JWTEnabled {
+		violations = append(violations, &errors.FieldViolation{Field: "FPEEnabled", Description: "requires JWT to be enabled, which guards the FPE RPCs"})
+	}
+	if c.FPEEnabled && (c.FPEKey == "") == (c.FPEKeyEnvelope == "") {
+		violations = append(violations, &errors.FieldViolation{Field: "FPEKey", Description: "exactly one of FPE_KEY and FPE_KEY_ENVELOPE is required when FPE is enabled"})
+	}
+	if c.FPEKeyEnvelope != "" && c.KeyManager == "none" {
+		violations = append(violations, &errors.FieldViolation{Field: "FPEKeyEnvelope", Description: "requires a key manager"})
+	}
+	if c.ExpiryJobEnabled && !c.WalletEnabled {
+		violations = append(violations, &errors.FieldViolation{Field: "ExpiryJobEnabled", Description: "requires the wallet to be enabled"})
+	}
+	if c.ExpiryJobEnabled && c.ExpiryNotify == "events" && !c.EventsEnabled {
+		violations = append(violations, &errors.FieldViolation{Field: "ExpiryNotify", Description: "requires events to be enabled"})
+	}
+
+	if len(violations) > 0 {
+		return errors.NewValidationError(violations)
+	}
+
 	return nil
 }
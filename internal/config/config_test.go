@@ -1,8 +1,10 @@
 package config
 
 import (
+	"cards-service/internal/core/domain"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/mwinyimoha/commons/pkg/errors"
@@ -26,6 +28,67 @@ func resetEnv() {
 	os.Unsetenv("DEFAULT_TIMEOUT")
 	os.Unsetenv("APP_ID")
 	os.Unsetenv("DEBUG")
+	os.Unsetenv("RATE_LIMIT_STORE")
+	os.Unsetenv("RATE_LIMITS_FILE")
+	os.Unsetenv("TLS_ENABLED")
+	os.Unsetenv("TLS_CERT_FILE")
+	os.Unsetenv("TLS_KEY_FILE")
+	os.Unsetenv("TLS_MIN_VERSION")
+	os.Unsetenv("TLS_CLIENT_AUTH")
+	os.Unsetenv("TLS_CLIENT_CA_FILE")
+	os.Unsetenv("JWT_ENABLED")
+	os.Unsetenv("JWT_JWKS_SOURCE")
+	os.Unsetenv("JWT_ISSUER")
+	os.Unsetenv("JWT_AUDIENCE")
+	os.Unsetenv("JWT_METHOD_SCOPES")
+	os.Unsetenv("METRICS_PORT")
+	os.Unsetenv("ADMIN_PORT")
+	os.Unsetenv("ADMIN_ADDR")
+	os.Unsetenv("HEALTH_PORT")
+	os.Unsetenv("LOG_LEVEL")
+	os.Unsetenv("TRACING_EXPORTER")
+	os.Unsetenv("TRACING_FILE")
+	os.Unsetenv("METHOD_TIMEOUTS")
+	os.Unsetenv("AUDIT_ENABLED")
+	os.Unsetenv("FINGERPRINT_KEY")
+	os.Unsetenv("FINGERPRINT_KEY_FILE")
+	os.Unsetenv("FINGERPRINT_KEY_ENVELOPE")
+	os.Unsetenv("CONFIG_FILE")
+	os.Unsetenv("SHUTDOWN_DELAY")
+	os.Unsetenv("DRAIN_TIMEOUT")
+	os.Unsetenv("TRUST_APP_ID_HEADER")
+	os.Unsetenv("BIN_CACHE_SIZE")
+	os.Unsetenv("BIN_PROVIDER_URL")
+	os.Unsetenv("BIN_PROVIDER_TIMEOUT_MS")
+	os.Unsetenv("BIN_RULES_ENABLED")
+	os.Unsetenv("BIN_RULES_REFRESH")
+	os.Unsetenv("APP_POLICIES_FILE")
+	os.Unsetenv("EVENTS_SINK")
+	os.Unsetenv("EVENTS_WEBHOOK_URL")
+	os.Unsetenv("EVENTS_WEBHOOK_SECRET")
+	os.Unsetenv("WALLET_ENABLED")
+	os.Unsetenv("WALLET_STORE")
+	os.Unsetenv("WALLET_MAX_CARDS")
+	os.Unsetenv("EXPIRY_JOB_ENABLED")
+	os.Unsetenv("KEY_MANAGER")
+	os.Unsetenv("KEYRING_PASSPHRASE")
+	os.Unsetenv("FPE_ENABLED")
+	os.Unsetenv("FPE_KEY")
+	os.Unsetenv("FPE_KEY_ENVELOPE")
+	os.Unsetenv("EXPIRY_SCHEDULE")
+	os.Unsetenv("EXPIRY_NOTIFY")
+	os.Unsetenv("EVENTS_ENABLED")
+	os.Unsetenv("VELOCITY_ENABLED")
+	os.Unsetenv("VELOCITY_MODE")
+	os.Unsetenv("VELOCITY_CARD_MAX")
+	os.Unsetenv("VELOCITY_BIN_MAX")
+}
+
+func setJWTEnv() {
+	os.Setenv("JWT_ENABLED", "true")
+	os.Setenv("JWT_JWKS_SOURCE", "https://issuer.example.com/.well-known/jwks.json")
+	os.Setenv("JWT_ISSUER", "https://issuer.example.com")
+	os.Setenv("JWT_AUDIENCE", "cards-service")
 }
 
 func TestNew(t *testing.T) {
@@ -44,6 +107,10 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, 8080, cfg.ServerPort)        // default
 		assert.Equal(t, 10, cfg.DefaultTimeout)      // default
 		assert.Equal(t, "0.1.0", cfg.ServiceVersion) // default
+		assert.True(t, cfg.LogRedactPANs)            // default
+		assert.False(t, cfg.Debug)                   // default
+		assert.False(t, cfg.TrustAppIDHeader)        // default
+		assert.Equal(t, "info", cfg.EffectiveLogLevel())
 	})
 }
 
@@ -54,7 +121,7 @@ func TestDefaultenvOverrides(t *testing.T) {
 	os.Setenv("SERVICE_NAME", "CustomService")
 	os.Setenv("SERVER_PORT", "9090")
 	os.Setenv("DEFAULT_TIMEOUT", "20")
-	os.Setenv("DEBUG", "false")
+	os.Setenv("DEBUG", "true")
 
 	cfg, err := New(v)
 	require.NoError(t, err)
@@ -63,7 +130,7 @@ func TestDefaultenvOverrides(t *testing.T) {
 	assert.Equal(t, "CustomService", cfg.ServiceName)
 	assert.Equal(t, 9090, cfg.ServerPort)
 	assert.Equal(t, 20, cfg.DefaultTimeout)
-	assert.Equal(t, false, cfg.Debug)
+	assert.Equal(t, true, cfg.Debug)
 }
 
 func TestValidationFails(t *testing.T) {
@@ -147,3 +214,847 @@ func TestDebugMode(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to load configuration file")
 	})
 }
+
+func TestRateLimits(t *testing.T) {
+
+	t.Run("Defaults", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+
+		limits := cfg.RateLimits()
+		assert.True(t, cfg.RateLimitEnabled)
+		assert.Equal(t, "memory", cfg.RateLimitStore)
+		assert.Equal(t, float64(50), limits.App.RequestsPerSecond)
+		assert.Zero(t, limits.Peer.RequestsPerSecond, "peers are not limited by default")
+		assert.Empty(t, limits.Apps)
+	})
+
+	t.Run("Per App Overrides", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/limits.yaml"
+		os.WriteFile(tmp, []byte("apps:\n  - app_id: Merchant-A\n    requests_per_second: 5\n    burst: 10\n    daily_quota: 1000\n"), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("RATE_LIMITS_FILE", tmp)
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+
+		limits := cfg.RateLimits()
+		policy := limits.ForApp("Merchant-A")
+		assert.Equal(t, float64(5), policy.RequestsPerSecond)
+		assert.Equal(t, 10, policy.Burst)
+		assert.Equal(t, int64(1000), policy.DailyQuota)
+		assert.Equal(t, limits.App, limits.ForApp("merchant-b"))
+	})
+
+	t.Run("Redis Store Requires URL", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("RATE_LIMIT_STORE", "redis")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Negative Override", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/limits.json"
+		os.WriteFile(tmp, []byte(`{"apps": [{"app_id": "a", "burst": -1}]}`), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("RATE_LIMITS_FILE", tmp)
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestTLS(t *testing.T) {
+
+	t.Run("Requires Key Pair", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("TLS_ENABLED", "true")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Client Auth Requires CA Bundle", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("TLS_ENABLED", "true")
+		os.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+		os.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+		os.Setenv("TLS_CLIENT_AUTH", "require")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+
+		os.Setenv("TLS_CLIENT_CA_FILE", "/etc/tls/ca.crt")
+		os.Setenv("TLS_MIN_VERSION", "1.3")
+
+		cfg, err = New(v)
+		require.NoError(t, err)
+		assert.Equal(t, "1.3", cfg.TLSMinVersion)
+	})
+}
+
+func TestJWT(t *testing.T) {
+
+	t.Run("Requires Issuer And Keys", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("JWT_ENABLED", "true")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Method Scopes", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		setJWTEnv()
+		os.Setenv("JWT_METHOD_SCOPES", "/cards.v1.CardsService/ValidateCardNumber=cards.validate; /cards.v1.UsageService/GetUsage=cards.usage cards.read;")
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+
+		assert.Equal(t, 30, cfg.JWTClockSkew)
+		assert.Equal(t, "azp", cfg.JWTAppClaim)
+		assert.Equal(t, []string{"cards.validate"}, cfg.MethodScopes["/cards.v1.CardsService/ValidateCardNumber"])
+		assert.Equal(t, []string{"cards.usage", "cards.read"}, cfg.MethodScopes["/cards.v1.UsageService/GetUsage"])
+	})
+
+	t.Run("Default Method Scopes", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		setJWTEnv()
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cards.bin_rules.write"}, cfg.MethodScopes["/cards.v1.BinRuleService/CreateBinRule"])
+		assert.Equal(t, []string{"cards.bin_rules.read"}, cfg.MethodScopes["/cards.v1.BinRuleService/ListBinRules"])
+		assert.Equal(t, []string{"cards.bin_rules.write"}, cfg.MethodScopes["/cards.v1.BinRuleService/DeleteBinRule"])
+		assert.Equal(t, []string{"cards.wallet"}, cfg.MethodScopes["/cards.v1.WalletService/AddCard"])
+		assert.Equal(t, []string{"cards.fpe.decrypt"}, cfg.MethodScopes["/cards.v1.FPEService/DecryptPAN"])
+
+		os.Setenv("JWT_METHOD_SCOPES", "/cards.v1.BinRuleService/DeleteBinRule=cards.admin")
+		cfg, err = New(v)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"cards.admin"}, cfg.MethodScopes["/cards.v1.BinRuleService/DeleteBinRule"])
+	})
+
+	t.Run("Malformed Method Scopes", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("JWT_METHOD_SCOPES", "ValidateCardNumber")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Method Scopes Require JWT", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("JWT_METHOD_SCOPES", "/cards.v1.UsageService/GetUsage=cards.usage")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestMetricsPort(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("METRICS_PORT", "8080")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "metrics must not share the gRPC port")
+}
+
+func TestTracing(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("TRACING_EXPORTER", "file")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+
+	os.Setenv("TRACING_FILE", "/var/log/cards/spans.json")
+
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, cfg.TracingSampleRatio)
+}
+
+func TestAudit(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("AUDIT_ENABLED", "true")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "audit requires a fingerprint key")
+
+	os.Setenv("FINGERPRINT_KEY", "too-short")
+
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+
+	os.Setenv("FINGERPRINT_KEY", "0123456789abcdef0123456789abcdef")
+
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "audit/validations.log", cfg.AuditFile)
+	assert.Equal(t, "audit/cli.log", cfg.AuditCLIFile)
+	assert.Equal(t, 100, cfg.AuditMaxSizeMB)
+}
+
+func TestFingerprintKeyEnvelope(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("AUDIT_ENABLED", "true")
+	os.Setenv("FINGERPRINT_KEY_ENVELOPE", "v1.a2V5.Y2lwaGVydGV4dA")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "envelopes need a key manager")
+
+	os.Setenv("KEY_MANAGER", "local")
+	os.Setenv("KEYRING_PASSPHRASE", "passphrase")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.a2V5.Y2lwaGVydGV4dA", cfg.FingerprintKeyEnvelope)
+
+	os.Setenv("FINGERPRINT_KEY", "0123456789abcdef0123456789abcdef")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "only one key is allowed")
+}
+
+func TestMethodTimeouts(t *testing.T) {
+
+	t.Run("Overrides", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("METHOD_TIMEOUTS", "/cards.CardsService/ValidateCardNumber=2s; /cards.v1.UsageService/GetUsage=500ms")
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+
+		assert.Equal(t, 10*time.Second, cfg.Timeout())
+		assert.Equal(t, map[string]time.Duration{
+			"/cards.CardsService/ValidateCardNumber": 2 * time.Second,
+			"/cards.v1.UsageService/GetUsage":        500 * time.Millisecond,
+		}, cfg.MethodDeadlines)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+
+		for _, raw := range []string{"ValidateCardNumber=2s", "/cards.CardsService/ValidateCardNumber=soon", "/cards.CardsService/ValidateCardNumber=-1s"} {
+			os.Setenv("METHOD_TIMEOUTS", raw)
+
+			cfg, err := New(v)
+			assert.Nil(t, cfg, raw)
+			require.Error(t, err, raw)
+		}
+	})
+}
+
+func TestConfigFile(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": "service_name: FileService\nserver:\n  port: 7000\ntls:\n  min_version: \"1.3\"\naudit:\n  max_size_mb: 5\n",
+		"config.toml": "SERVICE_NAME = \"FileService\"\n[server]\nport = 7000\n[tls]\nmin_version = \"1.3\"\n[audit]\nmax_size_mb = 5\n",
+		"config.json": `{"service_name": "FileService", "server": {"port": 7000}, "tls": {"min_version": "1.3"}, "audit": {"max_size_mb": 5}}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			defer resetEnv()
+			v := newValidator()
+
+			path := t.TempDir() + "/" + name
+			require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			os.Setenv("CONFIG_FILE", path)
+
+			cfg, err := New(v)
+			require.NoError(t, err)
+
+			assert.Equal(t, "FileService", cfg.ServiceName)
+			assert.Equal(t, 7000, cfg.ServerPort)
+			assert.Equal(t, "1.3", cfg.TLSMinVersion)
+			assert.Equal(t, 5, cfg.AuditMaxSizeMB)
+		})
+	}
+
+	t.Run("Missing File", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("CONFIG_FILE", t.TempDir()+"/missing.yaml")
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestPrecedence(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	path := t.TempDir() + "/config.yaml"
+	require.NoError(t, os.WriteFile(path, []byte("service_name: FileService\nserver_port: 7000\ndefault_timeout: 30\n"), 0644))
+
+	os.Setenv("SERVER_PORT", "7100")
+	os.Setenv("DEFAULT_TIMEOUT", "40")
+
+	cfg, err := New(v, "--config-file", path, "--server-port=7200", "--debug=false", "--app-rate-limit", "2.5")
+	require.NoError(t, err)
+
+	assert.Equal(t, "FileService", cfg.ServiceName) // file
+	assert.Equal(t, 40, cfg.DefaultTimeout)         // env over file
+	assert.Equal(t, 7200, cfg.ServerPort)           // flag over env
+	assert.False(t, cfg.Debug)
+	assert.Equal(t, 2.5, cfg.AppRateLimit)
+
+	_, err = New(v, "--no-such-flag")
+	require.Error(t, err)
+}
+
+func TestSecretFiles(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	path := t.TempDir() + "/fingerprint.key"
+	require.NoError(t, os.WriteFile(path, []byte("0123456789abcdef0123456789abcdef\n"), 0600))
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("AUDIT_ENABLED", "true")
+	os.Setenv("FINGERPRINT_KEY_FILE", path)
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", cfg.FingerprintKey)
+
+	// The file stands in for the environment variable, so a flag for the key still wins.
+	os.Setenv("FINGERPRINT_KEY", "fedcba9876543210fedcba9876543210")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", cfg.FingerprintKey)
+
+	cfg, err = New(v, "--fingerprint-key", "ffffffffffffffffffffffffffffffff")
+	require.NoError(t, err)
+	assert.Equal(t, "ffffffffffffffffffffffffffffffff", cfg.FingerprintKey)
+
+	os.Unsetenv("FINGERPRINT_KEY")
+	os.Setenv("FINGERPRINT_KEY_FILE", path+".missing")
+
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{
+		ServiceName:       "TestService",
+		ServerPort:        8080,
+		FingerprintKey:    "0123456789abcdef0123456789abcdef",
+		RateLimitRedisURL: "",
+		MethodScopes:      map[string][]string{"/a/b": {"c"}},
+	}
+
+	settings := cfg.Redacted()
+
+	assert.Equal(t, "TestService", settings["SERVICE_NAME"])
+	assert.Equal(t, 8080, settings["SERVER_PORT"])
+	assert.Equal(t, "[REDACTED]", settings["FINGERPRINT_KEY"])
+	assert.Equal(t, "", settings["RATE_LIMIT_REDIS_URL"])
+	assert.NotContains(t, settings, "MethodScopes")
+}
+
+func TestAdminPort(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("ADMIN_PORT", "9464")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "admin must not share the metrics port")
+
+	os.Unsetenv("ADMIN_PORT")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", cfg.AdminAddr, "only local operators reach the admin server by default")
+
+	os.Setenv("ADMIN_ADDR", "not an address")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+}
+
+func TestEffectiveLogLevel(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("LOG_LEVEL", "warn")
+
+	os.Setenv("DEBUG", "true")
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", cfg.EffectiveLogLevel())
+
+	os.Setenv("DEBUG", "false")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "warn", cfg.EffectiveLogLevel())
+}
+
+func TestHealthPort(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, 8081, cfg.HealthPort)
+
+	os.Setenv("HEALTH_PORT", "9465")
+
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "health must not share the admin port")
+}
+
+func TestShutdownSettings(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.ShutdownDelay)
+	assert.Equal(t, 20, cfg.DrainTimeout)
+
+	os.Setenv("SHUTDOWN_DELAY", "0")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.ShutdownDelay)
+
+	os.Setenv("DRAIN_TIMEOUT", "0")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the drain timeout must be positive")
+}
+
+func TestBinCache(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.True(t, cfg.BinCacheEnabled)
+	assert.Equal(t, 10000, cfg.BinCacheSize)
+	assert.Equal(t, 3600, cfg.BinCacheTTL)
+	assert.Equal(t, 300, cfg.BinCacheNegativeTTL)
+
+	os.Setenv("BIN_CACHE_SIZE", "0")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the cache must hold at least one BIN")
+}
+
+func TestBinProvider(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("BIN_PROVIDER_URL", "https://lookup.binlist.net/{bin}")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "https://lookup.binlist.net/{bin}", cfg.BinProviderURL)
+	assert.Equal(t, 2, cfg.BinProviderRetries)
+	assert.Equal(t, 5, cfg.BinProviderBreakerThreshold)
+	assert.Equal(t, time.Second, cfg.BinProviderTimeout())
+
+	os.Setenv("BIN_PROVIDER_TIMEOUT_MS", "4000")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "three attempts of 4s leave no time for the local table within 10s")
+
+	os.Unsetenv("BIN_PROVIDER_TIMEOUT_MS")
+	os.Setenv("BIN_PROVIDER_URL", "not a url")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+}
+
+func TestBinRules(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("BIN_RULES_ENABLED", "true")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the rule admin RPCs need JWT")
+
+	setJWTEnv()
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "data/bin_rules.db", cfg.BinRulesDB)
+	assert.Equal(t, 30, cfg.BinRulesRefresh)
+
+	os.Setenv("BIN_RULES_REFRESH", "0")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+}
+
+func TestAppPolicies(t *testing.T) {
+
+	t.Run("Loads Policies", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/policies.yaml"
+		os.WriteFile(tmp, []byte("apps:\n  - app_id: Merchant-A\n    networks: [VISA, MASTERCARD]\n    card_types: [credit, debit]\n    mode: reject\n"), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("APP_POLICIES_FILE", tmp)
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]domain.AppPolicy{
+			"Merchant-A": {
+				Networks:  []string{"VISA", "MASTERCARD"},
+				CardTypes: []string{"credit", "debit"},
+				Mode:      domain.PolicyReject,
+			},
+		}, cfg.AppPolicies)
+	})
+
+	t.Run("Card Rules", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/policies.yaml"
+		os.WriteFile(tmp, []byte("apps:\n  - app_id: a\n    rules:\n      - name: local-prepaid\n        expression: card.type != 'prepaid' || card.country == 'KE'\n"), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("APP_POLICIES_FILE", tmp)
+
+		cfg, err := New(v)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000), cfg.CardRuleCostLimit)
+		assert.Equal(t, []domain.CardRule{
+			{Name: "local-prepaid", Expression: "card.type != 'prepaid' || card.country == 'KE'"},
+		}, cfg.AppPolicies["a"].Rules)
+	})
+
+	t.Run("Card Rules Need A Name", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/policies.json"
+		os.WriteFile(tmp, []byte(`{"apps": [{"app_id": "a", "rules": [{"expression": "!card.test"}]}]}`), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("APP_POLICIES_FILE", tmp)
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Invalid Mode", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/policies.json"
+		os.WriteFile(tmp, []byte(`{"apps": [{"app_id": "a", "mode": "block"}]}`), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("APP_POLICIES_FILE", tmp)
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("Missing App ID", func(t *testing.T) {
+		defer resetEnv()
+		v := newValidator()
+
+		tmp := t.TempDir() + "/policies.json"
+		os.WriteFile(tmp, []byte(`{"apps": [{"networks": ["VISA"]}]}`), 0644)
+
+		os.Setenv("SERVICE_NAME", "TestService")
+		os.Setenv("APP_POLICIES_FILE", tmp)
+
+		cfg, err := New(v)
+		assert.Nil(t, cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestEvents(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "file", cfg.EventsSink)
+	assert.Equal(t, "data/outbox.db", cfg.EventsOutboxDB)
+	assert.Equal(t, 10, cfg.EventsMaxAttempts)
+
+	os.Setenv("EVENTS_SINK", "webhook")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "webhooks need a URL and a secret")
+
+	os.Setenv("EVENTS_WEBHOOK_URL", "https://events.example.com/hooks")
+	os.Setenv("EVENTS_WEBHOOK_SECRET", "secret")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED]", cfg.Redacted()["EVENTS_WEBHOOK_SECRET"])
+}
+
+func TestWallet(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.False(t, cfg.WalletEnabled)
+	assert.Equal(t, "sqlite", cfg.WalletStore)
+	assert.Equal(t, "data/wallet.db", cfg.WalletDB)
+	assert.Equal(t, 20, cfg.WalletMaxCards)
+
+	os.Setenv("WALLET_ENABLED", "true")
+	os.Setenv("FINGERPRINT_KEY", "0123456789abcdef0123456789abcdef")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the wallet needs JWT")
+
+	setJWTEnv()
+	os.Unsetenv("FINGERPRINT_KEY")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the wallet needs a fingerprint key")
+
+	os.Setenv("FINGERPRINT_KEY", "0123456789abcdef0123456789abcdef")
+	os.Setenv("WALLET_STORE", "memory")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "memory", cfg.WalletStore)
+
+	os.Setenv("WALLET_STORE", "redis")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+}
+
+func TestExpiryJob(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "0 3 * * *", cfg.ExpirySchedule)
+	assert.Equal(t, time.Date(2025, 6, 16, 3, 0, 0, 0, time.UTC), cfg.ExpiryCron.Next(time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 30, cfg.ExpiryWindowDays)
+	assert.Equal(t, "report", cfg.ExpiryNotify)
+
+	os.Setenv("EXPIRY_SCHEDULE", "0 3 * *")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+	os.Unsetenv("EXPIRY_SCHEDULE")
+
+	os.Setenv("EXPIRY_JOB_ENABLED", "true")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the job needs the wallet")
+
+	os.Setenv("WALLET_ENABLED", "true")
+	os.Setenv("FINGERPRINT_KEY", "0123456789abcdef0123456789abcdef")
+	setJWTEnv()
+	os.Setenv("EXPIRY_NOTIFY", "events")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "events need EVENTS_ENABLED")
+
+	os.Setenv("EVENTS_ENABLED", "true")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.True(t, cfg.ExpiryJobEnabled)
+}
+
+func TestKeyManager(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "none", cfg.KeyManager)
+	assert.Equal(t, "data/keyring.json", cfg.KeyringFile)
+
+	os.Setenv("KEY_MANAGER", "local")
+	os.Setenv("KEYRING_PASSPHRASE", "passphrase")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED]", cfg.Redacted()["KEYRING_PASSPHRASE"])
+
+	os.Setenv("KEY_MANAGER", "vault")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+}
+
+func TestFPE(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("FPE_ENABLED", "true")
+	os.Setenv("FPE_KEY", "2b7e151628aed2a6abf7158809cf4f3c")
+
+	cfg, err := New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "the FPE RPCs need JWT")
+
+	setJWTEnv()
+	os.Unsetenv("FPE_KEY")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "a key is required")
+
+	os.Setenv("FPE_KEY", "2b7e151628aed2a6abf7158809cf4f3c")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED]", cfg.Redacted()["FPE_KEY"])
+
+	os.Setenv("FPE_KEY", "2b7e151628aed2a6")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "keys are 128, 192 or 256 bits")
+
+	os.Setenv("FPE_KEY", "2b7e151628aed2a6abf7158809cf4f3c")
+	os.Setenv("FPE_KEY_ENVELOPE", "v1.a2V5.Y2lwaGVydGV4dA")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "only one key is allowed")
+
+	os.Unsetenv("FPE_KEY")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "envelopes need a key manager")
+
+	os.Setenv("KEY_MANAGER", "local")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.a2V5.Y2lwaGVydGV4dA", cfg.FPEKeyEnvelope)
+}
+
+func TestVelocityLimits(t *testing.T) {
+	defer resetEnv()
+	v := newValidator()
+
+	os.Setenv("SERVICE_NAME", "TestService")
+	os.Setenv("VELOCITY_BIN_MAX", "100")
+
+	cfg, err := New(v)
+	require.NoError(t, err)
+	assert.Equal(t, "flag", cfg.VelocityMode)
+
+	limits := cfg.VelocityLimits()
+	assert.Equal(t, domain.VelocityLimit{Max: 5, Window: time.Hour}, limits.Card)
+	assert.Equal(t, domain.VelocityLimit{Max: 100, Window: time.Minute}, limits.BIN)
+	assert.Equal(t, int64(0), limits.App.Max)
+
+	os.Setenv("VELOCITY_MODE", "block")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+	os.Unsetenv("VELOCITY_MODE")
+
+	os.Setenv("VELOCITY_ENABLED", "true")
+	cfg, err = New(v)
+	assert.Nil(t, cfg)
+	require.Error(t, err, "card limits fingerprint cards")
+
+	os.Setenv("VELOCITY_CARD_MAX", "0")
+	cfg, err = New(v)
+	require.NoError(t, err)
+	assert.True(t, cfg.VelocityEnabled)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// defaultMethodScopes are required of callers of the administrative, wallet and FPE RPCs unless
+// JWT_METHOD_SCOPES sets others for the method.
+var defaultMethodScopes = map[string][]string{
+	"/cards.v1.BinRuleService/CreateBinRule": {"cards.bin_rules.write"},
+	"/cards.v1.BinRuleService/ListBinRules":  {"cards.bin_rules.read"},
+	"/cards.v1.BinRuleService/DeleteBinRule": {"cards.bin_rules.write"},
+
+	// Wallets belong to the app of the caller, which only a token attests.
+	"/cards.v1.WalletService/AddCard":           {"cards.wallet"},
+	"/cards.v1.WalletService/ListCards":         {"cards.wallet"},
+	"/cards.v1.WalletService/GetCard":           {"cards.wallet"},
+	"/cards.v1.WalletService/RemoveCard":        {"cards.wallet"},
+	"/cards.v1.WalletService/SetDefaultCard":    {"cards.wallet"},
+	"/cards.v1.WalletService/ListExpiringCards": {"cards.wallet"},
+
+	// Format-preserving encryption hands out card numbers, so decrypting has a scope of its own.
+	"/cards.v1.FPEService/EncryptPAN": {"cards.fpe.encrypt"},
+	"/cards.v1.FPEService/DecryptPAN": {"cards.fpe.decrypt"},
+}
+
+// parseMethodScopes reads per-RPC scope requirements written as
+// "/pkg.Service/Method=scope1 scope2;/pkg.Service/Other=scope3", on top of the defaults.
+func parseMethodScopes(raw string) (map[string][]string, error) {
+	scopes := make(map[string][]string, len(defaultMethodScopes))
+	for method, required := range defaultMethodScopes {
+		scopes[method] = slices.Clone(required)
+	}
+
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, required, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !ok || !strings.HasPrefix(method, "/") || len(strings.Fields(required)) == 0 {
+			return nil, errors.NewErrorf(errors.InvalidArgument, "invalid JWT_METHOD_SCOPES entry %q", entry)
+		}
+
+		scopes[method] = strings.Fields(required)
+	}
+
+	return scopes, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// parseMethodTimeouts reads per-RPC deadlines written as
+// "/pkg.Service/Method=2s;/pkg.Service/Other=500ms".
+func parseMethodTimeouts(raw string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		method, value, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !ok || !strings.HasPrefix(method, "/") {
+			return nil, errors.NewErrorf(errors.InvalidArgument, "invalid METHOD_TIMEOUTS entry %q", entry)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout <= 0 {
+			return nil, errors.NewErrorf(errors.InvalidArgument, "invalid timeout in METHOD_TIMEOUTS entry %q", entry)
+		}
+
+		timeouts[method] = timeout
+	}
+
+	return timeouts, nil
+}
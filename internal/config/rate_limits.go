@@ -0,0 +1,44 @@
+package config
+
+import (
+	"cards-service/internal/core/domain"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// appRateLimit is a per-app entry in the rate limits file. Entries are kept in a
+// list rather than a map because viper lowercases map keys and app IDs are case sensitive.
+type appRateLimit struct {
+	AppID                  string `mapstructure:"app_id"`
+	domain.RateLimitPolicy `mapstructure:",squash"`
+}
+
+type rateLimitsFile struct {
+	Apps []appRateLimit `mapstructure:"apps"`
+}
+
+// loadRateLimits reads per-app rate limit overrides from a YAML, TOML or JSON file.
+func loadRateLimits(path string) (map[string]domain.RateLimitPolicy, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to load rate limits file")
+	}
+
+	var file rateLimitsFile
+	if err := v.Unmarshal(&file); err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to unmarshal rate limits file")
+	}
+
+	overrides := make(map[string]domain.RateLimitPolicy, len(file.Apps))
+	for _, app := range file.Apps {
+		if app.AppID == "" {
+			return nil, errors.NewErrorf(errors.InvalidArgument, "rate limits file has an entry without app_id")
+		}
+		overrides[app.AppID] = app.RateLimitPolicy
+	}
+
+	return overrides, nil
+}
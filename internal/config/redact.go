@@ -0,0 +1,35 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// Redacted returns the effective configuration keyed by setting name, with the values of
+// fields tagged `secret:"true"` hidden. It is safe to print or serve.
+func (c *Config) Redacted() map[string]any {
+	settings := make(map[string]any)
+
+	val := reflect.ValueOf(c).Elem()
+	typ := val.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		value := val.Field(i).Interface()
+		if field.Tag.Get("secret") == "true" && !val.Field(i).IsZero() {
+			value = redacted
+		}
+
+		settings[name] = value
+	}
+
+	return settings
+}
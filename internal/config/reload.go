@@ -0,0 +1,217 @@
+package config
+
+import (
+	"cards-service/internal/core/ports"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// dotEnvFile is the optional .env file read from the working directory.
+const dotEnvFile = "config.env"
+
+// Reloader reloads the dynamic settings on SIGHUP or when a configuration file changes,
+// and notifies subscribers of the new configuration. A configuration that fails validation
+// or one of the checks is rejected and the current one stays active.
+type Reloader struct {
+	mu          sync.RWMutex
+	current     *Config
+	version     uint64
+	val         ports.AppValidator
+	args        []string
+	logger      *zap.Logger
+	checks      []func(*Config) error
+	subscribers []func(*Config)
+}
+
+func NewReloader(cfg *Config, val ports.AppValidator, args []string, logger *zap.Logger) *Reloader {
+	return &Reloader{current: cfg, version: 1, val: val, args: args, logger: logger}
+}
+
+// Current returns the active configuration. It must not be modified.
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.current
+}
+
+func (r *Reloader) Version() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.version
+}
+
+// Check registers fn to vet every reloaded configuration before it is activated, for
+// settings that only their consumers can validate.
+func (r *Reloader) Check(fn func(*Config) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, fn)
+}
+
+// Subscribe registers fn to be called with every configuration that is activated.
+func (r *Reloader) Subscribe(fn func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, fn)
+}
+
+// Reload loads the configuration again and activates its dynamic settings. Changes to
+// static settings are logged and ignored until the next restart.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded, err := New(r.val, r.args...)
+	if err != nil {
+		r.logger.Error("rejected configuration reload", zap.Uint64("config_version", r.version), zap.Error(err))
+		return err
+	}
+
+	next := *r.current
+	changed, ignored := applyDynamic(&next, loaded)
+
+	if len(ignored) > 0 {
+		r.logger.Warn("static settings changed and need a restart", zap.Strings("settings", ignored))
+	}
+
+	if len(changed) == 0 {
+		r.logger.Info("configuration unchanged", zap.Uint64("config_version", r.version))
+		return nil
+	}
+
+	if err := next.validate(r.val); err != nil {
+		r.logger.Error("rejected configuration reload", zap.Uint64("config_version", r.version), zap.Error(err))
+		return err
+	}
+
+	for _, check := range r.checks {
+		if err := check(&next); err != nil {
+			r.logger.Error("rejected configuration reload", zap.Uint64("config_version", r.version), zap.Error(err))
+			return err
+		}
+	}
+
+	r.current = &next
+	r.version++
+
+	for _, fn := range r.subscribers {
+		fn(r.current)
+	}
+
+	r.logger.Info(
+		"configuration reloaded",
+		zap.Uint64("config_version", r.version),
+		zap.String("config_checksum", r.current.Checksum()),
+		zap.Strings("changed", changed),
+	)
+
+	return nil
+}
+
+// Run reloads on SIGHUP and, when interval is positive, whenever one of the configuration
+// files changes. It returns when ctx is done.
+func (r *Reloader) Run(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	seen := r.fileVersions()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.logger.Info("reloading configuration on SIGHUP")
+			_ = r.Reload()
+			seen = r.fileVersions()
+		case <-tick:
+			if current := r.fileVersions(); !maps.Equal(seen, current) {
+				r.logger.Info("reloading configuration after file change")
+				_ = r.Reload()
+				seen = current
+			}
+		}
+	}
+}
+
+// fileVersions returns the modification time of every file the configuration is read from.
+func (r *Reloader) fileVersions() map[string]time.Time {
+	cfg := r.Current()
+	versions := make(map[string]time.Time)
+
+	for _, path := range []string{dotEnvFile, cfg.ConfigFile, cfg.RateLimitsFile, cfg.AppPoliciesFile} {
+		if path == "" {
+			continue
+		}
+
+		if info, err := os.Stat(path); err == nil {
+			versions[path] = info.ModTime()
+		} else {
+			versions[path] = time.Time{}
+		}
+	}
+
+	return versions
+}
+
+// Checksum identifies the effective configuration so replicas can be compared in logs.
+func (c *Config) Checksum() string {
+	data, _ := json.Marshal(c.Redacted())
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// applyDynamic copies the dynamic settings of loaded into cfg and reports which settings
+// changed, and which static settings differ and were left alone.
+func applyDynamic(cfg, loaded *Config) (changed, ignored []string) {
+	dst := reflect.ValueOf(cfg).Elem()
+	src := reflect.ValueOf(loaded).Elem()
+	typ := dst.Type()
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if reflect.DeepEqual(dst.Field(i).Interface(), src.Field(i).Interface()) {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		if field.Tag.Get("dynamic") != "true" {
+			ignored = append(ignored, name)
+			continue
+		}
+
+		dst.Field(i).Set(src.Field(i))
+		changed = append(changed, name)
+	}
+
+	return changed, ignored
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func newTestReloader(t *testing.T, content string) (*Reloader, string) {
+	t.Helper()
+
+	path := t.TempDir() + "/config.yaml"
+	writeConfig(t, path, content)
+
+	v := newValidator()
+	args := []string{"--config-file", path}
+
+	cfg, err := New(v, args...)
+	require.NoError(t, err)
+
+	return NewReloader(cfg, v, args, zap.NewNop()), path
+}
+
+func TestReloader(t *testing.T) {
+
+	t.Run("Applies Dynamic Settings", func(t *testing.T) {
+		reloader, path := newTestReloader(t, "service_name: TestService\nlog_level: info\napp:\n  rate_limit: 50\n")
+
+		var notified *Config
+		reloader.Subscribe(func(cfg *Config) { notified = cfg })
+
+		writeConfig(t, path, "service_name: TestService\nlog_level: debug\napp:\n  rate_limit: 5\n")
+		require.NoError(t, reloader.Reload())
+
+		require.NotNil(t, notified)
+		assert.Equal(t, "debug", notified.LogLevel)
+		assert.Equal(t, float64(5), notified.RateLimits().App.RequestsPerSecond)
+		assert.Same(t, notified, reloader.Current())
+		assert.Equal(t, uint64(2), reloader.Version())
+	})
+
+	t.Run("Ignores Static Settings", func(t *testing.T) {
+		reloader, path := newTestReloader(t, "service_name: TestService\nserver_port: 8090\n")
+
+		writeConfig(t, path, "service_name: TestService\nserver_port: 8091\nlog_level: warn\n")
+		require.NoError(t, reloader.Reload())
+
+		assert.Equal(t, 8090, reloader.Current().ServerPort)
+		assert.Equal(t, "warn", reloader.Current().LogLevel)
+	})
+
+	t.Run("Keeps Current Config When Invalid", func(t *testing.T) {
+		reloader, path := newTestReloader(t, "service_name: TestService\n")
+		current := reloader.Current()
+
+		notified := false
+		reloader.Subscribe(func(*Config) { notified = true })
+
+		writeConfig(t, path, "service_name: TestService\nlog_level: verbose\n")
+		require.Error(t, reloader.Reload())
+
+		assert.Same(t, current, reloader.Current())
+		assert.Equal(t, uint64(1), reloader.Version())
+		assert.False(t, notified)
+	})
+
+	t.Run("Keeps Current Config When A Check Fails", func(t *testing.T) {
+		reloader, path := newTestReloader(t, "service_name: TestService\n")
+		current := reloader.Current()
+
+		notified := false
+		reloader.Check(func(cfg *Config) error {
+			if cfg.LogLevel == "debug" {
+				return errors.NewErrorf(errors.InvalidArgument, "debug logging is not allowed")
+			}
+			return nil
+		})
+		reloader.Subscribe(func(*Config) { notified = true })
+
+		writeConfig(t, path, "service_name: TestService\nlog_level: debug\n")
+		require.Error(t, reloader.Reload())
+
+		assert.Same(t, current, reloader.Current())
+		assert.False(t, notified)
+
+		writeConfig(t, path, "service_name: TestService\nlog_level: warn\n")
+		require.NoError(t, reloader.Reload())
+		assert.True(t, notified)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		reloader, _ := newTestReloader(t, "service_name: TestService\n")
+
+		require.NoError(t, reloader.Reload())
+		assert.Equal(t, uint64(1), reloader.Version())
+	})
+}
+
+func TestReloaderRun(t *testing.T) {
+
+	t.Run("File Change", func(t *testing.T) {
+		reloader, path := newTestReloader(t, "service_name: TestService\n")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go reloader.Run(ctx, 10*time.Millisecond)
+
+		time.Sleep(30 * time.Millisecond)
+		writeConfig(t, path, "service_name: TestService\nlog_level: error\n")
+		later := time.Now().Add(time.Second)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		assert.Eventually(t, func() bool {
+			return reloader.Current().LogLevel == "error"
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("SIGHUP", func(t *testing.T) {
+		reloader, path := newTestReloader(t, "service_name: TestService\n")
+
+		// Keeps the signal from terminating the test binary before Run subscribes to it.
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		defer signal.Stop(hup)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go reloader.Run(ctx, 0)
+
+		writeConfig(t, path, "service_name: TestService\nlog_level: debug\n")
+
+		assert.Eventually(t, func() bool {
+			_ = syscall.Kill(os.Getpid(), syscall.SIGHUP)
+			return reloader.Current().LogLevel == "debug"
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// secretFileSuffix marks a key whose value is read from the file it names, so secrets can
+// come from mounted files instead of the environment.
+const secretFileSuffix = "_file"
+
+// bindFlags exposes every configuration key as a command-line flag, e.g. SERVER_PORT as
+// --server-port. Flags take precedence over every other source.
+func bindFlags(v *viper.Viper, args []string) (*pflag.FlagSet, error) {
+	flags := pflag.NewFlagSet("cards-service", pflag.ContinueOnError)
+	flags.SortFlags = true
+
+	for _, key := range v.AllKeys() {
+		name := strings.ReplaceAll(key, "_", "-")
+		usage := "overrides " + strings.ToUpper(key)
+
+		switch value := v.Get(key).(type) {
+		case bool:
+			flags.Bool(name, value, usage)
+		case int:
+			flags.Int(name, value, usage)
+		case float64:
+			flags.Float64(name, value, usage)
+		default:
+			flags.String(name, v.GetString(key), usage)
+		}
+
+		if err := v.BindPFlag(key, flags.Lookup(name)); err != nil {
+			return nil, errors.WrapError(err, errors.Internal, "failed to bind flag %s", name)
+		}
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, errors.WrapError(err, errors.InvalidArgument, "invalid command-line flags")
+	}
+
+	return flags, nil
+}
+
+// mergeConfigFile layers a YAML, TOML or JSON file over the defaults and the .env file.
+// Nested sections are flattened, so "tls: {cert_file: x}" sets TLS_CERT_FILE.
+func mergeConfigFile(v *viper.Viper, path string) error {
+	fv := viper.New()
+	fv.SetConfigFile(path)
+
+	if err := fv.ReadInConfig(); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to load configuration file %s", path)
+	}
+
+	settings := make(map[string]any)
+	flatten("", fv.AllSettings(), settings)
+
+	return v.MergeConfigMap(settings)
+}
+
+func flatten(prefix string, nested map[string]any, flat map[string]any) {
+	for key, value := range nested {
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		if section, ok := value.(map[string]any); ok {
+			flatten(key, section, flat)
+			continue
+		}
+
+		flat[key] = value
+	}
+}
+
+// resolveSecretFiles replaces the value of every key whose KEY_FILE counterpart is set
+// with the contents of that file. The file stands in for the environment variable, so it
+// overrides the variable and the configuration files but not a flag for the key itself.
+func resolveSecretFiles(v *viper.Viper, flags *pflag.FlagSet) error {
+	keys := v.AllKeys()
+
+	for _, key := range keys {
+		fileKey := key + secretFileSuffix
+		if slices.Contains(keys, fileKey) {
+			continue
+		}
+
+		path := v.GetString(fileKey)
+		if path == "" || flags.Changed(strings.ReplaceAll(key, "_", "-")) {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.WrapError(err, errors.Internal, "failed to read %s", strings.ToUpper(fileKey))
+		}
+
+		v.Set(key, strings.TrimRight(string(data), "\r\n"))
+	}
+
+	return nil
+}
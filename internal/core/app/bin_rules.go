@@ -0,0 +1,128 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BinRules manages the BIN block and allow rules. Validations match against a copy of the
+// rules kept in memory, refreshed after every change made here and periodically, to pick up
+// changes made by other replicas sharing the store.
+type BinRules struct {
+	store ports.BinRuleStore
+	now   func() time.Time
+
+	mu    sync.RWMutex
+	rules []domain.BinRule
+}
+
+func NewBinRules(ctx context.Context, store ports.BinRuleStore) (*BinRules, error) {
+	br := &BinRules{store: store, now: time.Now}
+
+	if err := br.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return br, nil
+}
+
+func (br *BinRules) CreateBinRule(ctx context.Context, rule *domain.BinRule) (*domain.BinRule, error) {
+	created := *rule
+	if created.RangeEnd == "" {
+		created.RangeEnd = created.RangeStart
+	}
+	created.CreatedAt = br.now().UTC()
+	created.CreatedBy = callerName(domain.CallerFromContext(ctx))
+
+	if err := created.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+	created.ID = id
+
+	if err := br.store.Create(ctx, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, br.Refresh(ctx)
+}
+
+func (br *BinRules) ListBinRules(ctx context.Context, includeExpired bool) ([]domain.BinRule, error) {
+	rules, err := br.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !includeExpired {
+		now := br.now()
+		rules = slices.DeleteFunc(rules, func(rule domain.BinRule) bool { return rule.Expired(now) })
+	}
+
+	return rules, nil
+}
+
+func (br *BinRules) DeleteBinRule(ctx context.Context, id string) error {
+	if err := br.store.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return br.Refresh(ctx)
+}
+
+// Match returns the rule that applies to the BIN, or nil when none does.
+func (br *BinRules) Match(bin string) *domain.BinRule {
+	br.mu.RLock()
+	defer br.mu.RUnlock()
+
+	return domain.MatchBinRule(br.rules, bin, br.now())
+}
+
+// Refresh reloads the rules from the store.
+func (br *BinRules) Refresh(ctx context.Context) error {
+	rules, err := br.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	br.rules = rules
+
+	return nil
+}
+
+// Run refreshes the rules every interval until ctx is done. Failed refreshes are reported
+// to onError and keep the current rules.
+func (br *BinRules) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := br.Refresh(ctx); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// callerName identifies who made a change, preferring the token subject over the app.
+func callerName(caller domain.Caller) string {
+	if caller.Subject != "" {
+		return caller.Subject
+	}
+
+	return caller.AppID
+}
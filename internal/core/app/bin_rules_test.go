@@ -0,0 +1,141 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryBinRuleStore struct {
+	rules []domain.BinRule
+}
+
+func (s *memoryBinRuleStore) Create(ctx context.Context, rule *domain.BinRule) error {
+	s.rules = append(s.rules, *rule)
+	return nil
+}
+
+func (s *memoryBinRuleStore) List(ctx context.Context) ([]domain.BinRule, error) {
+	return slices.Clone(s.rules), nil
+}
+
+func (s *memoryBinRuleStore) Delete(ctx context.Context, id string) error {
+	n := len(s.rules)
+	s.rules = slices.DeleteFunc(s.rules, func(rule domain.BinRule) bool { return rule.ID == id })
+	if len(s.rules) == n {
+		return errors.NewErrorf(errors.NotFound, "BIN rule %s not found", id)
+	}
+	return nil
+}
+
+func newTestBinRules(t *testing.T, rules ...domain.BinRule) *BinRules {
+	binRules, err := NewBinRules(context.Background(), &memoryBinRuleStore{rules: rules})
+	require.NoError(t, err)
+
+	return binRules
+}
+
+func TestBinRules(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "risk-console", Subject: "alice"})
+
+	t.Run("Create", func(t *testing.T) {
+		binRules := newTestBinRules(t)
+
+		rule, err := binRules.CreateBinRule(ctx, &domain.BinRule{RangeStart: "411111", Action: domain.BinRuleBlock, Reason: "compromised issuer"})
+		require.NoError(t, err)
+		assert.Len(t, rule.ID, 32)
+		assert.Equal(t, "411111", rule.RangeEnd, "the range defaults to a single prefix")
+		assert.Equal(t, "alice", rule.CreatedBy)
+		assert.False(t, rule.CreatedAt.IsZero())
+
+		assert.Equal(t, rule.ID, binRules.Match("41111111").ID, "new rules apply immediately")
+	})
+
+	t.Run("Create Invalid", func(t *testing.T) {
+		binRules := newTestBinRules(t)
+
+		_, err := binRules.CreateBinRule(ctx, &domain.BinRule{RangeStart: "4111", Action: domain.BinRuleBlock})
+		require.Error(t, err)
+		assert.Equal(t, errors.InvalidArgument, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("List Hides Expired Rules", func(t *testing.T) {
+		expired := time.Now().Add(-time.Minute)
+		binRules := newTestBinRules(t,
+			domain.BinRule{ID: "a", RangeStart: "4", RangeEnd: "4", Action: domain.BinRuleBlock, Reason: "r"},
+			domain.BinRule{ID: "b", RangeStart: "5", RangeEnd: "5", Action: domain.BinRuleBlock, Reason: "r", ExpiresAt: expired},
+		)
+
+		rules, err := binRules.ListBinRules(ctx, false)
+		require.NoError(t, err)
+		assert.Len(t, rules, 1)
+
+		rules, err = binRules.ListBinRules(ctx, true)
+		require.NoError(t, err)
+		assert.Len(t, rules, 2)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		binRules := newTestBinRules(t, domain.BinRule{ID: "a", RangeStart: "4", RangeEnd: "4", Action: domain.BinRuleBlock, Reason: "r"})
+
+		require.NoError(t, binRules.DeleteBinRule(ctx, "a"))
+		assert.Nil(t, binRules.Match("41111111"))
+
+		err := binRules.DeleteBinRule(ctx, "a")
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+	})
+}
+
+func TestServiceBinRules(t *testing.T) {
+	ctx := context.Background()
+	binRules := newTestBinRules(t,
+		domain.BinRule{ID: "block", RangeStart: "411111", RangeEnd: "411111", Action: domain.BinRuleBlock, Reason: "fraud"},
+		domain.BinRule{ID: "allow", RangeStart: "7000", RangeEnd: "7000", Action: domain.BinRuleAllow, Reason: "private label"},
+	)
+
+	t.Run("Blocked Range Is Rejected", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithBinRules(binRules), WithMetrics(metrics))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.Error(t, err)
+		assert.Equal(t, errors.InvalidArgument, err.(*errors.Error).ErrCode)
+		assert.Equal(t, []validation{{unknownNetwork, OutcomeRejected, RejectionBinBlocked}}, metrics.validations)
+	})
+
+	t.Run("Other Ranges Are Unaffected", func(t *testing.T) {
+		svc := NewService(validator.New(), WithBinRules(binRules))
+
+		info, err := svc.ValidateCardNumber(ctx, "4012888888881881")
+		require.NoError(t, err)
+		assert.Equal(t, "VISA", info.CardProvider)
+	})
+
+	t.Run("Allowed Private Label Range Is Accepted", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithBinRules(binRules), WithMetrics(metrics))
+
+		info, err := svc.ValidateCardNumber(ctx, "7000123400000001")
+		require.NoError(t, err)
+		assert.Equal(t, domain.NetworkPrivateLabel, info.CardProvider)
+		assert.Equal(t, domain.SourceBinRule, info.Bin.Source)
+		assert.Equal(t, []validation{{domain.NetworkPrivateLabel, OutcomeAccepted, ""}}, metrics.validations)
+	})
+
+	t.Run("Allowed Range Must Pass The Luhn Check", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithBinRules(binRules), WithMetrics(metrics))
+
+		_, err := svc.ValidateCardNumber(ctx, "7000123400000002")
+		require.Error(t, err)
+		assert.Equal(t, []validation{{unknownNetwork, OutcomeRejected, RejectionLuhnCheckFailed}}, metrics.validations)
+	})
+}
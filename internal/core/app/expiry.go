@@ -0,0 +1,185 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// expiryLease names the lease replicas hold while checking card expiry.
+const expiryLease = "card_expiry"
+
+// ExpiryJob finds the saved cards about to expire and those that have expired. Each card is
+// reported once as expiring and once more as expired, when it is also made inactive, so runs
+// can be repeated without telling apps twice.
+type ExpiryJob struct {
+	repo    ports.WalletRepository
+	lease   ports.Lease
+	holder  string
+	window  time.Duration
+	ttl     time.Duration
+	events  bool
+	reports ports.ExpiryReporter
+	now     func() time.Time
+}
+
+type ExpiryOption func(*ExpiryJob)
+
+// WithExpiryEvents publishes an event for every card found, which the repository stores
+// together with the status change of the card.
+func WithExpiryEvents() ExpiryOption {
+	return func(job *ExpiryJob) {
+		job.events = true
+	}
+}
+
+// WithExpiryReports writes a report of the cards found for each app.
+func WithExpiryReports(reporter ports.ExpiryReporter) ExpiryOption {
+	return func(job *ExpiryJob) {
+		job.reports = reporter
+	}
+}
+
+// NewExpiryJob creates a job for cards that expire within window. Only the replica holding
+// the lease, named holder here, runs the job; ttl bounds how long a replica that died
+// mid-run keeps the others from taking over.
+func NewExpiryJob(repo ports.WalletRepository, lease ports.Lease, holder string, window time.Duration, ttl time.Duration, opts ...ExpiryOption) *ExpiryJob {
+	job := &ExpiryJob{repo: repo, lease: lease, holder: holder, window: window, ttl: ttl, now: time.Now}
+
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	return job
+}
+
+// Check runs the job once, unless another replica holds the lease, and returns the reports
+// of the apps whose cards changed. The reports are nil when the job did not run.
+func (j *ExpiryJob) Check(ctx context.Context) ([]domain.ExpiryReport, error) {
+	acquired, err := j.lease.Acquire(ctx, expiryLease, j.holder, j.ttl)
+	if err != nil || !acquired {
+		return nil, err
+	}
+	defer j.lease.Release(context.WithoutCancel(ctx), expiryLease, j.holder)
+	renewed := j.now()
+
+	now := j.now().UTC()
+	cards, err := j.repo.ListExpiring(ctx, "", now.Add(j.window))
+	if err != nil {
+		return nil, err
+	}
+
+	reports := []domain.ExpiryReport{}
+	changed := [][]*domain.WalletCard{}
+	byApp := make(map[string]int)
+
+	for i := range cards {
+		card := &cards[i]
+
+		status := domain.CardStatusExpiring
+		if card.Expired(now) {
+			status = domain.CardStatusInactive
+		}
+		if card.Status == status || card.Status == domain.CardStatusInactive {
+			continue
+		}
+		card.Status = status
+
+		n, ok := byApp[card.AppID]
+		if !ok {
+			n = len(reports)
+			byApp[card.AppID] = n
+			reports = append(reports, domain.ExpiryReport{AppID: card.AppID, GeneratedAt: now})
+			changed = append(changed, nil)
+		}
+		reports[n].Cards = append(reports[n].Cards, domain.NewExpiringCard(card))
+		changed[n] = append(changed[n], card)
+	}
+
+	// Reports are written before the status of their cards changes: should the change fail,
+	// the cards are reported again on the next run rather than never. Events are published
+	// with the change itself.
+	for n := range reports {
+		if j.reports != nil {
+			if err := j.reports.Report(ctx, &reports[n]); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, card := range changed[n] {
+			if err := j.renew(ctx, &renewed); err != nil {
+				return nil, err
+			}
+
+			var event *domain.Event
+			if j.events {
+				if event, err = j.event(now, card); err != nil {
+					return nil, err
+				}
+			}
+			if err := j.repo.SetStatus(ctx, card.AppID, card.CustomerID, card.Token, card.Status, event); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return reports, nil
+}
+
+// renew extends the lease once half of its ttl has passed since it was last taken, so long
+// runs keep it. It fails when another replica has taken the lease over meanwhile.
+func (j *ExpiryJob) renew(ctx context.Context, renewed *time.Time) error {
+	if j.now().Sub(*renewed) < j.ttl/2 {
+		return nil
+	}
+
+	acquired, err := j.lease.Acquire(ctx, expiryLease, j.holder, j.ttl)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errors.NewErrorf(errors.Conflict, "lost the %s lease to another replica", expiryLease)
+	}
+	*renewed = j.now()
+
+	return nil
+}
+
+// Run runs the job at every time the schedule matches until ctx is done. Failed runs are
+// reported to onError and retried at the next scheduled time.
+func (j *ExpiryJob) Run(ctx context.Context, schedule *domain.Schedule, onError func(error)) {
+	for {
+		next := schedule.Next(j.now())
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if _, err := j.Check(ctx); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (j *ExpiryJob) event(now time.Time, card *domain.WalletCard) (*domain.Event, error) {
+	eventType := domain.EventCardExpiring
+	if card.Status == domain.CardStatusInactive {
+		eventType = domain.EventCardExpired
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewEvent(id, eventType, now, card.AppID, domain.NewExpiringCard(card))
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLease is held by whoever acquired it last, until released.
+type fakeLease struct {
+	holder   string
+	acquired int
+}
+
+func (l *fakeLease) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	if l.holder != "" && l.holder != holder {
+		return false, nil
+	}
+
+	l.holder = holder
+	l.acquired++
+	return true, nil
+}
+
+func (l *fakeLease) Release(ctx context.Context, name string, holder string) error {
+	if l.holder == holder {
+		l.holder = ""
+	}
+
+	return nil
+}
+
+type recordingReporter struct {
+	reports []domain.ExpiryReport
+	err     error
+}
+
+func (r *recordingReporter) Report(ctx context.Context, report *domain.ExpiryReport) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	r.reports = append(r.reports, *report)
+	return nil
+}
+
+func TestExpiryJob(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 15, 3, 0, 0, 0, time.UTC)
+
+	newRepo := func() *fakeWalletRepository {
+		card := func(token string, appID string, month int, year int) domain.WalletCard {
+			return domain.WalletCard{
+				Token:       token,
+				AppID:       appID,
+				CustomerID:  "cus-1",
+				MaskedPAN:   "411111******1111",
+				ExpiryMonth: month,
+				ExpiryYear:  year,
+				Status:      domain.CardStatusActive,
+			}
+		}
+
+		return &fakeWalletRepository{cards: []domain.WalletCard{
+			card("expired", "checkout", 5, 2025),
+			card("expiring", "checkout", 6, 2025),
+			card("other-app", "billing", 7, 2025),
+			card("valid", "checkout", 12, 2030),
+		}}
+	}
+
+	newJob := func(repo *fakeWalletRepository, opts ...ExpiryOption) *ExpiryJob {
+		job := NewExpiryJob(repo, &fakeLease{}, "replica-1", 30*24*time.Hour, time.Minute, opts...)
+		job.now = func() time.Time { return now }
+
+		return job
+	}
+
+	t.Run("Reports Each Card Once", func(t *testing.T) {
+		repo := newRepo()
+		reporter := &recordingReporter{}
+		job := newJob(repo, WithExpiryEvents(), WithExpiryReports(reporter))
+
+		reports, err := job.Check(ctx)
+		require.NoError(t, err)
+		require.Len(t, reports, 1, "July cards are not within 30 days of June 15")
+		assert.Equal(t, reporter.reports, reports)
+		assert.Equal(t, "checkout", reports[0].AppID)
+		require.Len(t, reports[0].Cards, 2)
+		assert.Equal(t, domain.CardStatusInactive, reports[0].Cards[0].Status)
+		assert.Equal(t, domain.CardStatusExpiring, reports[0].Cards[1].Status)
+
+		require.Len(t, repo.events, 2)
+		assert.Equal(t, domain.EventCardExpired, repo.events[0].Type)
+		assert.Equal(t, domain.EventCardExpiring, repo.events[1].Type)
+		assert.Equal(t, "checkout", repo.events[1].AppID)
+
+		var data domain.ExpiringCard
+		require.NoError(t, json.Unmarshal(repo.events[1].Data, &data))
+		assert.Equal(t, "expiring", data.Token)
+		assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), data.ExpiresAt)
+
+		assert.Equal(t, domain.CardStatusInactive, repo.cards[0].Status)
+		assert.Equal(t, domain.CardStatusExpiring, repo.cards[1].Status)
+		assert.Equal(t, domain.CardStatusActive, repo.cards[2].Status)
+
+		reports, err = job.Check(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+		assert.Len(t, repo.events, 2)
+	})
+
+	t.Run("Expiring Cards Are Made Inactive Once Expired", func(t *testing.T) {
+		repo := newRepo()
+		job := newJob(repo, WithExpiryEvents())
+
+		_, err := job.Check(ctx)
+		require.NoError(t, err)
+
+		now = now.AddDate(0, 1, 0)
+		defer func() { now = now.AddDate(0, -1, 0) }()
+
+		reports, err := job.Check(ctx)
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		assert.Equal(t, "expiring", reports[0].Cards[0].Token)
+		assert.Equal(t, domain.CardStatusInactive, reports[0].Cards[0].Status)
+		assert.Equal(t, "billing", reports[1].AppID)
+		assert.Equal(t, domain.CardStatusInactive, repo.cards[1].Status)
+	})
+
+	t.Run("Skips When Another Replica Holds The Lease", func(t *testing.T) {
+		repo := newRepo()
+		job := newJob(repo)
+		job.lease = &fakeLease{holder: "replica-2"}
+
+		reports, err := job.Check(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, reports)
+		assert.Equal(t, domain.CardStatusActive, repo.cards[0].Status)
+	})
+
+	t.Run("Failed Status Changes Leave The Card For The Next Run", func(t *testing.T) {
+		repo := newRepo()
+		repo.err = assert.AnError
+		job := newJob(repo, WithExpiryEvents())
+
+		_, err := job.Check(ctx)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, domain.CardStatusActive, repo.cards[0].Status)
+		assert.Empty(t, repo.events)
+
+		repo.err = nil
+		_, err = job.Check(ctx)
+		require.NoError(t, err)
+		assert.Len(t, repo.events, 2)
+	})
+
+	t.Run("Failed Reports Leave The Cards For The Next Run", func(t *testing.T) {
+		repo := newRepo()
+		job := newJob(repo, WithExpiryReports(&recordingReporter{err: assert.AnError}))
+
+		_, err := job.Check(ctx)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, domain.CardStatusActive, repo.cards[0].Status)
+		assert.Equal(t, domain.CardStatusActive, repo.cards[1].Status)
+	})
+
+	t.Run("Renews The Lease During Long Runs", func(t *testing.T) {
+		repo := newRepo()
+		lease := &fakeLease{}
+		job := newJob(repo)
+		job.lease = lease
+		clock := now
+		job.now = func() time.Time {
+			clock = clock.Add(20 * time.Second)
+			return clock
+		}
+
+		_, err := job.Check(ctx)
+		require.NoError(t, err)
+		assert.Greater(t, lease.acquired, 1)
+		assert.Equal(t, domain.CardStatusInactive, repo.cards[0].Status)
+	})
+
+	t.Run("Stops When The Lease Is Lost", func(t *testing.T) {
+		repo := newRepo()
+		lease := &fakeLease{}
+		job := newJob(repo)
+		job.lease = lease
+		clock := now
+		job.now = func() time.Time {
+			clock = clock.Add(40 * time.Second)
+			if clock.Sub(now) > time.Minute {
+				lease.holder = "replica-2"
+			}
+			return clock
+		}
+
+		_, err := job.Check(ctx)
+		require.Error(t, err)
+		assert.Equal(t, errors.Conflict, err.(*errors.Error).ErrCode)
+	})
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"cards-service/internal/core/ports"
+	"context"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	// fpeKeptPrefix and fpeKeptSuffix are the digits left in the clear: the BIN and the last
+	// four.
+	fpeKeptPrefix = 6
+	fpeKeptSuffix = 4
+	// fpeMinLength leaves the six digits in between that FPE needs at the least.
+	fpeMinLength = 16
+	fpeMaxLength = 19
+)
+
+// PANCipher encrypts card numbers into others that still look like card numbers: they keep
+// their length, first six and last four digits, and only the digits in between change. The
+// result is not generally Luhn-valid. Only the same key, algorithm and tweak decrypt it.
+type PANCipher struct {
+	ciphers map[string]ports.FormatPreservingCipher
+}
+
+// NewPANCipher creates a cipher offering the given algorithms, by name.
+func NewPANCipher(ciphers map[string]ports.FormatPreservingCipher) *PANCipher {
+	return &PANCipher{ciphers: ciphers}
+}
+
+func (c *PANCipher) EncryptPAN(ctx context.Context, algorithm string, cardNumber string, tweak []byte) (string, error) {
+	return c.apply(algorithm, "card_number", cardNumber, func(fpe ports.FormatPreservingCipher, digits string) (string, error) {
+		return fpe.Encrypt(tweak, digits)
+	})
+}
+
+func (c *PANCipher) DecryptPAN(ctx context.Context, algorithm string, encrypted string, tweak []byte) (string, error) {
+	return c.apply(algorithm, "encrypted_card_number", encrypted, func(fpe ports.FormatPreservingCipher, digits string) (string, error) {
+		return fpe.Decrypt(tweak, digits)
+	})
+}
+
+// apply runs op over the digits between the BIN and the last four.
+func (c *PANCipher) apply(algorithm string, field string, pan string, op func(ports.FormatPreservingCipher, string) (string, error)) (string, error) {
+	fpe, ok := c.ciphers[algorithm]
+	if !ok {
+		return "", errors.NewErrorf(errors.InvalidArgument, "unsupported algorithm %q", algorithm)
+	}
+
+	if len(pan) < fpeMinLength || len(pan) > fpeMaxLength || strings.Trim(pan, "0123456789") != "" {
+		return "", errors.NewValidationError([]*errors.FieldViolation{
+			{Field: field, Description: "must be 16 to 19 digits"},
+		})
+	}
+
+	end := len(pan) - fpeKeptSuffix
+	middle, err := op(fpe, pan[fpeKeptPrefix:end])
+	if err != nil {
+		return "", err
+	}
+
+	return pan[:fpeKeptPrefix] + middle + pan[end:], nil
+}
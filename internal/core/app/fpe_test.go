@@ -0,0 +1,70 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"testing"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shiftCipher adds one plus the length of the tweak to every digit, modulo ten.
+type shiftCipher struct{}
+
+func (shiftCipher) shift(tweak []byte, digits string, sign int) string {
+	out := []byte(digits)
+	for i, d := range out {
+		out[i] = byte('0' + ((int(d-'0')+sign*(1+len(tweak)))%10+10)%10)
+	}
+	return string(out)
+}
+
+func (c shiftCipher) Encrypt(tweak []byte, digits string) (string, error) {
+	return c.shift(tweak, digits, 1), nil
+}
+
+func (c shiftCipher) Decrypt(tweak []byte, digits string) (string, error) {
+	return c.shift(tweak, digits, -1), nil
+}
+
+func TestPANCipher(t *testing.T) {
+	ctx := context.Background()
+	cipher := NewPANCipher(map[string]ports.FormatPreservingCipher{domain.FPEFF1: shiftCipher{}})
+
+	t.Run("Keeps The BIN And Last Four", func(t *testing.T) {
+		encrypted, err := cipher.EncryptPAN(ctx, domain.FPEFF1, "4111111111111111", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "4111112222221111", encrypted)
+
+		pan, err := cipher.DecryptPAN(ctx, domain.FPEFF1, encrypted, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "4111111111111111", pan)
+	})
+
+	t.Run("Tweak", func(t *testing.T) {
+		encrypted, err := cipher.EncryptPAN(ctx, domain.FPEFF1, "4111111111111111111", []byte("t"))
+		require.NoError(t, err)
+		assert.Equal(t, "4111113333333331111", encrypted)
+
+		pan, err := cipher.DecryptPAN(ctx, domain.FPEFF1, encrypted, []byte("t"))
+		require.NoError(t, err)
+		assert.Equal(t, "4111111111111111111", pan)
+	})
+
+	t.Run("Invalid Card Numbers", func(t *testing.T) {
+		for _, pan := range []string{"", "378282246310005", "41111111111111111111", "4111-1111-1111-1111", "411111111111111a"} {
+			_, err := cipher.EncryptPAN(ctx, domain.FPEFF1, pan, nil)
+			require.Error(t, err, pan)
+			assert.Equal(t, errors.InvalidArgument, err.(*errors.Error).ErrCode, pan)
+		}
+	})
+
+	t.Run("Unsupported Algorithm", func(t *testing.T) {
+		_, err := cipher.EncryptPAN(ctx, domain.FPEFF31, "4111111111111111", nil)
+		require.Error(t, err)
+		assert.Equal(t, errors.InvalidArgument, err.(*errors.Error).ErrCode)
+	})
+}
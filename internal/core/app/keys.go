@@ -0,0 +1,128 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	KeyEncrypt = "encrypt"
+	KeyDecrypt = "decrypt"
+	KeyRewrap  = "rewrap"
+	KeyRotate  = "rotate"
+
+	// OutcomeCompleted is a key operation that succeeded.
+	OutcomeCompleted = "completed"
+)
+
+// AuditedKeys records every use of the keys of a key manager in the audit trail. Like
+// validations, operations that cannot be audited fail.
+type AuditedKeys struct {
+	keys  ports.KeyManager
+	audit ports.AuditSink
+}
+
+func NewAuditedKeys(keys ports.KeyManager, audit ports.AuditSink) *AuditedKeys {
+	return &AuditedKeys{keys: keys, audit: audit}
+}
+
+func (k *AuditedKeys) Encrypt(ctx context.Context, purpose string, plaintext []byte, aad []byte) (*domain.Envelope, error) {
+	envelope, err := k.keys.Encrypt(ctx, purpose, plaintext, aad)
+
+	version := k.keys.CurrentVersion()
+	if envelope != nil {
+		version = envelope.KeyVersion
+	}
+
+	return envelope, k.record(ctx, KeyEncrypt, purpose, version, err)
+}
+
+func (k *AuditedKeys) Decrypt(ctx context.Context, purpose string, envelope *domain.Envelope, aad []byte) ([]byte, error) {
+	plaintext, err := k.keys.Decrypt(ctx, purpose, envelope, aad)
+	if err := k.record(ctx, KeyDecrypt, purpose, envelope.KeyVersion, err); err != nil {
+		return nil, err
+	}
+
+	return plaintext, nil
+}
+
+func (k *AuditedKeys) Rewrap(ctx context.Context, purpose string, envelope *domain.Envelope) (*domain.Envelope, error) {
+	rewrapped, err := k.keys.Rewrap(ctx, purpose, envelope)
+
+	// The version recorded is the one the data key was wrapped by.
+	return rewrapped, k.record(ctx, KeyRewrap, purpose, envelope.KeyVersion, err)
+}
+
+func (k *AuditedKeys) CurrentVersion() int {
+	return k.keys.CurrentVersion()
+}
+
+// Rotate adds a key version, when the key manager can.
+func (k *AuditedKeys) Rotate(ctx context.Context) (int, error) {
+	rotator, ok := k.keys.(ports.KeyRotator)
+	if !ok {
+		return 0, errors.NewErrorf(errors.NotImplemented, "the key manager cannot rotate keys")
+	}
+
+	version, err := rotator.Rotate(ctx)
+	return version, k.record(ctx, KeyRotate, "", version, err)
+}
+
+// record audits an operation and returns its error, or the error auditing it.
+func (k *AuditedKeys) record(ctx context.Context, operation string, purpose string, version int, opErr error) error {
+	caller := domain.CallerFromContext(ctx)
+
+	event := &domain.AuditEvent{
+		Timestamp:    time.Now().UTC(),
+		AppID:        caller.AppID,
+		Peer:         caller.Peer,
+		Method:       caller.Method,
+		Outcome:      OutcomeCompleted,
+		KeyOperation: operation,
+		KeyPurpose:   purpose,
+		KeyVersion:   version,
+	}
+	if opErr != nil {
+		event.Outcome = OutcomeError
+	}
+
+	if err := k.audit.Record(ctx, event); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to record audit event")
+	}
+
+	return opErr
+}
+
+// RewrapEnvelopes rewraps the data keys of the envelopes in store still wrapped by an earlier
+// key version than the current one, batchSize at a time, and returns how many it rewrapped.
+// The data stays as it is, and records can keep being read and written meanwhile.
+func RewrapEnvelopes(ctx context.Context, keys ports.KeyManager, purpose string, store ports.EnvelopeStore, batchSize int) (int, error) {
+	version := keys.CurrentVersion()
+	rewrapped := 0
+
+	for {
+		envelopes, err := store.StaleEnvelopes(ctx, version, batchSize)
+		if err != nil {
+			return rewrapped, err
+		}
+		if len(envelopes) == 0 {
+			return rewrapped, nil
+		}
+
+		for id, envelope := range envelopes {
+			updated, err := keys.Rewrap(ctx, purpose, envelope)
+			if err != nil {
+				return rewrapped, err
+			}
+
+			if err := store.ReplaceEnvelope(ctx, id, envelope, updated); err != nil {
+				return rewrapped, err
+			}
+			rewrapped++
+		}
+	}
+}
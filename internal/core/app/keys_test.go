@@ -0,0 +1,173 @@
+package app
+
+import (
+	"bytes"
+	"cards-service/internal/core/domain"
+	"context"
+	"testing"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKeys "encrypts" by prefixing the data with its aad, and "wraps" by recording the key
+// version and purpose.
+type fakeKeys struct {
+	version int
+}
+
+func (k *fakeKeys) Encrypt(ctx context.Context, purpose string, plaintext []byte, aad []byte) (*domain.Envelope, error) {
+	return &domain.Envelope{KeyVersion: k.version, WrappedKey: []byte(purpose), Ciphertext: append(append([]byte{}, aad...), plaintext...)}, nil
+}
+
+func (k *fakeKeys) Decrypt(ctx context.Context, purpose string, envelope *domain.Envelope, aad []byte) ([]byte, error) {
+	if string(envelope.WrappedKey) != purpose || !bytes.HasPrefix(envelope.Ciphertext, aad) {
+		return nil, errors.NewErrorf(errors.InvalidArgument, "failed to decrypt")
+	}
+
+	return envelope.Ciphertext[len(aad):], nil
+}
+
+func (k *fakeKeys) Rewrap(ctx context.Context, purpose string, envelope *domain.Envelope) (*domain.Envelope, error) {
+	rewrapped := *envelope
+	rewrapped.KeyVersion = k.version
+
+	return &rewrapped, nil
+}
+
+func (k *fakeKeys) CurrentVersion() int {
+	return k.version
+}
+
+type rotatingKeys struct {
+	fakeKeys
+}
+
+func (k *rotatingKeys) Rotate(ctx context.Context) (int, error) {
+	k.version++
+	return k.version, nil
+}
+
+// fakeEnvelopeStore holds envelopes by ID.
+type fakeEnvelopeStore map[string]*domain.Envelope
+
+func (s fakeEnvelopeStore) StaleEnvelopes(ctx context.Context, version int, limit int) (map[string]*domain.Envelope, error) {
+	stale := make(map[string]*domain.Envelope)
+	for id, envelope := range s {
+		if envelope.KeyVersion < version && len(stale) < limit {
+			stale[id] = envelope
+		}
+	}
+
+	return stale, nil
+}
+
+func (s fakeEnvelopeStore) ReplaceEnvelope(ctx context.Context, id string, old *domain.Envelope, rewrapped *domain.Envelope) error {
+	if s[id] == old {
+		s[id] = rewrapped
+	}
+
+	return nil
+}
+
+func TestAuditedKeys(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "checkout", Method: "/cards.v1.WalletService/AddCard"})
+
+	t.Run("Records Every Use", func(t *testing.T) {
+		audit := &recordingAudit{}
+		keys := NewAuditedKeys(&fakeKeys{version: 2}, audit)
+
+		envelope, err := keys.Encrypt(ctx, domain.KeyPurposeWalletPAN, []byte("4111111111111111"), []byte("a"))
+		require.NoError(t, err)
+		_, err = keys.Decrypt(ctx, domain.KeyPurposeWalletPAN, envelope, []byte("b"))
+		require.Error(t, err)
+		_, err = keys.Rewrap(ctx, domain.KeyPurposeWalletPAN, envelope)
+		require.NoError(t, err)
+
+		require.Len(t, audit.events, 3)
+		assert.Equal(t, KeyEncrypt, audit.events[0].KeyOperation)
+		assert.Equal(t, domain.KeyPurposeWalletPAN, audit.events[0].KeyPurpose)
+		assert.Equal(t, 2, audit.events[0].KeyVersion)
+		assert.Equal(t, "checkout", audit.events[0].AppID)
+		assert.Equal(t, "/cards.v1.WalletService/AddCard", audit.events[0].Method)
+		assert.Equal(t, OutcomeCompleted, audit.events[0].Outcome)
+		assert.Empty(t, audit.events[0].MaskedPAN)
+		assert.Equal(t, KeyDecrypt, audit.events[1].KeyOperation)
+		assert.Equal(t, OutcomeError, audit.events[1].Outcome)
+		assert.Equal(t, KeyRewrap, audit.events[2].KeyOperation)
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		audit := &recordingAudit{}
+		keys := NewAuditedKeys(&fakeKeys{version: 1}, audit)
+
+		_, err := keys.Rotate(ctx)
+		require.Error(t, err, "fakeKeys cannot rotate")
+		assert.Equal(t, errors.NotImplemented, err.(*errors.Error).ErrCode)
+		assert.Empty(t, audit.events)
+
+		keys = NewAuditedKeys(&rotatingKeys{fakeKeys{version: 1}}, audit)
+		version, err := keys.Rotate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, version)
+		assert.Equal(t, 2, keys.CurrentVersion())
+		require.Len(t, audit.events, 1)
+		assert.Equal(t, KeyRotate, audit.events[0].KeyOperation)
+		assert.Equal(t, 2, audit.events[0].KeyVersion)
+	})
+
+	t.Run("Fails When It Cannot Audit", func(t *testing.T) {
+		keys := NewAuditedKeys(&fakeKeys{version: 1}, &recordingAudit{err: assert.AnError})
+
+		_, err := keys.Encrypt(ctx, domain.KeyPurposeWalletPAN, []byte("4111111111111111"), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestRewrapEnvelopes(t *testing.T) {
+	ctx := context.Background()
+	keys := &fakeKeys{version: 3}
+	store := fakeEnvelopeStore{
+		"a": {KeyVersion: 1, Ciphertext: []byte("a")},
+		"b": {KeyVersion: 2, Ciphertext: []byte("b")},
+		"c": {KeyVersion: 3, Ciphertext: []byte("c")},
+	}
+
+	n, err := RewrapEnvelopes(ctx, keys, domain.KeyPurposeWalletPAN, store, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	for id, envelope := range store {
+		assert.Equal(t, 3, envelope.KeyVersion, id)
+		assert.Equal(t, []byte(id), envelope.Ciphertext, "the data is left as it is")
+	}
+}
+
+func TestWalletPANEncryption(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "checkout"})
+	repo := &fakeWalletRepository{}
+	wallet := newTestWallet(repo)
+
+	card, err := wallet.AddCard(ctx, "cus-1", "4111111111111111", 12, 2030)
+	require.NoError(t, err)
+	assert.Nil(t, card.PANEnvelope)
+	_, err = wallet.CardNumber(ctx, card)
+	require.Error(t, err, "card numbers are not kept by default")
+
+	WithPANEncryption(&fakeKeys{version: 1})(wallet)
+
+	card, err = wallet.AddCard(ctx, "cus-1", "5555555555554444", 12, 2030)
+	require.NoError(t, err)
+	require.NotNil(t, card.PANEnvelope)
+
+	saved, err := wallet.GetCard(ctx, "cus-1", card.Token)
+	require.NoError(t, err)
+	pan, err := wallet.CardNumber(ctx, saved)
+	require.NoError(t, err)
+	assert.Equal(t, "5555555555554444", pan)
+
+	saved.Token = "another"
+	_, err = wallet.CardNumber(ctx, saved)
+	require.Error(t, err, "envelopes are bound to their card")
+}
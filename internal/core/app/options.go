@@ -0,0 +1,105 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"strings"
+)
+
+type Option func(*Service)
+
+func WithMetrics(metrics ports.ValidationMetrics) Option {
+	return func(svc *Service) {
+		svc.metrics = metrics
+	}
+}
+
+// WithAudit records every validation request. Cards are identified by a fingerprint keyed with
+// the key given to WithFingerprintKey.
+func WithAudit(sink ports.AuditSink) Option {
+	return func(svc *Service) {
+		svc.audit = sink
+	}
+}
+
+func WithFingerprintKey(key []byte) Option {
+	return func(svc *Service) {
+		svc.fingerprintKey = key
+	}
+}
+
+// WithBinLookup resolves card issuers through lookup instead of domain.DefaultBins.
+func WithBinLookup(lookup ports.BinLookup) Option {
+	return func(svc *Service) {
+		svc.bins = lookup
+	}
+}
+
+// WithBinRules blocks the BIN ranges the rules block and accepts the ones they allow, even
+// when they are not in the BIN data.
+func WithBinRules(rules *BinRules) Option {
+	return func(svc *Service) {
+		svc.binRules = rules
+	}
+}
+
+// WithVelocity counts validations per card, BIN, app and peer in store and raises a risk signal
+// for every limit exceeded. When reject is set, such validations are refused instead.
+func WithVelocity(store ports.VelocityStore, limits domain.VelocityLimits, reject bool) Option {
+	return func(svc *Service) {
+		svc.velocity = store
+		svc.velocityLimits = limits
+		svc.velocityReject = reject
+	}
+}
+
+// WithPolicies restricts the cards each app accepts. See Service.SetPolicies.
+func WithPolicies(policies map[string]domain.AppPolicy) Option {
+	return func(svc *Service) {
+		svc.SetPolicies(policies)
+	}
+}
+
+// WithCardRules requires the cards each app accepts to satisfy its custom rules. See
+// Service.SetCardRules.
+func WithCardRules(rules map[string][]ports.CardRule) Option {
+	return func(svc *Service) {
+		svc.SetCardRules(rules)
+	}
+}
+
+// WithEvents publishes the outcome of every validation.
+func WithEvents(publisher ports.EventPublisher) Option {
+	return func(svc *Service) {
+		svc.events = publisher
+	}
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ValidationCompleted(string, string, string) {}
+
+func (noopMetrics) BinLookup(bool) {}
+
+func (noopMetrics) RiskSignal(string) {}
+
+func (noopMetrics) ValidationBatch(int) {}
+
+type noopAudit struct{}
+
+func (noopAudit) Record(context.Context, *domain.AuditEvent) error { return nil }
+
+func (noopAudit) Close() error { return nil }
+
+type defaultBins struct{}
+
+func (defaultBins) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	for _, info := range domain.DefaultBins {
+		if strings.HasPrefix(bin, info.Prefix) {
+			return &info, nil
+		}
+	}
+
+	return nil, domain.UnknownBinError(bin)
+}
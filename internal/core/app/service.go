@@ -2,32 +2,430 @@ package app
 
 import (
 	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/mwinyimoha/commons/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	OutcomeAccepted = "accepted"
+	OutcomeRejected = "rejected"
+	// OutcomeDeclined is a valid card the policy of the calling app does not accept.
+	OutcomeDeclined = "declined"
+	OutcomeError    = "error"
+
+	unknownNetwork = "UNKNOWN"
+)
+
+// tracer spans never carry the card number, only facts derived from it.
+var tracer = otel.Tracer("cards-service/internal/core/app")
+
 type Service struct {
-	validation *validator.Validate
+	validation     *validator.Validate
+	metrics        ports.ValidationMetrics
+	audit          ports.AuditSink
+	bins           ports.BinLookup
+	fingerprintKey []byte
+	events         ports.EventPublisher
+
+	binRules       *BinRules
+	policies       atomic.Pointer[map[string]domain.AppPolicy]
+	cardRules      atomic.Pointer[map[string][]ports.CardRule]
+	velocity       ports.VelocityStore
+	velocityLimits domain.VelocityLimits
+	velocityReject bool
 }
 
-func NewService(val *validator.Validate) *Service {
+func NewService(val *validator.Validate, opts ...Option) *Service {
 	val.RegisterValidation("valid_card_number", validateCardNumber)
 
-	return &Service{validation: val}
+	svc := &Service{validation: val, metrics: noopMetrics{}, audit: noopAudit{}, bins: defaultBins{}}
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+// result is the outcome of a validation along with what is reported to metrics, traces and audit.
+type result struct {
+	cardInfo *domain.CardInfo
+	network  string
+	outcome  string
+	reason   string
+	rule     string
+	signals  []string
+	err      error
+}
+
+func (svc *Service) ValidateCardNumber(ctx context.Context, cardNumber string) (*domain.CardInfo, error) {
+	ctx, span := tracer.Start(ctx, "Service.ValidateCardNumber", trace.WithAttributes(
+		attribute.Int("card.length", len(cardNumber)),
+	))
+	defer span.End()
+
+	res := svc.validate(ctx, cardNumber)
+
+	svc.metrics.ValidationCompleted(res.network, res.outcome, res.reason)
+	span.SetAttributes(
+		attribute.String("card.network", res.network),
+		attribute.String("validation.outcome", res.outcome),
+		attribute.String("validation.reason", res.reason),
+	)
+
+	if err := svc.recordAudit(ctx, cardNumber, res); err != nil {
+		span.SetStatus(codes.Error, "audit failed")
+		return nil, err
+	}
+
+	svc.publishEvent(ctx, cardNumber, res)
+
+	if res.err != nil {
+		span.SetStatus(codes.Error, "card number "+res.outcome)
+		return nil, res.err
+	}
+
+	return res.cardInfo, nil
+}
+
+func (svc *Service) validate(ctx context.Context, cardNumber string) result {
+	if err := ctx.Err(); err != nil {
+		return result{network: unknownNetwork, outcome: OutcomeError, err: contextError(err)}
+	}
+
+	// Every attempt counts, well-formed or not: enumeration mostly sends numbers that fail the
+	// format checks below.
+	signals := svc.checkVelocity(ctx, cardNumber)
+	if len(signals) > 0 && svc.velocityReject {
+		err := errors.NewErrorf(errors.QuotaExceeded, "too many validation attempts: %s", strings.Join(signals, ", "))
+		return result{network: unknownNetwork, outcome: OutcomeRejected, reason: RejectionVelocityExceeded, signals: signals, err: err}
+	}
+
+	rule := svc.matchBinRule(cardNumber)
+
+	if err := svc.validateFormat(ctx, cardNumber); err != nil {
+		appErr, ok := err.(*errors.Error)
+		if !ok || appErr.ErrCode != errors.InvalidArgument {
+			return result{network: unknownNetwork, outcome: OutcomeError, signals: signals, err: err}
+		}
+
+		if reason := formatRejection(rule, cardNumber); reason != "" {
+			return result{network: unknownNetwork, outcome: OutcomeRejected, reason: reason, signals: signals, err: err}
+		}
+	}
+
+	if rule != nil && rule.Action == domain.BinRuleBlock {
+		err := errors.NewErrorf(errors.InvalidArgument, "card range is not accepted")
+		return result{network: unknownNetwork, outcome: OutcomeRejected, reason: RejectionBinBlocked, signals: signals, err: err}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result{network: unknownNetwork, outcome: OutcomeError, signals: signals, err: contextError(err)}
+	}
+
+	cardInfo, err := svc.lookupNetwork(ctx, cardNumber)
+	if err != nil {
+		appErr, ok := err.(*errors.Error)
+		switch {
+		case !ok || appErr.ErrCode != errors.InvalidArgument:
+			return result{network: unknownNetwork, outcome: OutcomeError, signals: signals, err: err}
+		case rule == nil || rule.Action != domain.BinRuleAllow:
+			return result{network: unknownNetwork, outcome: OutcomeRejected, reason: RejectionUnsupportedPrefix, signals: signals, err: err}
+		}
+
+		cardInfo = rule.CardInfo(cardNumber)
+	}
+	cardInfo.RiskSignals = signals
+
+	if reason, rule, reject := svc.checkPolicy(ctx, cardNumber, cardInfo); reason != "" {
+		if reject {
+			err := errors.NewErrorf(errors.PreconditionFailed, "card is not accepted: %s", policyDetail(reason, rule))
+			return result{network: cardInfo.CardProvider, outcome: OutcomeRejected, reason: reason, rule: rule, signals: signals, err: err}
+		}
+
+		cardInfo.PolicyReason, cardInfo.PolicyRule = reason, rule
+		return result{cardInfo: cardInfo, network: cardInfo.CardProvider, outcome: OutcomeDeclined, reason: reason, rule: rule, signals: signals}
+	}
+
+	return result{cardInfo: cardInfo, network: cardInfo.CardProvider, outcome: OutcomeAccepted, signals: signals}
+}
+
+// SetPolicies replaces the per-app policies, keyed by app ID. Apps without one accept every
+// valid card.
+func (svc *Service) SetPolicies(policies map[string]domain.AppPolicy) {
+	svc.policies.Store(&policies)
+}
+
+// SetCardRules replaces the compiled custom rules of each app, keyed by app ID.
+func (svc *Service) SetCardRules(rules map[string][]ports.CardRule) {
+	svc.cardRules.Store(&rules)
+}
+
+// checkPolicy returns why the policy of the calling app does not accept the card, along with
+// the custom rule it failed, if any, and whether the card is to be refused.
+func (svc *Service) checkPolicy(ctx context.Context, cardNumber string, cardInfo *domain.CardInfo) (reason string, rule string, reject bool) {
+	appID := domain.CallerFromContext(ctx).AppID
+
+	var policy domain.AppPolicy
+	if policies := svc.policies.Load(); policies != nil {
+		policy = (*policies)[appID]
+	}
+
+	if reason := policy.Check(cardInfo); reason != "" {
+		return reason, "", policy.Rejects()
+	}
+
+	if rules := svc.cardRules.Load(); rules != nil && len((*rules)[appID]) > 0 {
+		if rule := svc.evaluateCardRules(ctx, (*rules)[appID], domain.NewCardFacts(cardNumber, cardInfo)); rule != "" {
+			return domain.ReasonRuleNotSatisfied, rule, policy.Rejects()
+		}
+	}
+
+	return "", "", false
+}
+
+// evaluateCardRules returns the name of the first rule the card fails. Rules that cannot be
+// evaluated, for instance because they exceed the cost limit, count as failed.
+func (svc *Service) evaluateCardRules(ctx context.Context, rules []ports.CardRule, facts domain.CardFacts) string {
+	ctx, span := tracer.Start(ctx, "Service.evaluateCardRules", trace.WithAttributes(
+		attribute.Int("rules.count", len(rules)),
+	))
+	defer span.End()
+
+	for _, rule := range rules {
+		satisfied, err := rule.Evaluate(ctx, facts)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "rule evaluation failed")
+		}
+
+		if !satisfied {
+			span.SetAttributes(attribute.String("rules.failed", rule.Name()))
+			return rule.Name()
+		}
+	}
+
+	return ""
+}
+
+func policyDetail(reason string, rule string) string {
+	if rule == "" {
+		return reason
+	}
+
+	return reason + " (" + rule + ")"
 }
 
-func (svc *Service) ValidateCardNumber(cardNumber string) (*domain.CardInfo, error) {
+// contextError reports work abandoned because the request expired or its caller went away.
+// There is no error code for cancellation, so context.Canceled is returned as it is, which
+// gRPC reports as Canceled.
+func contextError(err error) error {
+	if err == context.DeadlineExceeded {
+		return errors.WrapError(err, errors.DeadlineExceeded, "request deadline exceeded")
+	}
+
+	return err
+}
+
+func (svc *Service) validateFormat(ctx context.Context, cardNumber string) error {
+	_, span := tracer.Start(ctx, "Service.validateFormat")
+	defer span.End()
+
 	payload := &domain.CardNumberPayload{CardNumber: cardNumber}
 
 	if err := svc.validation.Struct(payload); err != nil {
 		if verr, ok := err.(validator.ValidationErrors); ok {
 			violations := errors.BuildViolations(verr)
-			return nil, errors.NewValidationError(violations)
+			return errors.NewValidationError(violations)
+		}
+
+		span.SetStatus(codes.Error, "validation failed")
+		return errors.WrapError(err, errors.Internal, "validation failed")
+	}
+
+	return nil
+}
+
+func (svc *Service) lookupNetwork(ctx context.Context, cardNumber string) (*domain.CardInfo, error) {
+	_, span := tracer.Start(ctx, "Service.lookupNetwork")
+	defer span.End()
+
+	bin, err := svc.bins.Lookup(ctx, domain.BIN(cardNumber))
+	svc.metrics.BinLookup(err == nil)
+	span.SetAttributes(attribute.Bool("bin.hit", err == nil))
+
+	if err != nil {
+		appErr, ok := err.(*errors.Error)
+		if ok && appErr.ErrCode == errors.NotFound {
+			return nil, errors.NewErrorf(errors.InvalidArgument, "unknown card provider")
+		}
+
+		span.SetStatus(codes.Error, "BIN lookup failed")
+		if ok {
+			return nil, err
 		}
+		return nil, errors.WrapError(err, errors.Internal, "BIN lookup failed")
+	}
+
+	return bin.CardInfo(cardNumber), nil
+}
+
+func (svc *Service) matchBinRule(cardNumber string) *domain.BinRule {
+	if svc.binRules == nil {
+		return nil
+	}
+
+	return svc.binRules.Match(domain.BIN(cardNumber))
+}
+
+// formatRejection explains why a card number is invalid. Cards in a range an allow rule
+// admits need not belong to a supported network, but must still be well formed.
+func formatRejection(rule *domain.BinRule, cardNumber string) string {
+	reason := cardNumberRejection(cardNumber)
+	if reason == RejectionUnsupportedPrefix && rule != nil && rule.Action == domain.BinRuleAllow {
+		return unlistedRangeRejection(cardNumber)
+	}
+
+	return reason
+}
+
+// checkVelocity counts the validation against every velocity limit and returns the signals of
+// those exceeded. Checks fail open: an unavailable store does not stop validations.
+func (svc *Service) checkVelocity(ctx context.Context, cardNumber string) []string {
+	if svc.velocity == nil {
+		return nil
+	}
+
+	ctx, span := tracer.Start(ctx, "Service.checkVelocity")
+	defer span.End()
+
+	caller := domain.CallerFromContext(ctx)
+	checks := []struct {
+		dimension string
+		key       string
+		limit     domain.VelocityLimit
+		signal    string
+	}{
+		{domain.VelocityCard, domain.Fingerprint(svc.fingerprintKey, cardNumber), svc.velocityLimits.Card, domain.SignalCardVelocity},
+		{domain.VelocityBIN, domain.MaskBIN(domain.BIN(cardNumber)), svc.velocityLimits.BIN, domain.SignalBINVelocity},
+		{domain.VelocityApp, caller.AppID, svc.velocityLimits.App, domain.SignalAppVelocity},
+		{domain.VelocityPeer, caller.Peer, svc.velocityLimits.Peer, domain.SignalPeerVelocity},
+	}
+
+	now := time.Now()
+	var signals []string
+
+	for _, check := range checks {
+		if check.limit.Max <= 0 || check.key == "" {
+			continue
+		}
+
+		count, err := svc.velocity.Add(ctx, check.dimension+":"+check.key, now, check.limit.Window)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "velocity store failed")
+			continue
+		}
+
+		if count > check.limit.Max {
+			signals = append(signals, check.signal)
+			svc.metrics.RiskSignal(check.signal)
+		}
+	}
+
+	span.SetAttributes(attribute.StringSlice("risk.signals", signals))
+
+	return signals
+}
+
+func (svc *Service) recordAudit(ctx context.Context, cardNumber string, res result) error {
+	caller := domain.CallerFromContext(ctx)
+
+	event := &domain.AuditEvent{
+		Timestamp:   time.Now().UTC(),
+		AppID:       caller.AppID,
+		Peer:        caller.Peer,
+		Method:      caller.Method,
+		MaskedPAN:   domain.MaskPAN(cardNumber),
+		Fingerprint: domain.Fingerprint(svc.fingerprintKey, cardNumber),
+		Network:     res.network,
+		Outcome:     res.outcome,
+		Reason:      res.reason,
+		RiskSignals: res.signals,
+	}
+
+	// Validations that cannot be audited are refused so the trail stays complete.
+	if err := svc.audit.Record(ctx, event); err != nil {
+		return errors.WrapError(err, errors.Internal, "failed to record audit event")
+	}
+
+	return nil
+}
+
+// publishEvent announces the validation downstream. Events are best effort: validations go
+// ahead when they cannot be published, unlike when they cannot be audited.
+func (svc *Service) publishEvent(ctx context.Context, cardNumber string, res result) {
+	if svc.events == nil {
+		return
+	}
+
+	ctx, span := tracer.Start(ctx, "Service.publishEvent")
+	defer span.End()
+
+	data := domain.CardValidated{
+		MaskedPAN:   domain.MaskPAN(cardNumber),
+		Network:     res.network,
+		Outcome:     res.outcome,
+		Reason:      res.reason,
+		PolicyRule:  res.rule,
+		RiskSignals: res.signals,
+	}
+	if len(svc.fingerprintKey) > 0 {
+		data.Fingerprint = domain.Fingerprint(svc.fingerprintKey, cardNumber)
+	}
+	if res.cardInfo != nil && res.cardInfo.Bin != nil {
+		data.BinSource = res.cardInfo.Bin.Source
+	}
+
+	err := svc.publish(ctx, domain.EventCardValidated, domain.CallerFromContext(ctx).AppID, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "event not published")
+	}
+}
+
+func (svc *Service) publish(ctx context.Context, eventType string, appID string, data any) error {
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	event, err := domain.NewEvent(id, eventType, time.Now().UTC(), appID, data)
+	if err != nil {
+		return err
+	}
+
+	// The outcome is final by now, so events are kept even if the caller has gone away.
+	return svc.events.Publish(context.WithoutCancel(ctx), event)
+}
 
-		return nil, errors.WrapError(err, errors.Internal, "validation failed")
+// newID returns a random identifier for rules and events.
+func newID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", errors.WrapError(err, errors.Internal, "failed to generate ID")
 	}
 
-	return domain.NewCardInfo(cardNumber)
+	return hex.EncodeToString(id), nil
 }
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAudit struct {
+	events []*domain.AuditEvent
+	err    error
+}
+
+func (a *recordingAudit) Record(_ context.Context, event *domain.AuditEvent) error {
+	if a.err != nil {
+		return a.err
+	}
+
+	a.events = append(a.events, event)
+	return nil
+}
+
+func (a *recordingAudit) Close() error { return nil }
+
+func TestServiceAudit(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{
+		AppID:  "checkout",
+		Peer:   "10.0.0.1",
+		Method: "/cards.CardsService/ValidateCardNumber",
+	})
+
+	t.Run("Accepted Card", func(t *testing.T) {
+		audit := &recordingAudit{}
+		svc := NewService(validator.New(), WithAudit(audit), WithFingerprintKey(key))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		require.Len(t, audit.events, 1)
+
+		event := audit.events[0]
+		assert.Equal(t, "checkout", event.AppID)
+		assert.Equal(t, "10.0.0.1", event.Peer)
+		assert.Equal(t, "/cards.CardsService/ValidateCardNumber", event.Method)
+		assert.Equal(t, "411111******1111", event.MaskedPAN)
+		assert.Equal(t, domain.Fingerprint(key, "4111111111111111"), event.Fingerprint)
+		assert.Equal(t, "VISA", event.Network)
+		assert.Equal(t, OutcomeAccepted, event.Outcome)
+		assert.Empty(t, event.Reason)
+		assert.False(t, event.Timestamp.IsZero())
+	})
+
+	t.Run("Rejected Card", func(t *testing.T) {
+		audit := &recordingAudit{}
+		svc := NewService(validator.New(), WithAudit(audit), WithFingerprintKey(key))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111112")
+		require.Error(t, err)
+		require.Len(t, audit.events, 1)
+
+		assert.Equal(t, OutcomeRejected, audit.events[0].Outcome)
+		assert.Equal(t, RejectionLuhnCheckFailed, audit.events[0].Reason)
+		assert.Equal(t, "411111******1112", audit.events[0].MaskedPAN)
+	})
+
+	t.Run("Fails Closed When Audit Is Unavailable", func(t *testing.T) {
+		audit := &recordingAudit{err: stderrors.New("disk full")}
+		svc := NewService(validator.New(), WithAudit(audit), WithFingerprintKey(key))
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		assert.Nil(t, info)
+		require.Error(t, err)
+
+		appErr, ok := err.(*errors.Error)
+		require.True(t, ok)
+		assert.Equal(t, errors.Internal, appErr.ErrCode)
+	})
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	events []*domain.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestServiceEvents(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "checkout"})
+
+	t.Run("Publishes Outcomes", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		svc := NewService(validator.New(), WithEvents(publisher))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		_, err = svc.ValidateCardNumber(ctx, "4111111111111112")
+		require.Error(t, err)
+
+		require.Len(t, publisher.events, 2)
+		event := publisher.events[0]
+		assert.Len(t, event.ID, 32)
+		assert.Equal(t, domain.EventCardValidated, event.Type)
+		assert.Equal(t, "checkout", event.AppID)
+		assert.NotEqual(t, event.ID, publisher.events[1].ID)
+
+		var data domain.CardValidated
+		require.NoError(t, json.Unmarshal(publisher.events[1].Data, &data))
+		assert.Equal(t, domain.CardValidated{
+			MaskedPAN: "411111******1112",
+			Network:   unknownNetwork,
+			Outcome:   OutcomeRejected,
+			Reason:    RejectionLuhnCheckFailed,
+		}, data)
+	})
+
+	t.Run("Events Never Carry The Card Number", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		svc := NewService(validator.New(), WithEvents(publisher), WithFingerprintKey([]byte("0123456789abcdef0123456789abcdef")))
+
+		_, err := svc.ValidateCardNumber(ctx, "4012888888881881")
+		require.NoError(t, err)
+
+		encoded, err := json.Marshal(publisher.events[0])
+		require.NoError(t, err)
+		assert.False(t, strings.Contains(string(encoded), "4012888888881881"))
+		assert.Contains(t, string(encoded), `"fingerprint"`)
+	})
+
+	t.Run("Carries The Failed Card Rule", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		rule := &fakeCardRule{name: "no-test-cards", satisfied: func(facts domain.CardFacts) bool { return !facts.Test }}
+		svc := NewService(validator.New(), WithEvents(publisher), WithCardRules(map[string][]ports.CardRule{"checkout": {rule}}))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+
+		var data domain.CardValidated
+		require.NoError(t, json.Unmarshal(publisher.events[0].Data, &data))
+		assert.Equal(t, OutcomeDeclined, data.Outcome)
+		assert.Equal(t, domain.ReasonRuleNotSatisfied, data.Reason)
+		assert.Equal(t, "no-test-cards", data.PolicyRule)
+	})
+
+	t.Run("Publishing Failures Do Not Fail Validations", func(t *testing.T) {
+		svc := NewService(validator.New(), WithEvents(&recordingPublisher{err: assert.AnError}))
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		assert.Equal(t, "VISA", info.CardProvider)
+	})
+}
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/mwinyimoha/commons/pkg/errors"
@@ -24,7 +28,7 @@ func TestService(t *testing.T) {
 
 		for _, card := range tests {
 			t.Run(card, func(t *testing.T) {
-				info, err := svc.ValidateCardNumber(card)
+				info, err := svc.ValidateCardNumber(context.Background(), card)
 				require.NoError(t, err)
 				require.NotNil(t, info)
 				assert.Equal(t, card, info.CardNumber)
@@ -45,7 +49,7 @@ func TestService(t *testing.T) {
 
 		for _, card := range invalidCards {
 			t.Run(card, func(t *testing.T) {
-				info, err := svc.ValidateCardNumber(card)
+				info, err := svc.ValidateCardNumber(context.Background(), card)
 				require.Error(t, err)
 				assert.Nil(t, info)
 
@@ -55,3 +59,372 @@ func TestService(t *testing.T) {
 		}
 	})
 }
+
+type validation struct {
+	network string
+	outcome string
+	reason  string
+}
+
+type fakeMetrics struct {
+	validations []validation
+	binHits     int
+	signals     []string
+}
+
+func (m *fakeMetrics) ValidationCompleted(network string, outcome string, reason string) {
+	m.validations = append(m.validations, validation{network, outcome, reason})
+}
+
+func (m *fakeMetrics) BinLookup(hit bool) {
+	if hit {
+		m.binHits++
+	}
+}
+
+func (m *fakeMetrics) RiskSignal(signal string) {
+	m.signals = append(m.signals, signal)
+}
+
+func (m *fakeMetrics) ValidationBatch(int) {}
+
+func TestServiceMetrics(t *testing.T) {
+	metrics := &fakeMetrics{}
+	svc := NewService(validator.New(), WithMetrics(metrics))
+
+	_, err := svc.ValidateCardNumber(context.Background(), "5555555555554444")
+	require.NoError(t, err)
+
+	rejected := map[string]string{
+		"":                 RejectionMissing,
+		"9111111111111111": RejectionUnsupportedPrefix,
+		"4111111111111":    RejectionInvalidLength,
+		"6011abcd11111117": RejectionInvalidCharacters,
+		"4111111111111112": RejectionLuhnCheckFailed,
+	}
+
+	for card, reason := range rejected {
+		_, err := svc.ValidateCardNumber(context.Background(), card)
+		require.Error(t, err)
+		assert.Contains(t, metrics.validations, validation{unknownNetwork, OutcomeRejected, reason})
+	}
+
+	assert.Equal(t, validation{"MASTERCARD", OutcomeAccepted, ""}, metrics.validations[0])
+	assert.Equal(t, 1, metrics.binHits)
+}
+
+func TestServiceContext(t *testing.T) {
+	svc := NewService(validator.New())
+
+	t.Run("Deadline Exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		assert.Nil(t, info)
+		require.Error(t, err)
+
+		appErr, ok := err.(*errors.Error)
+		require.True(t, ok)
+		assert.Equal(t, errors.DeadlineExceeded, appErr.ErrCode)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		assert.Nil(t, info)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+type fakeBinLookup struct {
+	bins map[string]*domain.BinInfo
+	err  error
+}
+
+func (l *fakeBinLookup) Lookup(ctx context.Context, bin string) (*domain.BinInfo, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	for n := len(bin); n > 0; n-- {
+		if info, ok := l.bins[bin[:n]]; ok {
+			return info, nil
+		}
+	}
+
+	return nil, domain.UnknownBinError(bin)
+}
+
+func TestServiceBinLookup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Resolves Network From Lookup", func(t *testing.T) {
+		lookup := &fakeBinLookup{bins: map[string]*domain.BinInfo{"411111": {Prefix: "411111", Network: "ELECTRON"}}}
+		svc := NewService(validator.New(), WithBinLookup(lookup))
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		assert.Equal(t, "ELECTRON", info.CardProvider)
+		assert.Equal(t, "https://dummy.com/card-provider-icons/electron.png", info.ProviderBadge)
+	})
+
+	t.Run("Unknown BIN Is Rejected", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithBinLookup(&fakeBinLookup{}), WithMetrics(metrics))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.Error(t, err)
+		assert.Equal(t, errors.InvalidArgument, err.(*errors.Error).ErrCode)
+		assert.Equal(t, []validation{{unknownNetwork, OutcomeRejected, RejectionUnsupportedPrefix}}, metrics.validations)
+	})
+
+	t.Run("Lookup Failure Is An Error", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		lookup := &fakeBinLookup{err: errors.NewErrorf(errors.ServiceUnavailable, "provider down")}
+		svc := NewService(validator.New(), WithBinLookup(lookup), WithMetrics(metrics))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.Error(t, err)
+		assert.Equal(t, errors.ServiceUnavailable, err.(*errors.Error).ErrCode)
+		assert.Equal(t, []validation{{unknownNetwork, OutcomeError, ""}}, metrics.validations)
+	})
+}
+
+// countingVelocityStore counts every event, as if all of them fell within the window.
+type countingVelocityStore struct {
+	counts map[string]int64
+}
+
+func newCountingVelocityStore() *countingVelocityStore {
+	return &countingVelocityStore{counts: make(map[string]int64)}
+}
+
+func (s *countingVelocityStore) Add(ctx context.Context, key string, now time.Time, window time.Duration) (int64, error) {
+	s.counts[key]++
+	return s.counts[key], nil
+}
+
+type failingVelocityStore struct{}
+
+func (failingVelocityStore) Add(context.Context, string, time.Time, time.Duration) (int64, error) {
+	return 0, errors.NewErrorf(errors.ServiceUnavailable, "store down")
+}
+
+func TestServiceVelocity(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "app-1", Peer: "10.0.0.1"})
+	limits := domain.VelocityLimits{
+		Card: domain.VelocityLimit{Max: 2, Window: time.Hour},
+		BIN:  domain.VelocityLimit{Max: 3, Window: time.Minute},
+	}
+
+	t.Run("Flags Repeated Attempts", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithVelocity(newCountingVelocityStore(), limits, false), WithMetrics(metrics))
+
+		for range 2 {
+			info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+			require.NoError(t, err)
+			assert.Empty(t, info.RiskSignals)
+		}
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		assert.Equal(t, []string{domain.SignalCardVelocity}, info.RiskSignals)
+
+		info, err = svc.ValidateCardNumber(ctx, "4111111111111129")
+		require.NoError(t, err)
+		assert.Equal(t, []string{domain.SignalBINVelocity}, info.RiskSignals, "another card from the same BIN")
+
+		assert.Equal(t, []string{domain.SignalCardVelocity, domain.SignalBINVelocity}, metrics.signals)
+	})
+
+	t.Run("Rejects Repeated Attempts", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithVelocity(newCountingVelocityStore(), limits, true), WithMetrics(metrics))
+
+		for range 2 {
+			_, err := svc.ValidateCardNumber(ctx, "5555555555554444")
+			require.NoError(t, err)
+		}
+
+		info, err := svc.ValidateCardNumber(ctx, "5555555555554444")
+		assert.Nil(t, info)
+		require.Error(t, err)
+		assert.Equal(t, errors.QuotaExceeded, err.(*errors.Error).ErrCode)
+		assert.Equal(t, validation{unknownNetwork, OutcomeRejected, RejectionVelocityExceeded}, metrics.validations[2])
+	})
+
+	t.Run("Caller Limits", func(t *testing.T) {
+		svc := NewService(validator.New(), WithVelocity(newCountingVelocityStore(), domain.VelocityLimits{
+			App:  domain.VelocityLimit{Max: 1, Window: time.Minute},
+			Peer: domain.VelocityLimit{Max: 1, Window: time.Minute},
+		}, false))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+
+		info, err := svc.ValidateCardNumber(ctx, "5555555555554444")
+		require.NoError(t, err)
+		assert.Equal(t, []string{domain.SignalAppVelocity, domain.SignalPeerVelocity}, info.RiskSignals)
+
+		other := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "app-2", Peer: "10.0.0.2"})
+		info, err = svc.ValidateCardNumber(other, "5555555555554444")
+		require.NoError(t, err)
+		assert.Empty(t, info.RiskSignals)
+	})
+
+	t.Run("Invalid Card Numbers Count", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithVelocity(newCountingVelocityStore(), domain.VelocityLimits{
+			App: domain.VelocityLimit{Max: 2, Window: time.Minute},
+		}, true), WithMetrics(metrics))
+
+		for _, cardNumber := range []string{"4111111111111112", "12ab"} {
+			_, err := svc.ValidateCardNumber(ctx, cardNumber)
+			require.Error(t, err)
+			assert.Equal(t, errors.InvalidArgument, err.(*errors.Error).ErrCode)
+		}
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111113")
+		require.Error(t, err)
+		assert.Equal(t, errors.QuotaExceeded, err.(*errors.Error).ErrCode)
+		assert.Equal(t, validation{unknownNetwork, OutcomeRejected, RejectionVelocityExceeded}, metrics.validations[2])
+	})
+
+	t.Run("Fails Open", func(t *testing.T) {
+		svc := NewService(validator.New(), WithVelocity(failingVelocityStore{}, limits, true))
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+	})
+}
+
+func TestServicePolicies(t *testing.T) {
+	policies := map[string]domain.AppPolicy{
+		"visa-only":    {Networks: []string{"VISA"}},
+		"visa-enforce": {Networks: []string{"VISA"}, Mode: domain.PolicyReject},
+	}
+	caller := func(appID string) context.Context {
+		return domain.ContextWithCaller(context.Background(), domain.Caller{AppID: appID})
+	}
+
+	t.Run("Accepted", func(t *testing.T) {
+		svc := NewService(validator.New(), WithPolicies(policies))
+
+		info, err := svc.ValidateCardNumber(caller("visa-only"), "4111111111111111")
+		require.NoError(t, err)
+		assert.Empty(t, info.PolicyReason)
+	})
+
+	t.Run("Reports Cards The Policy Excludes", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithPolicies(policies), WithMetrics(metrics))
+
+		info, err := svc.ValidateCardNumber(caller("visa-only"), "5555555555554444")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReasonNetworkNotAccepted, info.PolicyReason)
+		assert.Equal(t, []validation{{"MASTERCARD", OutcomeDeclined, domain.ReasonNetworkNotAccepted}}, metrics.validations)
+	})
+
+	t.Run("Rejects Cards The Policy Excludes", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithPolicies(policies), WithMetrics(metrics))
+
+		_, err := svc.ValidateCardNumber(caller("visa-enforce"), "5555555555554444")
+		require.Error(t, err)
+		assert.Equal(t, errors.PreconditionFailed, err.(*errors.Error).ErrCode)
+		assert.Equal(t, []validation{{"MASTERCARD", OutcomeRejected, domain.ReasonNetworkNotAccepted}}, metrics.validations)
+	})
+
+	t.Run("Apps Without A Policy Accept Every Card", func(t *testing.T) {
+		svc := NewService(validator.New(), WithPolicies(policies))
+
+		info, err := svc.ValidateCardNumber(caller("other"), "5555555555554444")
+		require.NoError(t, err)
+		assert.Empty(t, info.PolicyReason)
+	})
+
+	t.Run("Policies Can Be Replaced", func(t *testing.T) {
+		svc := NewService(validator.New(), WithPolicies(policies))
+		svc.SetPolicies(nil)
+
+		info, err := svc.ValidateCardNumber(caller("visa-only"), "5555555555554444")
+		require.NoError(t, err)
+		assert.Empty(t, info.PolicyReason)
+	})
+}
+
+type fakeCardRule struct {
+	name      string
+	satisfied func(domain.CardFacts) bool
+	err       error
+	facts     domain.CardFacts
+}
+
+func (r *fakeCardRule) Name() string { return r.name }
+
+func (r *fakeCardRule) Evaluate(ctx context.Context, facts domain.CardFacts) (bool, error) {
+	r.facts = facts
+	if r.err != nil {
+		return false, r.err
+	}
+	return r.satisfied(facts), nil
+}
+
+func TestServiceCardRules(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "merchant-a"})
+	noTestCards := &fakeCardRule{name: "no-test-cards", satisfied: func(facts domain.CardFacts) bool { return !facts.Test }}
+
+	t.Run("Passes Card Facts", func(t *testing.T) {
+		rule := &fakeCardRule{name: "any", satisfied: func(domain.CardFacts) bool { return true }}
+		svc := NewService(validator.New(), WithCardRules(map[string][]ports.CardRule{"merchant-a": {rule}}))
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		assert.Empty(t, info.PolicyReason)
+		assert.Equal(t, domain.CardFacts{Network: "VISA", BIN: "41111111", Length: 16, Test: true}, rule.facts)
+	})
+
+	t.Run("Reports Failed Rules", func(t *testing.T) {
+		metrics := &fakeMetrics{}
+		svc := NewService(validator.New(), WithCardRules(map[string][]ports.CardRule{"merchant-a": {noTestCards}}), WithMetrics(metrics))
+
+		info, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReasonRuleNotSatisfied, info.PolicyReason)
+		assert.Equal(t, "no-test-cards", info.PolicyRule)
+		assert.Equal(t, []validation{{"VISA", OutcomeDeclined, domain.ReasonRuleNotSatisfied}}, metrics.validations)
+	})
+
+	t.Run("Rejects Failed Rules In Reject Mode", func(t *testing.T) {
+		svc := NewService(validator.New(),
+			WithPolicies(map[string]domain.AppPolicy{"merchant-a": {Mode: domain.PolicyReject}}),
+			WithCardRules(map[string][]ports.CardRule{"merchant-a": {noTestCards}}),
+		)
+
+		_, err := svc.ValidateCardNumber(ctx, "4111111111111111")
+		require.Error(t, err)
+		assert.Equal(t, errors.PreconditionFailed, err.(*errors.Error).ErrCode)
+		assert.Contains(t, err.Error(), "no-test-cards")
+	})
+
+	t.Run("Rules That Cannot Be Evaluated Fail", func(t *testing.T) {
+		broken := &fakeCardRule{name: "too-expensive", err: assert.AnError}
+		svc := NewService(validator.New(), WithCardRules(map[string][]ports.CardRule{"merchant-a": {broken}}))
+
+		info, err := svc.ValidateCardNumber(ctx, "4012888888881881")
+		require.NoError(t, err)
+		assert.Equal(t, "too-expensive", info.PolicyRule)
+	})
+
+	t.Run("Other Apps Are Unaffected", func(t *testing.T) {
+		svc := NewService(validator.New(), WithCardRules(map[string][]ports.CardRule{"merchant-a": {noTestCards}}))
+
+		info, err := svc.ValidateCardNumber(context.Background(), "4111111111111111")
+		require.NoError(t, err)
+		assert.Empty(t, info.PolicyReason)
+	})
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestServiceTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	svc := NewService(validator.New())
+	cards := []string{"4111111111111111", "4111111111111112"}
+
+	for _, card := range cards {
+		_, _ = svc.ValidateCardNumber(context.Background(), card)
+	}
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+
+	names := []string{}
+	for _, span := range spans {
+		names = append(names, span.Name())
+
+		// Spans must never carry the PAN, whether in attributes, events or status.
+		var dump strings.Builder
+		fmt.Fprint(&dump, span.Name(), span.Status().Description)
+		for _, attr := range span.Attributes() {
+			fmt.Fprint(&dump, attr.Key, attr.Value.Emit())
+		}
+		for _, event := range span.Events() {
+			fmt.Fprint(&dump, event.Name, event.Attributes)
+		}
+
+		for _, card := range cards {
+			assert.NotContains(t, dump.String(), card)
+			assert.NotContains(t, dump.String(), card[6:])
+		}
+	}
+
+	assert.Equal(t, []string{
+		"Service.validateFormat", "Service.lookupNetwork", "Service.ValidateCardNumber",
+		"Service.validateFormat", "Service.ValidateCardNumber",
+	}, names)
+
+	assert.Contains(t, spans[2].Attributes(), attribute.String("card.network", "VISA"))
+	assert.Contains(t, spans[4].Attributes(), attribute.String("validation.reason", RejectionLuhnCheckFailed))
+	assert.Equal(t, spans[2].SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
@@ -8,24 +8,67 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+const (
+	RejectionMissing           = "missing"
+	RejectionInvalidCharacters = "invalid_characters"
+	RejectionUnsupportedPrefix = "unsupported_prefix"
+	RejectionInvalidLength     = "invalid_length"
+	RejectionLuhnCheckFailed   = "luhn_check_failed"
+	RejectionVelocityExceeded  = "velocity_exceeded"
+	RejectionBinBlocked        = "bin_blocked"
+)
+
 func validateCardNumber(fl validator.FieldLevel) bool {
-	val := fl.Field().String()
+	return cardNumberRejection(fl.Field().String()) == ""
+}
+
+// cardNumberRejection explains why a card number is invalid, or returns an empty string when it is valid.
+func cardNumberRejection(val string) string {
+	if val == "" {
+		return RejectionMissing
+	}
 
 	if !slices.Contains([]string{"3", "4", "5", "6"}, val[:1]) {
-		return false
+		return RejectionUnsupportedPrefix
 	}
 
 	if len(val) < 15 || len(val) > 16 {
-		return false
+		return RejectionInvalidLength
 	}
 
 	if val[:1] == "3" {
 		if len(val) != 15 {
-			return false
+			return RejectionInvalidLength
 		}
 	}
 
-	return luhnValidation(val)
+	if strings.Trim(val, "0123456789 ") != "" {
+		return RejectionInvalidCharacters
+	}
+
+	if !luhnValidation(val) {
+		return RejectionLuhnCheckFailed
+	}
+
+	return ""
+}
+
+// unlistedRangeRejection checks card numbers from ranges outside the supported networks, such
+// as private-label cards, which may have any length allowed by ISO/IEC 7812.
+func unlistedRangeRejection(val string) string {
+	if strings.Trim(val, "0123456789 ") != "" {
+		return RejectionInvalidCharacters
+	}
+
+	if digits := len(strings.ReplaceAll(val, " ", "")); digits < 12 || digits > 19 {
+		return RejectionInvalidLength
+	}
+
+	if !luhnValidation(val) {
+		return RejectionLuhnCheckFailed
+	}
+
+	return ""
 }
 
 func luhnValidation(cardNumber string) bool {
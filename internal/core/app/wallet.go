@@ -0,0 +1,181 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"cards-service/internal/core/ports"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const maxExpiryWindowDays = 366
+
+// Wallet keeps the cards customers of each app save. Cards go through the same validation as
+// ValidateCardNumber, app policies included, before they are saved. The first card a customer
+// saves becomes their default.
+type Wallet struct {
+	validation     ports.AppService
+	repo           ports.WalletRepository
+	fingerprintKey []byte
+	maxCards       int
+	keys           ports.KeyManager
+	now            func() time.Time
+}
+
+type WalletOption func(*Wallet)
+
+// WithPANEncryption keeps the card numbers of saved cards, encrypted with keys.
+func WithPANEncryption(keys ports.KeyManager) WalletOption {
+	return func(w *Wallet) {
+		w.keys = keys
+	}
+}
+
+// NewWallet creates a wallet that allows up to maxCards cards per customer, or any number
+// when maxCards is zero. The fingerprint key must not be empty.
+func NewWallet(validation ports.AppService, repo ports.WalletRepository, fingerprintKey []byte, maxCards int, opts ...WalletOption) *Wallet {
+	w := &Wallet{validation: validation, repo: repo, fingerprintKey: fingerprintKey, maxCards: maxCards, now: time.Now}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+func (w *Wallet) AddCard(ctx context.Context, customerID string, cardNumber string, expiryMonth int, expiryYear int) (*domain.WalletCard, error) {
+	if err := domain.ValidateCustomerID(customerID); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateExpiry(expiryMonth, expiryYear, w.now()); err != nil {
+		return nil, err
+	}
+
+	info, err := w.validation.ValidateCardNumber(ctx, cardNumber)
+	if err != nil {
+		return nil, err
+	}
+	if info.PolicyReason != "" {
+		return nil, errors.NewErrorf(errors.PreconditionFailed, "card is not accepted: %s", info.PolicyReason)
+	}
+
+	token, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	card := &domain.WalletCard{
+		Token:       token,
+		AppID:       domain.CallerFromContext(ctx).AppID,
+		CustomerID:  customerID,
+		Fingerprint: domain.Fingerprint(w.fingerprintKey, cardNumber),
+		MaskedPAN:   domain.MaskPAN(cardNumber),
+		Brand:       info.CardProvider,
+		Badge:       info.ProviderBadge,
+		ExpiryMonth: expiryMonth,
+		ExpiryYear:  expiryYear,
+		Status:      domain.CardStatusActive,
+		CreatedAt:   w.now().UTC(),
+	}
+
+	if w.keys != nil {
+		envelope, err := w.keys.Encrypt(ctx, domain.KeyPurposeWalletPAN, []byte(cardNumber), panAAD(card))
+		if err != nil {
+			return nil, err
+		}
+		card.PANEnvelope = envelope
+	}
+
+	if err := w.repo.Add(ctx, card, w.maxCards); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (w *Wallet) ListCards(ctx context.Context, customerID string) ([]domain.WalletCard, error) {
+	if err := domain.ValidateCustomerID(customerID); err != nil {
+		return nil, err
+	}
+
+	return w.repo.List(ctx, domain.CallerFromContext(ctx).AppID, customerID)
+}
+
+func (w *Wallet) GetCard(ctx context.Context, customerID string, token string) (*domain.WalletCard, error) {
+	if err := domain.ValidateCustomerID(customerID); err != nil {
+		return nil, err
+	}
+
+	return w.repo.Get(ctx, domain.CallerFromContext(ctx).AppID, customerID, token)
+}
+
+// RemoveCard deletes a saved card. When it was the default, the most recently saved of the
+// remaining cards takes its place.
+func (w *Wallet) RemoveCard(ctx context.Context, customerID string, token string) error {
+	card, err := w.GetCard(ctx, customerID, token)
+	if err != nil {
+		return err
+	}
+
+	if err := w.repo.Remove(ctx, card.AppID, customerID, token); err != nil {
+		return err
+	}
+
+	if !card.Default {
+		return nil
+	}
+
+	remaining, err := w.repo.List(ctx, card.AppID, customerID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+
+	newest := slices.MaxFunc(remaining, func(a, b domain.WalletCard) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return w.repo.SetDefault(ctx, card.AppID, customerID, newest.Token)
+}
+
+func (w *Wallet) SetDefaultCard(ctx context.Context, customerID string, token string) (*domain.WalletCard, error) {
+	if err := domain.ValidateCustomerID(customerID); err != nil {
+		return nil, err
+	}
+
+	appID := domain.CallerFromContext(ctx).AppID
+	if err := w.repo.SetDefault(ctx, appID, customerID, token); err != nil {
+		return nil, err
+	}
+
+	return w.repo.Get(ctx, appID, customerID, token)
+}
+
+// ListExpiringCards returns the cards of the calling app that expire within the given number
+// of days, or have expired, soonest first.
+func (w *Wallet) ListExpiringCards(ctx context.Context, withinDays int) ([]domain.WalletCard, error) {
+	if withinDays < 0 || withinDays > maxExpiryWindowDays {
+		return nil, errors.NewValidationError([]*errors.FieldViolation{
+			{Field: "within_days", Description: "must be between 0 and 366"},
+		})
+	}
+
+	return w.repo.ListExpiring(ctx, domain.CallerFromContext(ctx).AppID, w.now().AddDate(0, 0, withinDays))
+}
+
+// CardNumber decrypts the card number of a saved card kept with WithPANEncryption.
+func (w *Wallet) CardNumber(ctx context.Context, card *domain.WalletCard) (string, error) {
+	if w.keys == nil || card.PANEnvelope == nil {
+		return "", errors.NewErrorf(errors.PreconditionFailed, "card number of %s is not kept", card.Token)
+	}
+
+	pan, err := w.keys.Decrypt(ctx, domain.KeyPurposeWalletPAN, card.PANEnvelope, panAAD(card))
+	if err != nil {
+		return "", err
+	}
+
+	return string(pan), nil
+}
+
+// panAAD binds an encrypted card number to the card it belongs to.
+func panAAD(card *domain.WalletCard) []byte {
+	return []byte(card.AppID + "/" + card.CustomerID + "/" + card.Token)
+}
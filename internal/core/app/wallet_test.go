@@ -0,0 +1,273 @@
+package app
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mwinyimoha/commons/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWalletRepository holds the cards of every customer in a single list, in the order saved.
+// fakeWalletRepository records the events published with status changes, unless it fails
+// with err.
+type fakeWalletRepository struct {
+	cards  []domain.WalletCard
+	events []*domain.Event
+	err    error
+}
+
+func (r *fakeWalletRepository) find(appID string, customerID string, token string) int {
+	return slices.IndexFunc(r.cards, func(c domain.WalletCard) bool {
+		return c.AppID == appID && c.CustomerID == customerID && c.Token == token
+	})
+}
+
+func (r *fakeWalletRepository) Add(ctx context.Context, card *domain.WalletCard, maxCards int) error {
+	count, hasDefault := 0, false
+	for _, c := range r.cards {
+		if c.AppID != card.AppID || c.CustomerID != card.CustomerID {
+			continue
+		}
+		if c.Fingerprint == card.Fingerprint {
+			return errors.NewErrorf(errors.Conflict, "card is already saved")
+		}
+		count++
+		hasDefault = hasDefault || c.Default
+	}
+	if maxCards > 0 && count >= maxCards {
+		return errors.NewErrorf(errors.QuotaExceeded, "customer already has %d saved cards", count)
+	}
+
+	card.Default = !hasDefault
+	r.cards = append(r.cards, *card)
+	return nil
+}
+
+func (r *fakeWalletRepository) List(ctx context.Context, appID string, customerID string) ([]domain.WalletCard, error) {
+	var cards []domain.WalletCard
+	for _, c := range r.cards {
+		if c.AppID == appID && c.CustomerID == customerID {
+			cards = append(cards, c)
+		}
+	}
+
+	return cards, nil
+}
+
+func (r *fakeWalletRepository) Get(ctx context.Context, appID string, customerID string, token string) (*domain.WalletCard, error) {
+	i := r.find(appID, customerID, token)
+	if i < 0 {
+		return nil, errors.NewErrorf(errors.NotFound, "card %s not found", token)
+	}
+
+	card := r.cards[i]
+	return &card, nil
+}
+
+func (r *fakeWalletRepository) Remove(ctx context.Context, appID string, customerID string, token string) error {
+	i := r.find(appID, customerID, token)
+	if i < 0 {
+		return errors.NewErrorf(errors.NotFound, "card %s not found", token)
+	}
+
+	r.cards = slices.Delete(r.cards, i, i+1)
+	return nil
+}
+
+func (r *fakeWalletRepository) SetDefault(ctx context.Context, appID string, customerID string, token string) error {
+	if r.find(appID, customerID, token) < 0 {
+		return errors.NewErrorf(errors.NotFound, "card %s not found", token)
+	}
+
+	for i, c := range r.cards {
+		if c.AppID == appID && c.CustomerID == customerID {
+			r.cards[i].Default = c.Token == token
+		}
+	}
+
+	return nil
+}
+
+func (r *fakeWalletRepository) ListExpiring(ctx context.Context, appID string, before time.Time) ([]domain.WalletCard, error) {
+	var cards []domain.WalletCard
+	for _, c := range r.cards {
+		if (appID == "" || c.AppID == appID) && c.Expired(before) {
+			cards = append(cards, c)
+		}
+	}
+
+	slices.SortStableFunc(cards, func(a, b domain.WalletCard) int {
+		return domain.ExpiryEnd(a.ExpiryMonth, a.ExpiryYear).Compare(domain.ExpiryEnd(b.ExpiryMonth, b.ExpiryYear))
+	})
+
+	return cards, nil
+}
+
+func (r *fakeWalletRepository) SetStatus(ctx context.Context, appID string, customerID string, token string, status string, event *domain.Event) error {
+	i := r.find(appID, customerID, token)
+	if i < 0 {
+		return errors.NewErrorf(errors.NotFound, "card %s not found", token)
+	}
+	if r.err != nil {
+		return r.err
+	}
+
+	r.cards[i].Status = status
+	if event != nil {
+		r.events = append(r.events, event)
+	}
+	return nil
+}
+
+func newTestWallet(repo *fakeWalletRepository, opts ...Option) *Wallet {
+	wallet := NewWallet(NewService(validator.New(), opts...), repo, []byte("0123456789abcdef0123456789abcdef"), 3)
+
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	wallet.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	return wallet
+}
+
+func TestWallet(t *testing.T) {
+	ctx := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "checkout"})
+
+	t.Run("Add Card", func(t *testing.T) {
+		repo := &fakeWalletRepository{}
+		wallet := newTestWallet(repo)
+
+		card, err := wallet.AddCard(ctx, "cus-1", "4111111111111111", 12, 2030)
+		require.NoError(t, err)
+		assert.Len(t, card.Token, 32)
+		assert.Equal(t, "checkout", card.AppID)
+		assert.Equal(t, "411111******1111", card.MaskedPAN)
+		assert.Equal(t, "VISA", card.Brand)
+		assert.Equal(t, "https://dummy.com/card-provider-icons/visa.png", card.Badge)
+		assert.True(t, card.Default, "the first card is the default")
+		assert.NotContains(t, card.Fingerprint, "4111111111111111")
+
+		second, err := wallet.AddCard(ctx, "cus-1", "5555555555554444", 1, 2031)
+		require.NoError(t, err)
+		assert.False(t, second.Default)
+	})
+
+	t.Run("Invalid Cards Are Not Saved", func(t *testing.T) {
+		repo := &fakeWalletRepository{}
+		wallet := newTestWallet(repo)
+
+		_, err := wallet.AddCard(ctx, "cus-1", "4111111111111112", 12, 2030)
+		require.Error(t, err)
+		_, err = wallet.AddCard(ctx, "cus-1", "4111111111111111", 12, 2024)
+		require.Error(t, err, "expired cards are refused")
+		_, err = wallet.AddCard(ctx, "cus-1", "4111111111111111", 13, 2030)
+		require.Error(t, err)
+		_, err = wallet.AddCard(ctx, "", "4111111111111111", 12, 2030)
+		require.Error(t, err)
+
+		assert.Empty(t, repo.cards)
+	})
+
+	t.Run("Cards The App Does Not Accept Are Not Saved", func(t *testing.T) {
+		repo := &fakeWalletRepository{}
+		wallet := newTestWallet(repo, WithPolicies(map[string]domain.AppPolicy{"checkout": {Networks: []string{"VISA"}}}))
+
+		_, err := wallet.AddCard(ctx, "cus-1", "5555555555554444", 12, 2030)
+		require.Error(t, err)
+		assert.Equal(t, errors.PreconditionFailed, err.(*errors.Error).ErrCode)
+		assert.Empty(t, repo.cards)
+	})
+
+	t.Run("Card Limit", func(t *testing.T) {
+		wallet := newTestWallet(&fakeWalletRepository{})
+
+		for _, card := range []string{"4111111111111111", "5555555555554444", "378282246310005"} {
+			_, err := wallet.AddCard(ctx, "cus-1", card, 12, 2030)
+			require.NoError(t, err)
+		}
+
+		_, err := wallet.AddCard(ctx, "cus-1", "6011111111111117", 12, 2030)
+		require.Error(t, err)
+		assert.Equal(t, errors.QuotaExceeded, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("Removing The Default Promotes The Newest Card", func(t *testing.T) {
+		wallet := newTestWallet(&fakeWalletRepository{})
+
+		first, err := wallet.AddCard(ctx, "cus-1", "4111111111111111", 12, 2030)
+		require.NoError(t, err)
+		_, err = wallet.AddCard(ctx, "cus-1", "5555555555554444", 12, 2030)
+		require.NoError(t, err)
+		newest, err := wallet.AddCard(ctx, "cus-1", "378282246310005", 12, 2030)
+		require.NoError(t, err)
+
+		require.NoError(t, wallet.RemoveCard(ctx, "cus-1", first.Token))
+
+		card, err := wallet.GetCard(ctx, "cus-1", newest.Token)
+		require.NoError(t, err)
+		assert.True(t, card.Default)
+	})
+
+	t.Run("Set Default Card", func(t *testing.T) {
+		wallet := newTestWallet(&fakeWalletRepository{})
+
+		_, err := wallet.AddCard(ctx, "cus-1", "4111111111111111", 12, 2030)
+		require.NoError(t, err)
+		second, err := wallet.AddCard(ctx, "cus-1", "5555555555554444", 12, 2030)
+		require.NoError(t, err)
+
+		card, err := wallet.SetDefaultCard(ctx, "cus-1", second.Token)
+		require.NoError(t, err)
+		assert.True(t, card.Default)
+
+		cards, err := wallet.ListCards(ctx, "cus-1")
+		require.NoError(t, err)
+		assert.False(t, cards[0].Default)
+	})
+
+	t.Run("Other Apps Cannot See Cards", func(t *testing.T) {
+		wallet := newTestWallet(&fakeWalletRepository{})
+
+		card, err := wallet.AddCard(ctx, "cus-1", "4111111111111111", 12, 2030)
+		require.NoError(t, err)
+
+		other := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "other"})
+		_, err = wallet.GetCard(other, "cus-1", card.Token)
+		require.Error(t, err)
+		assert.Equal(t, errors.NotFound, err.(*errors.Error).ErrCode)
+	})
+
+	t.Run("List Expiring Cards", func(t *testing.T) {
+		wallet := newTestWallet(&fakeWalletRepository{})
+
+		soon, err := wallet.AddCard(ctx, "cus-1", "4111111111111111", 1, 2025)
+		require.NoError(t, err)
+		_, err = wallet.AddCard(ctx, "cus-2", "5555555555554444", 12, 2030)
+		require.NoError(t, err)
+
+		cards, err := wallet.ListExpiringCards(ctx, 31)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		assert.Equal(t, soon.Token, cards[0].Token)
+		assert.Equal(t, domain.CardStatusActive, cards[0].Status)
+
+		cards, err = wallet.ListExpiringCards(ctx, 0)
+		require.NoError(t, err)
+		assert.Empty(t, cards)
+
+		other := domain.ContextWithCaller(context.Background(), domain.Caller{AppID: "other"})
+		cards, err = wallet.ListExpiringCards(other, 31)
+		require.NoError(t, err)
+		assert.Empty(t, cards)
+
+		_, err = wallet.ListExpiringCards(ctx, -1)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// AuditEvent records a single validation request, or a use of an encryption key, in which
+// case the Key fields are set. It only ever holds the masked PAN and its fingerprint.
+type AuditEvent struct {
+	Timestamp   time.Time `json:"timestamp"`
+	AppID       string    `json:"app_id"`
+	Peer        string    `json:"peer"`
+	Method      string    `json:"method"`
+	MaskedPAN   string    `json:"masked_pan"`
+	Fingerprint string    `json:"fingerprint"`
+	Network     string    `json:"network"`
+	Outcome     string    `json:"outcome"`
+	Reason      string    `json:"reason,omitempty"`
+	RiskSignals []string  `json:"risk_signals,omitempty"`
+	// KeyOperation is encrypt, decrypt, rewrap or rotate.
+	KeyOperation string `json:"key_operation,omitempty"`
+	KeyPurpose   string `json:"key_purpose,omitempty"`
+	KeyVersion   int    `json:"key_version,omitempty"`
+}
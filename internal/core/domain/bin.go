@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// BinLength is how many leading digits identify the issuer of a card. Lookups never need more.
+const BinLength = 8
+
+// BinInfo describes the issuer range a card number falls into.
+type BinInfo struct {
+	Prefix   string `json:"prefix"`
+	Network  string `json:"network"`
+	Issuer   string `json:"issuer,omitempty"`
+	Country  string `json:"country,omitempty"`
+	CardType string `json:"card_type,omitempty"`
+	// Source names the data set that answered the lookup.
+	Source string `json:"source,omitempty"`
+}
+
+// DefaultBins is the built-in table used when no BIN data is configured. It only tells the
+// networks apart.
+var DefaultBins = []BinInfo{
+	{Prefix: "3", Network: "AMEX"},
+	{Prefix: "4", Network: "VISA"},
+	{Prefix: "5", Network: "MASTERCARD"},
+	{Prefix: "6", Network: "DISCOVER"},
+}
+
+// BIN returns the leading digits of a card number used to look up its issuer.
+func BIN(cardNumber string) string {
+	cardNumber = strings.ReplaceAll(cardNumber, " ", "")
+	if len(cardNumber) > BinLength {
+		return cardNumber[:BinLength]
+	}
+
+	return cardNumber
+}
+
+// UnknownBinError is returned by BIN lookups when no range matches.
+func UnknownBinError(bin string) error {
+	return errors.NewErrorf(errors.NotFound, "no issuer found for BIN %s", MaskBIN(bin))
+}
+
+// MaskBIN keeps the first six digits of a BIN, which is all that may be logged with a card.
+func MaskBIN(bin string) string {
+	if len(bin) > 6 {
+		return bin[:6]
+	}
+
+	return bin
+}
+
+// CardInfo describes the card number as belonging to this BIN range.
+func (b *BinInfo) CardInfo(cardNumber string) *CardInfo {
+	bin := *b
+
+	return &CardInfo{
+		CardNumber:    cardNumber,
+		CardProvider:  b.Network,
+		ProviderBadge: providerBadge(b.Network),
+		Bin:           &bin,
+	}
+}
+
+func providerBadge(network string) string {
+	return fmt.Sprintf("https://dummy.com/card-provider-icons/%s.png", strings.ToLower(network))
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	BinRuleBlock = "block"
+	BinRuleAllow = "allow"
+
+	// NetworkPrivateLabel is reported for cards admitted by an allow rule that the BIN data
+	// does not know.
+	NetworkPrivateLabel = "PRIVATE_LABEL"
+	// SourceBinRule identifies BIN details that come from an allow rule.
+	SourceBinRule = "bin_rule"
+)
+
+// BinRule blocks a range of BINs, or allows one that the BIN data does not know, such as a
+// private-label range. RangeStart and RangeEnd are BIN prefixes of the same length and the
+// range includes both.
+type BinRule struct {
+	ID         string
+	RangeStart string
+	RangeEnd   string
+	Action     string
+	Reason     string
+	// ExpiresAt is zero for rules that never expire.
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+// Validate checks a rule before it is stored.
+func (r *BinRule) Validate() error {
+	var violations []*errors.FieldViolation
+
+	if !isDigits(r.RangeStart) || len(r.RangeStart) > BinLength {
+		violations = append(violations, &errors.FieldViolation{Field: "range_start", Description: "must be 1 to 8 digits"})
+	}
+	if !isDigits(r.RangeEnd) || len(r.RangeEnd) != len(r.RangeStart) {
+		violations = append(violations, &errors.FieldViolation{Field: "range_end", Description: "must have as many digits as range_start"})
+	} else if r.RangeEnd < r.RangeStart {
+		violations = append(violations, &errors.FieldViolation{Field: "range_end", Description: "must not be before range_start"})
+	}
+	if r.Action != BinRuleBlock && r.Action != BinRuleAllow {
+		violations = append(violations, &errors.FieldViolation{Field: "action", Description: "must be block or allow"})
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		violations = append(violations, &errors.FieldViolation{Field: "reason", Description: "is required"})
+	}
+	if !r.ExpiresAt.IsZero() && !r.ExpiresAt.After(r.CreatedAt) {
+		violations = append(violations, &errors.FieldViolation{Field: "expires_at", Description: "must be in the future"})
+	}
+
+	if len(violations) > 0 {
+		return errors.NewValidationError(violations)
+	}
+
+	return nil
+}
+
+func (r *BinRule) Expired(now time.Time) bool {
+	return !r.ExpiresAt.IsZero() && !now.Before(r.ExpiresAt)
+}
+
+// Matches reports whether the BIN falls into the range.
+func (r *BinRule) Matches(bin string) bool {
+	if len(bin) < len(r.RangeStart) {
+		return false
+	}
+
+	prefix := bin[:len(r.RangeStart)]
+	return prefix >= r.RangeStart && prefix <= r.RangeEnd
+}
+
+// CardInfo describes a card admitted by this rule that the BIN data does not know.
+func (r *BinRule) CardInfo(cardNumber string) *CardInfo {
+	bin := &BinInfo{Prefix: r.RangeStart, Network: NetworkPrivateLabel, Source: SourceBinRule}
+
+	return bin.CardInfo(cardNumber)
+}
+
+// MatchBinRule returns the rule that applies to the BIN, or nil when none does.
+// Narrower ranges take precedence over wider ones, so a range can be allowed within a
+// blocked one. Between equally narrow ranges, blocking wins.
+func MatchBinRule(rules []BinRule, bin string, now time.Time) *BinRule {
+	var match *BinRule
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Expired(now) || !rule.Matches(bin) {
+			continue
+		}
+
+		if match == nil || rule.narrowerThan(match) || (!match.narrowerThan(rule) && rule.Action == BinRuleBlock) {
+			match = rule
+		}
+	}
+
+	return match
+}
+
+// narrowerThan compares how many card numbers two ranges cover.
+func (r *BinRule) narrowerThan(other *BinRule) bool {
+	return r.width() < other.width()
+}
+
+// width is the fraction of all card numbers the range covers.
+func (r *BinRule) width() float64 {
+	span := float64(parseDigits(r.RangeEnd) - parseDigits(r.RangeStart) + 1)
+	for range len(r.RangeStart) {
+		span /= 10
+	}
+
+	return span
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+func parseDigits(s string) int64 {
+	var n int64
+	for _, c := range s {
+		n = n*10 + int64(c-'0')
+	}
+
+	return n
+}
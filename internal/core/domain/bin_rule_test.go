@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinRuleValidate(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := BinRule{RangeStart: "411111", RangeEnd: "411199", Action: BinRuleBlock, Reason: "fraud", CreatedAt: createdAt}
+
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, valid.Validate())
+	})
+
+	invalid := map[string]func(r *BinRule){
+		"Non Digit Start":    func(r *BinRule) { r.RangeStart = "41a111" },
+		"Start Too Long":     func(r *BinRule) { r.RangeStart, r.RangeEnd = "411111111", "411111111" },
+		"Mismatched Lengths": func(r *BinRule) { r.RangeEnd = "4112" },
+		"End Before Start":   func(r *BinRule) { r.RangeEnd = "411100" },
+		"Unknown Action":     func(r *BinRule) { r.Action = "flag" },
+		"Missing Reason":     func(r *BinRule) { r.Reason = " " },
+		"Expiry In The Past": func(r *BinRule) { r.ExpiresAt = createdAt.Add(-time.Hour) },
+		"Expiry At Creation": func(r *BinRule) { r.ExpiresAt = createdAt },
+	}
+
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			rule := valid
+			mutate(&rule)
+			assert.Error(t, rule.Validate())
+		})
+	}
+}
+
+func TestBinRuleMatches(t *testing.T) {
+	rule := BinRule{RangeStart: "4111", RangeEnd: "4119"}
+
+	assert.True(t, rule.Matches("41110000"))
+	assert.True(t, rule.Matches("41199999"))
+	assert.False(t, rule.Matches("41200000"))
+	assert.False(t, rule.Matches("411"), "shorter BINs cannot fall into the range")
+}
+
+func TestMatchBinRule(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rules := []BinRule{
+		{ID: "wide-block", RangeStart: "41", RangeEnd: "41", Action: BinRuleBlock},
+		{ID: "narrow-allow", RangeStart: "411111", RangeEnd: "411111", Action: BinRuleAllow},
+		{ID: "tie-allow", RangeStart: "5100", RangeEnd: "5199", Action: BinRuleAllow},
+		{ID: "tie-block", RangeStart: "510", RangeEnd: "519", Action: BinRuleBlock},
+		{ID: "expired", RangeStart: "6", RangeEnd: "6", Action: BinRuleBlock, ExpiresAt: now},
+	}
+
+	t.Run("Narrower Range Wins", func(t *testing.T) {
+		assert.Equal(t, "narrow-allow", MatchBinRule(rules, "41111111", now).ID)
+		assert.Equal(t, "wide-block", MatchBinRule(rules, "41222222", now).ID)
+	})
+
+	t.Run("Block Wins Between Equal Ranges", func(t *testing.T) {
+		assert.Equal(t, "tie-block", MatchBinRule(rules, "51500000", now).ID)
+	})
+
+	t.Run("Expired Rules Are Ignored", func(t *testing.T) {
+		assert.Nil(t, MatchBinRule(rules, "60110000", now))
+		assert.NotNil(t, MatchBinRule(rules, "60110000", now.Add(-time.Second)))
+	})
+
+	t.Run("No Match", func(t *testing.T) {
+		assert.Nil(t, MatchBinRule(rules, "37000000", now))
+	})
+}
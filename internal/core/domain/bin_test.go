@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBIN(t *testing.T) {
+	assert.Equal(t, "41111111", BIN("4111111111111111"))
+	assert.Equal(t, "41111111", BIN("4111 1111 1111 1111"))
+	assert.Equal(t, "4111", BIN("4111"))
+	assert.Equal(t, "411111", MaskBIN("41111111"))
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+type callerKey struct{}
+
+// Caller describes who made the current request, as resolved by the transport layer.
+type Caller struct {
+	AppID   string
+	Subject string
+	Peer    string
+	Method  string
+}
+
+func ContextWithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+func CallerFromContext(ctx context.Context) Caller {
+	caller, _ := ctx.Value(callerKey{}).(Caller)
+	return caller
+}
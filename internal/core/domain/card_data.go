@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// minMaskableLength is the shortest input for which the BIN and last four digits may be shown.
+const minMaskableLength = 13
+
+// MaskPAN keeps the first six and last four digits of a card number, which PCI DSS allows
+// to be displayed, and masks the rest. Shorter inputs are masked entirely.
+func MaskPAN(pan string) string {
+	if len(pan) < minMaskableLength {
+		return strings.Repeat("*", len(pan))
+	}
+
+	return pan[:6] + strings.Repeat("*", len(pan)-10) + pan[len(pan)-4:]
+}
+
+// Fingerprint returns a keyed, irreversible identifier for a card number so repeated use of
+// the same card can be correlated without storing it.
+func Fingerprint(key []byte, pan string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(pan))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
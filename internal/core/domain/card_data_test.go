@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskPAN(t *testing.T) {
+	t.Run("Keeps BIN And Last Four", func(t *testing.T) {
+		assert.Equal(t, "411111******1111", MaskPAN("4111111111111111"))
+		assert.Equal(t, "378282*****0005", MaskPAN("378282246310005"))
+	})
+
+	t.Run("Masks Short Input Entirely", func(t *testing.T) {
+		assert.Equal(t, "******", MaskPAN("411111"))
+		assert.Equal(t, "", MaskPAN(""))
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Run("Stable Per Key", func(t *testing.T) {
+		first := Fingerprint([]byte("key"), "4111111111111111")
+
+		assert.Len(t, first, 64)
+		assert.Equal(t, first, Fingerprint([]byte("key"), "4111111111111111"))
+		assert.NotEqual(t, first, Fingerprint([]byte("other"), "4111111111111111"))
+		assert.NotContains(t, first, "4111111111111111")
+	})
+}
@@ -0,0 +1,54 @@
+package domain
+
+// ReasonRuleNotSatisfied is reported for cards that fail one of the custom rules of an app.
+const ReasonRuleNotSatisfied = "rule_not_satisfied"
+
+// CardRule is a custom condition, written in CEL over the facts of a card, that an app
+// requires cards to satisfy, such as `card.type != 'prepaid' || card.country == 'KE'`.
+type CardRule struct {
+	Name       string `mapstructure:"name" validate:"required"`
+	Expression string `mapstructure:"expression" validate:"required"`
+}
+
+// CardFacts are what card rules can see of a card. They never include the card number.
+type CardFacts struct {
+	Network  string `cel:"network"`
+	BIN      string `cel:"bin"`
+	Length   int    `cel:"length"`
+	Issuer   string `cel:"issuer"`
+	Country  string `cel:"country"`
+	CardType string `cel:"type"`
+	// Test is set for the published test numbers of the card networks.
+	Test bool `cel:"test"`
+}
+
+// testCards are the numbers card networks and gateways publish for testing.
+var testCards = map[string]bool{
+	"4111111111111111": true,
+	"4012888888881881": true,
+	"4242424242424242": true,
+	"4000056655665556": true,
+	"5555555555554444": true,
+	"5105105105105100": true,
+	"2223003122003222": true,
+	"378282246310005":  true,
+	"371449635398431":  true,
+	"6011111111111117": true,
+	"6011000990139424": true,
+}
+
+func NewCardFacts(cardNumber string, card *CardInfo) CardFacts {
+	facts := CardFacts{
+		Network: card.CardProvider,
+		BIN:     BIN(cardNumber),
+		Length:  len(cardNumber),
+		Test:    testCards[cardNumber],
+	}
+	if card.Bin != nil {
+		facts.Issuer = card.Bin.Issuer
+		facts.Country = card.Bin.Country
+		facts.CardType = card.Bin.CardType
+	}
+
+	return facts
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCardFacts(t *testing.T) {
+	card := &CardInfo{CardProvider: "VISA", Bin: &BinInfo{Issuer: "Test Bank", Country: "KE", CardType: "debit"}}
+
+	assert.Equal(t, CardFacts{
+		Network:  "VISA",
+		BIN:      "41111111",
+		Length:   16,
+		Issuer:   "Test Bank",
+		Country:  "KE",
+		CardType: "debit",
+		Test:     true,
+	}, NewCardFacts("4111111111111111", card))
+
+	facts := NewCardFacts("4556737586899855", &CardInfo{CardProvider: "VISA"})
+	assert.False(t, facts.Test)
+	assert.Empty(t, facts.Country, "facts the BIN data does not know are empty")
+}
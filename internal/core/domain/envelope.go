@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// Key purposes name what a key manager encrypts, for the audit trail.
+const (
+	KeyPurposeWalletPAN      = "wallet_pan"
+	KeyPurposeFPEKey         = "fpe_key"
+	KeyPurposeFingerprintKey = "fingerprint_key"
+)
+
+// Envelope is data encrypted under a data encryption key (DEK) of its own, which is kept
+// wrapped by a version of the key encryption key (KEK). Rotating the KEK rewraps the DEK and
+// leaves the data as it is.
+type Envelope struct {
+	KeyVersion int
+	WrappedKey []byte
+	Ciphertext []byte
+}
+
+// String encodes the envelope for storage as "v<version>.<wrapped key>.<ciphertext>", in
+// unpadded URL-safe base64.
+func (e *Envelope) String() string {
+	enc := base64.RawURLEncoding
+	return "v" + strconv.Itoa(e.KeyVersion) + "." + enc.EncodeToString(e.WrappedKey) + "." + enc.EncodeToString(e.Ciphertext)
+}
+
+func ParseEnvelope(s string) (*Envelope, error) {
+	invalid := errors.NewErrorf(errors.InvalidArgument, "invalid envelope")
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 || !strings.HasPrefix(parts[0], "v") {
+		return nil, invalid
+	}
+
+	version, err := strconv.Atoi(parts[0][1:])
+	if err != nil || version < 1 {
+		return nil, invalid
+	}
+
+	enc := base64.RawURLEncoding
+	wrapped, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return nil, invalid
+	}
+	ciphertext, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalid
+	}
+
+	return &Envelope{KeyVersion: version, WrappedKey: wrapped, Ciphertext: ciphertext}, nil
+}
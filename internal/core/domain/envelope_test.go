@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	env := &Envelope{KeyVersion: 3, WrappedKey: []byte{1, 2, 3}, Ciphertext: []byte("sealed")}
+
+	parsed, err := ParseEnvelope(env.String())
+	assert.NoError(t, err)
+	assert.Equal(t, env, parsed)
+
+	for _, s := range []string{"", "v1.AQ", "x1.AQ.AQ", "v0.AQ.AQ", "v1.!!.AQ"} {
+		_, err := ParseEnvelope(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// EventCardValidated is published for every validation.
+const EventCardValidated = "card.validated"
+
+// Event is published to downstream systems. Data depends on Type and, like the rest of the
+// event, never holds a full card number.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	AppID     string          `json:"app_id,omitempty"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewEvent(id string, eventType string, timestamp time.Time, appID string, data any) (*Event, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.Internal, "failed to encode %s event", eventType)
+	}
+
+	return &Event{ID: id, Type: eventType, Timestamp: timestamp, AppID: appID, Data: encoded}, nil
+}
+
+// CardValidated is the data of EventCardValidated events. Fingerprint is only set when a
+// fingerprint key is configured.
+type CardValidated struct {
+	MaskedPAN   string   `json:"masked_pan"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+	Network     string   `json:"network"`
+	Outcome     string   `json:"outcome"`
+	Reason      string   `json:"reason,omitempty"`
+	PolicyRule  string   `json:"policy_rule,omitempty"`
+	RiskSignals []string `json:"risk_signals,omitempty"`
+	BinSource   string   `json:"bin_source,omitempty"`
+}
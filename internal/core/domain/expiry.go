@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+const (
+	// EventCardExpiring is published once for every saved card about to expire.
+	EventCardExpiring = "card.expiring"
+	// EventCardExpired is published when a saved card has expired and is made inactive.
+	EventCardExpired = "card.expired"
+)
+
+// ExpiringCard is the data of EventCardExpiring and EventCardExpired events, and an entry of
+// an ExpiryReport.
+type ExpiringCard struct {
+	Token       string    `json:"token"`
+	CustomerID  string    `json:"customer_id"`
+	MaskedPAN   string    `json:"masked_pan"`
+	Brand       string    `json:"brand"`
+	ExpiryMonth int       `json:"expiry_month"`
+	ExpiryYear  int       `json:"expiry_year"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Status      string    `json:"status"`
+}
+
+func NewExpiringCard(card *WalletCard) ExpiringCard {
+	return ExpiringCard{
+		Token:       card.Token,
+		CustomerID:  card.CustomerID,
+		MaskedPAN:   card.MaskedPAN,
+		Brand:       card.Brand,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+		ExpiresAt:   ExpiryEnd(card.ExpiryMonth, card.ExpiryYear),
+		Status:      card.Status,
+	}
+}
+
+// ExpiryReport lists the saved cards of an app that are about to expire, or have expired
+// since the previous report.
+type ExpiryReport struct {
+	AppID       string         `json:"app_id"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Cards       []ExpiringCard `json:"cards"`
+}
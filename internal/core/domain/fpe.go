@@ -0,0 +1,7 @@
+package domain
+
+// Format-preserving encryption algorithms of NIST SP 800-38G.
+const (
+	FPEFF1  = "ff1"
+	FPEFF31 = "ff3-1"
+)
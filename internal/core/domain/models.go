@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/mwinyimoha/commons/pkg/errors"
@@ -15,23 +14,23 @@ type CardInfo struct {
 	CardNumber    string
 	CardProvider  string
 	ProviderBadge string
+	// Bin is the issuer range the card belongs to, when it was resolved through a BIN lookup.
+	Bin *BinInfo
+	// RiskSignals lists the risk checks the validation tripped without being refused.
+	RiskSignals []string
+	// PolicyReason explains why the policy of the calling app does not accept this otherwise
+	// valid card. It is empty when the card is accepted.
+	PolicyReason string
+	// PolicyRule names the custom rule of the calling app the card failed, if any.
+	PolicyRule string
 }
 
 func NewCardInfo(cardNumber string) (*CardInfo, error) {
-	providers := map[string]string{
-		"3": "AMEX",
-		"4": "VISA",
-		"5": "MASTERCARD",
-		"6": "DISCOVER",
-	}
-	providerName, exists := providers[cardNumber[:1]]
-	if !exists {
-		return nil, errors.NewErrorf(errors.InvalidArgument, "unknown card provider")
+	for _, bin := range DefaultBins {
+		if strings.HasPrefix(cardNumber, bin.Prefix) {
+			return bin.CardInfo(cardNumber), nil
+		}
 	}
 
-	return &CardInfo{
-		CardNumber:    cardNumber,
-		CardProvider:  providerName,
-		ProviderBadge: fmt.Sprintf("https://dummy.com/card-provider-icons/%s.png", strings.ToLower(providerName)),
-	}, nil
+	return nil, errors.NewErrorf(errors.InvalidArgument, "unknown card provider")
 }
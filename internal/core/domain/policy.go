@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// PolicyReport accepts cards the policy excludes but reports them as not accepted.
+	PolicyReport = "report"
+	// PolicyReject refuses cards the policy excludes.
+	PolicyReject = "reject"
+
+	ReasonNetworkNotAccepted  = "network_not_accepted"
+	ReasonCardTypeNotAccepted = "card_type_not_accepted"
+	ReasonCountryNotAccepted  = "country_not_accepted"
+)
+
+// AppPolicy restricts the cards an app accepts by network, card type and issuing country.
+// Empty lists accept everything. Cards whose card type or country the BIN data does not know
+// are not accepted when those are restricted.
+type AppPolicy struct {
+	Networks  []string `mapstructure:"networks"`
+	CardTypes []string `mapstructure:"card_types"`
+	Countries []string `mapstructure:"countries"`
+	// Rules are custom conditions cards must also satisfy.
+	Rules []CardRule `mapstructure:"rules" validate:"dive"`
+	Mode  string     `mapstructure:"mode" validate:"omitempty,oneof=report reject"`
+}
+
+// Check returns why the policy does not accept the card, or an empty string when it does.
+func (p *AppPolicy) Check(card *CardInfo) string {
+	var bin BinInfo
+	if card.Bin != nil {
+		bin = *card.Bin
+	}
+
+	switch {
+	case !acceptsValue(p.Networks, card.CardProvider):
+		return ReasonNetworkNotAccepted
+	case !acceptsValue(p.CardTypes, bin.CardType):
+		return ReasonCardTypeNotAccepted
+	case !acceptsValue(p.Countries, bin.Country):
+		return ReasonCountryNotAccepted
+	default:
+		return ""
+	}
+}
+
+// Rejects reports whether cards the policy excludes are refused rather than reported.
+func (p *AppPolicy) Rejects() bool {
+	return p.Mode == PolicyReject
+}
+
+func acceptsValue(accepted []string, value string) bool {
+	if len(accepted) == 0 {
+		return true
+	}
+
+	return value != "" && slices.ContainsFunc(accepted, func(a string) bool { return strings.EqualFold(a, value) })
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppPolicyCheck(t *testing.T) {
+	visaDebit := &CardInfo{CardProvider: "VISA", Bin: &BinInfo{Network: "VISA", CardType: "debit", Country: "KE"}}
+	amex := &CardInfo{CardProvider: "AMEX"}
+
+	t.Run("Empty Policy Accepts Everything", func(t *testing.T) {
+		policy := AppPolicy{}
+		assert.Empty(t, policy.Check(visaDebit))
+		assert.Empty(t, policy.Check(amex))
+	})
+
+	t.Run("Networks", func(t *testing.T) {
+		policy := AppPolicy{Networks: []string{"visa", "MASTERCARD"}}
+		assert.Empty(t, policy.Check(visaDebit), "networks are compared case-insensitively")
+		assert.Equal(t, ReasonNetworkNotAccepted, policy.Check(amex))
+	})
+
+	t.Run("Card Types", func(t *testing.T) {
+		policy := AppPolicy{CardTypes: []string{"credit"}}
+		assert.Equal(t, ReasonCardTypeNotAccepted, policy.Check(visaDebit))
+		assert.Equal(t, ReasonCardTypeNotAccepted, policy.Check(amex), "unknown card types are not accepted")
+	})
+
+	t.Run("Countries", func(t *testing.T) {
+		policy := AppPolicy{Countries: []string{"ke", "UG"}}
+		assert.Empty(t, policy.Check(visaDebit))
+		assert.Equal(t, ReasonCountryNotAccepted, policy.Check(amex))
+	})
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "slices"
+
+// Principal is a caller authenticated with a bearer token.
+type Principal struct {
+	Subject string
+	AppID   string
+	Scopes  []string
+}
+
+// HasScopes reports whether the principal was granted every scope in required.
+func (p *Principal) HasScopes(required []string) bool {
+	for _, scope := range required {
+		if !slices.Contains(p.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
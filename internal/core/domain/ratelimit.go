@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// RateLimitPolicy describes the token bucket and quota limits applied to a caller.
+// Zero quotas are treated as unlimited.
+type RateLimitPolicy struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second" validate:"gte=0"`
+	Burst             int     `mapstructure:"burst" validate:"gte=0"`
+	DailyQuota        int64   `mapstructure:"daily_quota" validate:"gte=0"`
+	MonthlyQuota      int64   `mapstructure:"monthly_quota" validate:"gte=0"`
+}
+
+// RateLimits holds the default policy for apps and peers along with per-app overrides.
+type RateLimits struct {
+	App  RateLimitPolicy
+	Peer RateLimitPolicy
+	Apps map[string]RateLimitPolicy
+}
+
+// ForApp resolves the policy that applies to the given app.
+func (rl *RateLimits) ForApp(appID string) RateLimitPolicy {
+	if policy, ok := rl.Apps[appID]; ok {
+		return policy
+	}
+
+	return rl.App
+}
+
+const (
+	ReasonPeerRateLimited      = "peer_rate_limited"
+	ReasonAppRateLimited       = "app_rate_limited"
+	ReasonDailyQuotaExceeded   = "daily_quota_exceeded"
+	ReasonMonthlyQuotaExceeded = "monthly_quota_exceeded"
+)
+
+type LimitDecision struct {
+	Allowed    bool
+	Reason     string
+	RetryAfter time.Duration
+}
+
+type QuotaUsage struct {
+	Used     int64
+	Limit    int64
+	ResetsAt time.Time
+}
+
+type Usage struct {
+	AppID             string
+	RequestsPerSecond float64
+	Burst             int
+	Daily             QuotaUsage
+	Monthly           QuotaUsage
+}
+
+// QuotaWindows returns the UTC start of the next day and the next month relative to now.
+func QuotaWindows(now time.Time) (nextDay time.Time, nextMonth time.Time) {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return day.AddDate(0, 0, 1), month.AddDate(0, 1, 0)
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+// Schedule is a cron schedule of five fields: minute, hour, day of month, month and day of
+// week, matched in UTC. A field is *, a value or a range a-b, optionally followed by /step,
+// or a comma-separated list of those. Sunday is 0 or 7. As in cron, when both day fields are
+// restricted, days matching either of them match.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var scheduleFields = [5]scheduleField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func ParseSchedule(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(scheduleFields) {
+		return nil, errors.NewErrorf(errors.InvalidArgument, "schedule %q must have 5 fields", expr)
+	}
+
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseScheduleField(field, scheduleFields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday is day 0 whichever way it was written.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// Next returns the first time after t that the schedule matches, or the zero time when it
+// never does, as on February 30.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func parseScheduleField(field string, spec scheduleField) (uint64, error) {
+	invalid := errors.NewErrorf(errors.InvalidArgument, "invalid %s %q in schedule", spec.name, field)
+
+	var set uint64
+	for part := range strings.SplitSeq(field, ",") {
+		values, stepText, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, invalid
+			}
+			step = n
+		}
+
+		lo, hi := spec.min, spec.max
+		if values != "*" {
+			start, end, isRange := strings.Cut(values, "-")
+
+			var err error
+			if lo, err = strconv.Atoi(start); err != nil {
+				return 0, invalid
+			}
+
+			switch {
+			case isRange:
+				if hi, err = strconv.Atoi(end); err != nil {
+					return 0, invalid
+				}
+			case !hasStep:
+				// A single value; with a step it runs to the end of the field.
+				hi = lo
+			}
+
+			if lo < spec.min || hi > spec.max || lo > hi {
+				return 0, invalid
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule(t *testing.T) {
+	// A Sunday.
+	start := time.Date(2025, 6, 15, 10, 30, 0, 0, time.UTC)
+
+	next := func(t *testing.T, expr string, from time.Time) time.Time {
+		s, err := ParseSchedule(expr)
+		require.NoError(t, err)
+
+		return s.Next(from)
+	}
+
+	t.Run("Daily", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, 6, 16, 3, 0, 0, 0, time.UTC), next(t, "0 3 * * *", start))
+		assert.Equal(t, time.Date(2025, 6, 15, 10, 31, 0, 0, time.UTC), next(t, "* * * * *", start))
+	})
+
+	t.Run("Steps And Ranges", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, 6, 15, 10, 45, 0, 0, time.UTC), next(t, "*/15 * * * *", start))
+		assert.Equal(t, time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC), next(t, "0 9-17 * * 1-5", start))
+		assert.Equal(t, time.Date(2025, 6, 15, 11, 5, 0, 0, time.UTC), next(t, "5,35 11 * * *", start))
+	})
+
+	t.Run("Days", func(t *testing.T) {
+		assert.Equal(t, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), next(t, "0 0 1 * *", start))
+		assert.Equal(t, time.Date(2025, 6, 22, 0, 0, 0, 0, time.UTC), next(t, "0 0 * * 7", start), "7 is Sunday")
+		assert.Equal(t, time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC), next(t, "0 0 1 * 5", start), "either day field matches")
+		assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), next(t, "0 0 29 2 *", start))
+		assert.True(t, next(t, "0 0 30 2 *", start).IsZero())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+			_, err := ParseSchedule(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// Velocity dimensions count validations of the same card, BIN range, app or peer.
+const (
+	VelocityCard = "card"
+	VelocityBIN  = "bin"
+	VelocityApp  = "app"
+	VelocityPeer = "peer"
+)
+
+// Risk signals raised by velocity checks.
+const (
+	SignalCardVelocity = "card_velocity_exceeded"
+	SignalBINVelocity  = "bin_velocity_exceeded"
+	SignalAppVelocity  = "app_velocity_exceeded"
+	SignalPeerVelocity = "peer_velocity_exceeded"
+)
+
+// VelocityLimit caps how many validations a single key may make within a sliding window.
+// A zero Max disables the check.
+type VelocityLimit struct {
+	Max    int64
+	Window time.Duration
+}
+
+// VelocityLimits holds the limit of every velocity dimension.
+type VelocityLimits struct {
+	Card VelocityLimit
+	BIN  VelocityLimit
+	App  VelocityLimit
+	Peer VelocityLimit
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mwinyimoha/commons/pkg/errors"
+)
+
+const (
+	CardStatusActive = "active"
+	// CardStatusExpiring marks cards the app was told are about to expire. They can still be
+	// used.
+	CardStatusExpiring = "expiring"
+	// CardStatusInactive marks cards that have expired.
+	CardStatusInactive = "inactive"
+)
+
+// WalletCard is a card a customer of an app has saved. The card number itself is only ever
+// kept encrypted, if at all. Cards are known by a random token standing for them, with a
+// fingerprint to spot the same card being saved twice, and what is needed to display them.
+type WalletCard struct {
+	Token       string
+	AppID       string
+	CustomerID  string
+	Fingerprint string
+	MaskedPAN   string
+	Brand       string
+	Badge       string
+	ExpiryMonth int
+	ExpiryYear  int
+	Default     bool
+	Status      string
+	CreatedAt   time.Time
+	// PANEnvelope holds the card number encrypted, when the wallet is set to keep it.
+	PANEnvelope *Envelope
+}
+
+// ValidateExpiry checks a card expiry date, which must not have passed.
+func ValidateExpiry(month int, year int, now time.Time) error {
+	var violations []*errors.FieldViolation
+
+	if month < 1 || month > 12 {
+		violations = append(violations, &errors.FieldViolation{Field: "expiry_month", Description: "must be between 1 and 12"})
+	}
+	if year < 2000 || year > 2099 {
+		violations = append(violations, &errors.FieldViolation{Field: "expiry_year", Description: "must be a four-digit year"})
+	} else if len(violations) == 0 && !now.Before(ExpiryEnd(month, year)) {
+		violations = append(violations, &errors.FieldViolation{Field: "expiry_year", Description: "card has expired"})
+	}
+
+	if len(violations) > 0 {
+		return errors.NewValidationError(violations)
+	}
+
+	return nil
+}
+
+// ExpiryEnd is when a card stops being valid: the start of the month after its expiry month.
+func ExpiryEnd(month int, year int) time.Time {
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Expired reports whether the card has passed its expiry date.
+func (c *WalletCard) Expired(now time.Time) bool {
+	return !now.Before(ExpiryEnd(c.ExpiryMonth, c.ExpiryYear))
+}
+
+// ValidateCustomerID checks the ID an app knows a customer by.
+func ValidateCustomerID(customerID string) error {
+	if strings.TrimSpace(customerID) == "" || len(customerID) > 128 {
+		return errors.NewValidationError([]*errors.FieldViolation{
+			{Field: "customer_id", Description: "must be between 1 and 128 characters"},
+		})
+	}
+
+	return nil
+}
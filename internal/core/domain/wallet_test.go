@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExpiry(t *testing.T) {
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, ValidateExpiry(6, 2025, now), "cards are valid until the end of their expiry month")
+	assert.NoError(t, ValidateExpiry(1, 2030, now))
+	assert.Error(t, ValidateExpiry(5, 2025, now))
+	assert.Error(t, ValidateExpiry(0, 2030, now))
+	assert.Error(t, ValidateExpiry(13, 2030, now))
+	assert.Error(t, ValidateExpiry(12, 30, now), "years have four digits")
+}
+
+func TestWalletCardExpired(t *testing.T) {
+	card := WalletCard{ExpiryMonth: 12, ExpiryYear: 2025}
+
+	assert.False(t, card.Expired(time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)))
+	assert.True(t, card.Expired(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
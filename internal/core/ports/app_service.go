@@ -1,7 +1,30 @@
 package ports
 
-import "cards-service/internal/core/domain"
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
 
 type AppService interface {
-	ValidateCardNumber(cardNumber string) (*domain.CardInfo, error)
+	ValidateCardNumber(ctx context.Context, cardNumber string) (*domain.CardInfo, error)
+}
+
+type BinRuleService interface {
+	CreateBinRule(ctx context.Context, rule *domain.BinRule) (*domain.BinRule, error)
+	ListBinRules(ctx context.Context, includeExpired bool) ([]domain.BinRule, error)
+	DeleteBinRule(ctx context.Context, id string) error
+}
+
+type WalletService interface {
+	AddCard(ctx context.Context, customerID string, cardNumber string, expiryMonth int, expiryYear int) (*domain.WalletCard, error)
+	ListCards(ctx context.Context, customerID string) ([]domain.WalletCard, error)
+	GetCard(ctx context.Context, customerID string, token string) (*domain.WalletCard, error)
+	RemoveCard(ctx context.Context, customerID string, token string) error
+	SetDefaultCard(ctx context.Context, customerID string, token string) (*domain.WalletCard, error)
+	ListExpiringCards(ctx context.Context, withinDays int) ([]domain.WalletCard, error)
+}
+
+type PANCipherService interface {
+	EncryptPAN(ctx context.Context, algorithm string, cardNumber string, tweak []byte) (string, error)
+	DecryptPAN(ctx context.Context, algorithm string, encrypted string, tweak []byte) (string, error)
 }
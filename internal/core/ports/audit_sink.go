@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+type AuditSink interface {
+	Record(ctx context.Context, event *domain.AuditEvent) error
+	Close() error
+}
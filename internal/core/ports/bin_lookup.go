@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+type BinLookup interface {
+	// Lookup returns the issuer range the BIN belongs to, or a NotFound error when none matches.
+	Lookup(ctx context.Context, bin string) (*domain.BinInfo, error)
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+type BinRuleStore interface {
+	Create(ctx context.Context, rule *domain.BinRule) error
+	List(ctx context.Context) ([]domain.BinRule, error)
+	// Delete removes the rule with the given ID, or returns a NotFound error.
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+// CardRule is a compiled domain.CardRule.
+type CardRule interface {
+	Name() string
+	// Evaluate reports whether the card satisfies the rule.
+	Evaluate(ctx context.Context, facts domain.CardFacts) (bool, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+// EventPublisher accepts events for delivery to downstream systems. Delivery happens later
+// and at least once, so consumers must expect duplicates, identified by event ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *domain.Event) error
+}
+
+// EventSink delivers events to a downstream system.
+type EventSink interface {
+	Send(ctx context.Context, event *domain.Event) error
+}
@@ -0,0 +1,7 @@
+package ports
+
+// FormatPreservingCipher encrypts strings of decimal digits into others of the same length.
+type FormatPreservingCipher interface {
+	Encrypt(tweak []byte, digits string) (string, error)
+	Decrypt(tweak []byte, digits string) (string, error)
+}
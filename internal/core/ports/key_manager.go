@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+// KeyManager encrypts data under a fresh data key for every record, wrapped by the current
+// version of a key encryption key. Envelopes wrapped by earlier versions can still be
+// decrypted. The purpose says what the data is, and aad binds the ciphertext to the record it
+// belongs to: decrypting needs the same aad.
+type KeyManager interface {
+	Encrypt(ctx context.Context, purpose string, plaintext []byte, aad []byte) (*domain.Envelope, error)
+	Decrypt(ctx context.Context, purpose string, envelope *domain.Envelope, aad []byte) ([]byte, error)
+	// Rewrap wraps the data key of the envelope with the current key version.
+	Rewrap(ctx context.Context, purpose string, envelope *domain.Envelope) (*domain.Envelope, error)
+	CurrentVersion() int
+}
+
+// KeyRotator adds key versions.
+type KeyRotator interface {
+	// Rotate adds a key version and makes it current.
+	Rotate(ctx context.Context) (int, error)
+}
+
+// EnvelopeStore holds envelopes, so their data keys can be rewrapped after a rotation.
+// Envelopes are identified by the ID of the record holding them.
+type EnvelopeStore interface {
+	// StaleEnvelopes returns up to limit envelopes wrapped by a key version before version.
+	StaleEnvelopes(ctx context.Context, version int, limit int) (map[string]*domain.Envelope, error)
+	// ReplaceEnvelope stores the rewrapped envelope of a record, unless its envelope is no
+	// longer old.
+	ReplaceEnvelope(ctx context.Context, id string, old *domain.Envelope, rewrapped *domain.Envelope) error
+}
@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Lease elects the one replica that runs a scheduled job. The holder keeps a lease until it
+// releases it or the TTL passes, and may renew it by acquiring it again.
+type Lease interface {
+	// Acquire reports whether holder now has the lease.
+	Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error)
+	// Release gives up the lease, if holder has it.
+	Release(ctx context.Context, name string, holder string) error
+}
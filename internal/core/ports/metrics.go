@@ -0,0 +1,14 @@
+package ports
+
+type ValidationMetrics interface {
+	ValidationCompleted(network string, outcome string, reason string)
+	BinLookup(hit bool)
+	RiskSignal(signal string)
+	// ValidationBatch records how many card numbers a batch validation carried.
+	ValidationBatch(size int)
+}
+
+type BinCacheMetrics interface {
+	BinCacheLookup(result string)
+	BinCacheEvicted()
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+type RateLimiter interface {
+	Allow(ctx context.Context, appID string, peer string) (*domain.LimitDecision, error)
+	Usage(ctx context.Context, appID string) (*domain.Usage, error)
+	// SetLimits replaces the policies applied from the next request on. Existing counters are kept.
+	SetLimits(limits *domain.RateLimits)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+)
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*domain.Principal, error)
+}
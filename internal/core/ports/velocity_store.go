@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+type VelocityStore interface {
+	// Add records an event for key and returns how many events the key has had within the
+	// window ending now, this one included.
+	Add(ctx context.Context, key string, now time.Time, window time.Duration) (int64, error)
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"cards-service/internal/core/domain"
+	"context"
+	"time"
+)
+
+// WalletRepository stores saved cards. Cards are scoped by app and customer: operations never
+// see the cards of another app or customer, and a missing card is a NotFound error.
+type WalletRepository interface {
+	// Add stores a card, or returns a Conflict error when the customer already saved a card
+	// with the same fingerprint and a QuotaExceeded error when they saved maxCards cards, if
+	// maxCards is positive. The card becomes the default when the customer has none, which
+	// Add reports in card.Default. The checks and the insert are atomic.
+	Add(ctx context.Context, card *domain.WalletCard, maxCards int) error
+	// List returns the cards of a customer, oldest first.
+	List(ctx context.Context, appID string, customerID string) ([]domain.WalletCard, error)
+	Get(ctx context.Context, appID string, customerID string, token string) (*domain.WalletCard, error)
+	Remove(ctx context.Context, appID string, customerID string, token string) error
+	// SetDefault makes the card the default of the customer, and no other.
+	SetDefault(ctx context.Context, appID string, customerID string, token string) error
+	// ListExpiring returns the cards of an app that expire by before, those that expired
+	// included, soonest first. An empty appID lists the cards of every app.
+	ListExpiring(ctx context.Context, appID string, before time.Time) ([]domain.WalletCard, error)
+	// SetStatus changes the status of a card. An event, when given, is published with the
+	// change: either both happen or neither does.
+	SetStatus(ctx context.Context, appID string, customerID string, token string, status string, event *domain.Event) error
+}
+
+// ExpiryReporter keeps the expiry report of each app for it to collect.
+type ExpiryReporter interface {
+	Report(ctx context.Context, report *domain.ExpiryReport) error
+}
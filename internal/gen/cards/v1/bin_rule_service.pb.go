@@ -0,0 +1,580 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: cards/v1/bin_rule_service.proto
+
+package cardsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BinRuleAction int32
+
+const (
+	BinRuleAction_BIN_RULE_ACTION_UNSPECIFIED BinRuleAction = 0
+	BinRuleAction_BIN_RULE_ACTION_BLOCK       BinRuleAction = 1
+	BinRuleAction_BIN_RULE_ACTION_ALLOW       BinRuleAction = 2
+)
+
+// Enum value maps for BinRuleAction.
+var (
+	BinRuleAction_name = map[int32]string{
+		0: "BIN_RULE_ACTION_UNSPECIFIED",
+		1: "BIN_RULE_ACTION_BLOCK",
+		2: "BIN_RULE_ACTION_ALLOW",
+	}
+	BinRuleAction_value = map[string]int32{
+		"BIN_RULE_ACTION_UNSPECIFIED": 0,
+		"BIN_RULE_ACTION_BLOCK":       1,
+		"BIN_RULE_ACTION_ALLOW":       2,
+	}
+)
+
+func (x BinRuleAction) Enum() *BinRuleAction {
+	p := new(BinRuleAction)
+	*p = x
+	return p
+}
+
+func (x BinRuleAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BinRuleAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_cards_v1_bin_rule_service_proto_enumTypes[0].Descriptor()
+}
+
+func (BinRuleAction) Type() protoreflect.EnumType {
+	return &file_cards_v1_bin_rule_service_proto_enumTypes[0]
+}
+
+func (x BinRuleAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BinRuleAction.Descriptor instead.
+func (BinRuleAction) EnumDescriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{0}
+}
+
+type BinRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// First and last BIN prefix of the range, inclusive. Both have the same number of digits,
+	// between 1 and 8.
+	RangeStart string        `protobuf:"bytes,2,opt,name=range_start,json=rangeStart,proto3" json:"range_start,omitempty"`
+	RangeEnd   string        `protobuf:"bytes,3,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	Action     BinRuleAction `protobuf:"varint,4,opt,name=action,proto3,enum=cards.v1.BinRuleAction" json:"action,omitempty"`
+	Reason     string        `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Unset when the rule never expires.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// App or subject that created the rule.
+	CreatedBy     string `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BinRule) Reset() {
+	*x = BinRule{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BinRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinRule) ProtoMessage() {}
+
+func (x *BinRule) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BinRule.ProtoReflect.Descriptor instead.
+func (*BinRule) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BinRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BinRule) GetRangeStart() string {
+	if x != nil {
+		return x.RangeStart
+	}
+	return ""
+}
+
+func (x *BinRule) GetRangeEnd() string {
+	if x != nil {
+		return x.RangeEnd
+	}
+	return ""
+}
+
+func (x *BinRule) GetAction() BinRuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return BinRuleAction_BIN_RULE_ACTION_UNSPECIFIED
+}
+
+func (x *BinRule) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BinRule) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *BinRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *BinRule) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+type CreateBinRuleRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RangeStart string                 `protobuf:"bytes,1,opt,name=range_start,json=rangeStart,proto3" json:"range_start,omitempty"`
+	// Defaults to range_start, for a rule covering a single prefix.
+	RangeEnd      string                 `protobuf:"bytes,2,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	Action        BinRuleAction          `protobuf:"varint,3,opt,name=action,proto3,enum=cards.v1.BinRuleAction" json:"action,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBinRuleRequest) Reset() {
+	*x = CreateBinRuleRequest{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBinRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBinRuleRequest) ProtoMessage() {}
+
+func (x *CreateBinRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBinRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateBinRuleRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateBinRuleRequest) GetRangeStart() string {
+	if x != nil {
+		return x.RangeStart
+	}
+	return ""
+}
+
+func (x *CreateBinRuleRequest) GetRangeEnd() string {
+	if x != nil {
+		return x.RangeEnd
+	}
+	return ""
+}
+
+func (x *CreateBinRuleRequest) GetAction() BinRuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return BinRuleAction_BIN_RULE_ACTION_UNSPECIFIED
+}
+
+func (x *CreateBinRuleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateBinRuleRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateBinRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *BinRule               `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBinRuleResponse) Reset() {
+	*x = CreateBinRuleResponse{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBinRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBinRuleResponse) ProtoMessage() {}
+
+func (x *CreateBinRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBinRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateBinRuleResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateBinRuleResponse) GetRule() *BinRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListBinRulesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IncludeExpired bool                   `protobuf:"varint,1,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListBinRulesRequest) Reset() {
+	*x = ListBinRulesRequest{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBinRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBinRulesRequest) ProtoMessage() {}
+
+func (x *ListBinRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBinRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListBinRulesRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListBinRulesRequest) GetIncludeExpired() bool {
+	if x != nil {
+		return x.IncludeExpired
+	}
+	return false
+}
+
+type ListBinRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*BinRule             `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBinRulesResponse) Reset() {
+	*x = ListBinRulesResponse{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBinRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBinRulesResponse) ProtoMessage() {}
+
+func (x *ListBinRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBinRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListBinRulesResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListBinRulesResponse) GetRules() []*BinRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DeleteBinRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBinRuleRequest) Reset() {
+	*x = DeleteBinRuleRequest{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBinRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBinRuleRequest) ProtoMessage() {}
+
+func (x *DeleteBinRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBinRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBinRuleRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteBinRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteBinRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBinRuleResponse) Reset() {
+	*x = DeleteBinRuleResponse{}
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBinRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBinRuleResponse) ProtoMessage() {}
+
+func (x *DeleteBinRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_bin_rule_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBinRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBinRuleResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_bin_rule_service_proto_rawDescGZIP(), []int{6}
+}
+
+var File_cards_v1_bin_rule_service_proto protoreflect.FileDescriptor
+
+const file_cards_v1_bin_rule_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1fcards/v1/bin_rule_service.proto\x12\bcards.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb5\x02\n" +
+	"\aBinRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vrange_start\x18\x02 \x01(\tR\n" +
+	"rangeStart\x12\x1b\n" +
+	"\trange_end\x18\x03 \x01(\tR\brangeEnd\x12/\n" +
+	"\x06action\x18\x04 \x01(\x0e2\x17.cards.v1.BinRuleActionR\x06action\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\"\xd8\x01\n" +
+	"\x14CreateBinRuleRequest\x12\x1f\n" +
+	"\vrange_start\x18\x01 \x01(\tR\n" +
+	"rangeStart\x12\x1b\n" +
+	"\trange_end\x18\x02 \x01(\tR\brangeEnd\x12/\n" +
+	"\x06action\x18\x03 \x01(\x0e2\x17.cards.v1.BinRuleActionR\x06action\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\">\n" +
+	"\x15CreateBinRuleResponse\x12%\n" +
+	"\x04rule\x18\x01 \x01(\v2\x11.cards.v1.BinRuleR\x04rule\">\n" +
+	"\x13ListBinRulesRequest\x12'\n" +
+	"\x0finclude_expired\x18\x01 \x01(\bR\x0eincludeExpired\"?\n" +
+	"\x14ListBinRulesResponse\x12'\n" +
+	"\x05rules\x18\x01 \x03(\v2\x11.cards.v1.BinRuleR\x05rules\"&\n" +
+	"\x14DeleteBinRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteBinRuleResponse*f\n" +
+	"\rBinRuleAction\x12\x1f\n" +
+	"\x1bBIN_RULE_ACTION_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15BIN_RULE_ACTION_BLOCK\x10\x01\x12\x19\n" +
+	"\x15BIN_RULE_ACTION_ALLOW\x10\x022\x83\x02\n" +
+	"\x0eBinRuleService\x12P\n" +
+	"\rCreateBinRule\x12\x1e.cards.v1.CreateBinRuleRequest\x1a\x1f.cards.v1.CreateBinRuleResponse\x12M\n" +
+	"\fListBinRules\x12\x1d.cards.v1.ListBinRulesRequest\x1a\x1e.cards.v1.ListBinRulesResponse\x12P\n" +
+	"\rDeleteBinRule\x12\x1e.cards.v1.DeleteBinRuleRequest\x1a\x1f.cards.v1.DeleteBinRuleResponseB-Z+cards-service/internal/gen/cards/v1;cardsv1b\x06proto3"
+
+var (
+	file_cards_v1_bin_rule_service_proto_rawDescOnce sync.Once
+	file_cards_v1_bin_rule_service_proto_rawDescData []byte
+)
+
+func file_cards_v1_bin_rule_service_proto_rawDescGZIP() []byte {
+	file_cards_v1_bin_rule_service_proto_rawDescOnce.Do(func() {
+		file_cards_v1_bin_rule_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cards_v1_bin_rule_service_proto_rawDesc), len(file_cards_v1_bin_rule_service_proto_rawDesc)))
+	})
+	return file_cards_v1_bin_rule_service_proto_rawDescData
+}
+
+var file_cards_v1_bin_rule_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_cards_v1_bin_rule_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_cards_v1_bin_rule_service_proto_goTypes = []any{
+	(BinRuleAction)(0),            // 0: cards.v1.BinRuleAction
+	(*BinRule)(nil),               // 1: cards.v1.BinRule
+	(*CreateBinRuleRequest)(nil),  // 2: cards.v1.CreateBinRuleRequest
+	(*CreateBinRuleResponse)(nil), // 3: cards.v1.CreateBinRuleResponse
+	(*ListBinRulesRequest)(nil),   // 4: cards.v1.ListBinRulesRequest
+	(*ListBinRulesResponse)(nil),  // 5: cards.v1.ListBinRulesResponse
+	(*DeleteBinRuleRequest)(nil),  // 6: cards.v1.DeleteBinRuleRequest
+	(*DeleteBinRuleResponse)(nil), // 7: cards.v1.DeleteBinRuleResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_cards_v1_bin_rule_service_proto_depIdxs = []int32{
+	0,  // 0: cards.v1.BinRule.action:type_name -> cards.v1.BinRuleAction
+	8,  // 1: cards.v1.BinRule.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: cards.v1.BinRule.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: cards.v1.CreateBinRuleRequest.action:type_name -> cards.v1.BinRuleAction
+	8,  // 4: cards.v1.CreateBinRuleRequest.expires_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: cards.v1.CreateBinRuleResponse.rule:type_name -> cards.v1.BinRule
+	1,  // 6: cards.v1.ListBinRulesResponse.rules:type_name -> cards.v1.BinRule
+	2,  // 7: cards.v1.BinRuleService.CreateBinRule:input_type -> cards.v1.CreateBinRuleRequest
+	4,  // 8: cards.v1.BinRuleService.ListBinRules:input_type -> cards.v1.ListBinRulesRequest
+	6,  // 9: cards.v1.BinRuleService.DeleteBinRule:input_type -> cards.v1.DeleteBinRuleRequest
+	3,  // 10: cards.v1.BinRuleService.CreateBinRule:output_type -> cards.v1.CreateBinRuleResponse
+	5,  // 11: cards.v1.BinRuleService.ListBinRules:output_type -> cards.v1.ListBinRulesResponse
+	7,  // 12: cards.v1.BinRuleService.DeleteBinRule:output_type -> cards.v1.DeleteBinRuleResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_cards_v1_bin_rule_service_proto_init() }
+func file_cards_v1_bin_rule_service_proto_init() {
+	if File_cards_v1_bin_rule_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cards_v1_bin_rule_service_proto_rawDesc), len(file_cards_v1_bin_rule_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cards_v1_bin_rule_service_proto_goTypes,
+		DependencyIndexes: file_cards_v1_bin_rule_service_proto_depIdxs,
+		EnumInfos:         file_cards_v1_bin_rule_service_proto_enumTypes,
+		MessageInfos:      file_cards_v1_bin_rule_service_proto_msgTypes,
+	}.Build()
+	File_cards_v1_bin_rule_service_proto = out.File
+	file_cards_v1_bin_rule_service_proto_goTypes = nil
+	file_cards_v1_bin_rule_service_proto_depIdxs = nil
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: cards/v1/bin_rule_service.proto
+
+package cardsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BinRuleService_CreateBinRule_FullMethodName = "/cards.v1.BinRuleService/CreateBinRule"
+	BinRuleService_ListBinRules_FullMethodName  = "/cards.v1.BinRuleService/ListBinRules"
+	BinRuleService_DeleteBinRule_FullMethodName = "/cards.v1.BinRuleService/DeleteBinRule"
+)
+
+// BinRuleServiceClient is the client API for BinRuleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BinRuleService manages BIN ranges that are blocked, or allowed even though they are not in
+// the BIN data, during card validation.
+type BinRuleServiceClient interface {
+	CreateBinRule(ctx context.Context, in *CreateBinRuleRequest, opts ...grpc.CallOption) (*CreateBinRuleResponse, error)
+	ListBinRules(ctx context.Context, in *ListBinRulesRequest, opts ...grpc.CallOption) (*ListBinRulesResponse, error)
+	DeleteBinRule(ctx context.Context, in *DeleteBinRuleRequest, opts ...grpc.CallOption) (*DeleteBinRuleResponse, error)
+}
+
+type binRuleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBinRuleServiceClient(cc grpc.ClientConnInterface) BinRuleServiceClient {
+	return &binRuleServiceClient{cc}
+}
+
+func (c *binRuleServiceClient) CreateBinRule(ctx context.Context, in *CreateBinRuleRequest, opts ...grpc.CallOption) (*CreateBinRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBinRuleResponse)
+	err := c.cc.Invoke(ctx, BinRuleService_CreateBinRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binRuleServiceClient) ListBinRules(ctx context.Context, in *ListBinRulesRequest, opts ...grpc.CallOption) (*ListBinRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBinRulesResponse)
+	err := c.cc.Invoke(ctx, BinRuleService_ListBinRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binRuleServiceClient) DeleteBinRule(ctx context.Context, in *DeleteBinRuleRequest, opts ...grpc.CallOption) (*DeleteBinRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBinRuleResponse)
+	err := c.cc.Invoke(ctx, BinRuleService_DeleteBinRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BinRuleServiceServer is the server API for BinRuleService service.
+// All implementations must embed UnimplementedBinRuleServiceServer
+// for forward compatibility.
+//
+// BinRuleService manages BIN ranges that are blocked, or allowed even though they are not in
+// the BIN data, during card validation.
+type BinRuleServiceServer interface {
+	CreateBinRule(context.Context, *CreateBinRuleRequest) (*CreateBinRuleResponse, error)
+	ListBinRules(context.Context, *ListBinRulesRequest) (*ListBinRulesResponse, error)
+	DeleteBinRule(context.Context, *DeleteBinRuleRequest) (*DeleteBinRuleResponse, error)
+	mustEmbedUnimplementedBinRuleServiceServer()
+}
+
+// UnimplementedBinRuleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBinRuleServiceServer struct{}
+
+func (UnimplementedBinRuleServiceServer) CreateBinRule(context.Context, *CreateBinRuleRequest) (*CreateBinRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBinRule not implemented")
+}
+func (UnimplementedBinRuleServiceServer) ListBinRules(context.Context, *ListBinRulesRequest) (*ListBinRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBinRules not implemented")
+}
+func (UnimplementedBinRuleServiceServer) DeleteBinRule(context.Context, *DeleteBinRuleRequest) (*DeleteBinRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBinRule not implemented")
+}
+func (UnimplementedBinRuleServiceServer) mustEmbedUnimplementedBinRuleServiceServer() {}
+func (UnimplementedBinRuleServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeBinRuleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BinRuleServiceServer will
+// result in compilation errors.
+type UnsafeBinRuleServiceServer interface {
+	mustEmbedUnimplementedBinRuleServiceServer()
+}
+
+func RegisterBinRuleServiceServer(s grpc.ServiceRegistrar, srv BinRuleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBinRuleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BinRuleService_ServiceDesc, srv)
+}
+
+func _BinRuleService_CreateBinRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBinRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinRuleServiceServer).CreateBinRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinRuleService_CreateBinRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinRuleServiceServer).CreateBinRule(ctx, req.(*CreateBinRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinRuleService_ListBinRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBinRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinRuleServiceServer).ListBinRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinRuleService_ListBinRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinRuleServiceServer).ListBinRules(ctx, req.(*ListBinRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BinRuleService_DeleteBinRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBinRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinRuleServiceServer).DeleteBinRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BinRuleService_DeleteBinRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinRuleServiceServer).DeleteBinRule(ctx, req.(*DeleteBinRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BinRuleService_ServiceDesc is the grpc.ServiceDesc for BinRuleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BinRuleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cards.v1.BinRuleService",
+	HandlerType: (*BinRuleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateBinRule",
+			Handler:    _BinRuleService_CreateBinRule_Handler,
+		},
+		{
+			MethodName: "ListBinRules",
+			Handler:    _BinRuleService_ListBinRules_Handler,
+		},
+		{
+			MethodName: "DeleteBinRule",
+			Handler:    _BinRuleService_DeleteBinRule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cards/v1/bin_rule_service.proto",
+}
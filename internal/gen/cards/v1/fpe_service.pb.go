@@ -0,0 +1,372 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: cards/v1/fpe_service.proto
+
+package cardsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FPEAlgorithm int32
+
+const (
+	// Same as FPE_ALGORITHM_FF1.
+	FPEAlgorithm_FPE_ALGORITHM_UNSPECIFIED FPEAlgorithm = 0
+	FPEAlgorithm_FPE_ALGORITHM_FF1         FPEAlgorithm = 1
+	FPEAlgorithm_FPE_ALGORITHM_FF3_1       FPEAlgorithm = 2
+)
+
+// Enum value maps for FPEAlgorithm.
+var (
+	FPEAlgorithm_name = map[int32]string{
+		0: "FPE_ALGORITHM_UNSPECIFIED",
+		1: "FPE_ALGORITHM_FF1",
+		2: "FPE_ALGORITHM_FF3_1",
+	}
+	FPEAlgorithm_value = map[string]int32{
+		"FPE_ALGORITHM_UNSPECIFIED": 0,
+		"FPE_ALGORITHM_FF1":         1,
+		"FPE_ALGORITHM_FF3_1":       2,
+	}
+)
+
+func (x FPEAlgorithm) Enum() *FPEAlgorithm {
+	p := new(FPEAlgorithm)
+	*p = x
+	return p
+}
+
+func (x FPEAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FPEAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_cards_v1_fpe_service_proto_enumTypes[0].Descriptor()
+}
+
+func (FPEAlgorithm) Type() protoreflect.EnumType {
+	return &file_cards_v1_fpe_service_proto_enumTypes[0]
+}
+
+func (x FPEAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FPEAlgorithm.Descriptor instead.
+func (FPEAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_cards_v1_fpe_service_proto_rawDescGZIP(), []int{0}
+}
+
+type EncryptPANRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 16 to 19 digits.
+	CardNumber string       `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	Algorithm  FPEAlgorithm `protobuf:"varint,2,opt,name=algorithm,proto3,enum=cards.v1.FPEAlgorithm" json:"algorithm,omitempty"`
+	// Must be given again to decrypt. Up to 256 bytes for FF1, and 7 bytes or none for FF3-1.
+	Tweak         []byte `protobuf:"bytes,3,opt,name=tweak,proto3" json:"tweak,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptPANRequest) Reset() {
+	*x = EncryptPANRequest{}
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptPANRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptPANRequest) ProtoMessage() {}
+
+func (x *EncryptPANRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptPANRequest.ProtoReflect.Descriptor instead.
+func (*EncryptPANRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_fpe_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EncryptPANRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *EncryptPANRequest) GetAlgorithm() FPEAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return FPEAlgorithm_FPE_ALGORITHM_UNSPECIFIED
+}
+
+func (x *EncryptPANRequest) GetTweak() []byte {
+	if x != nil {
+		return x.Tweak
+	}
+	return nil
+}
+
+type EncryptPANResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	EncryptedCardNumber string                 `protobuf:"bytes,1,opt,name=encrypted_card_number,json=encryptedCardNumber,proto3" json:"encrypted_card_number,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *EncryptPANResponse) Reset() {
+	*x = EncryptPANResponse{}
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptPANResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptPANResponse) ProtoMessage() {}
+
+func (x *EncryptPANResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EncryptPANResponse.ProtoReflect.Descriptor instead.
+func (*EncryptPANResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_fpe_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EncryptPANResponse) GetEncryptedCardNumber() string {
+	if x != nil {
+		return x.EncryptedCardNumber
+	}
+	return ""
+}
+
+type DecryptPANRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	EncryptedCardNumber string                 `protobuf:"bytes,1,opt,name=encrypted_card_number,json=encryptedCardNumber,proto3" json:"encrypted_card_number,omitempty"`
+	Algorithm           FPEAlgorithm           `protobuf:"varint,2,opt,name=algorithm,proto3,enum=cards.v1.FPEAlgorithm" json:"algorithm,omitempty"`
+	Tweak               []byte                 `protobuf:"bytes,3,opt,name=tweak,proto3" json:"tweak,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DecryptPANRequest) Reset() {
+	*x = DecryptPANRequest{}
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecryptPANRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptPANRequest) ProtoMessage() {}
+
+func (x *DecryptPANRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptPANRequest.ProtoReflect.Descriptor instead.
+func (*DecryptPANRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_fpe_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DecryptPANRequest) GetEncryptedCardNumber() string {
+	if x != nil {
+		return x.EncryptedCardNumber
+	}
+	return ""
+}
+
+func (x *DecryptPANRequest) GetAlgorithm() FPEAlgorithm {
+	if x != nil {
+		return x.Algorithm
+	}
+	return FPEAlgorithm_FPE_ALGORITHM_UNSPECIFIED
+}
+
+func (x *DecryptPANRequest) GetTweak() []byte {
+	if x != nil {
+		return x.Tweak
+	}
+	return nil
+}
+
+type DecryptPANResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardNumber    string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecryptPANResponse) Reset() {
+	*x = DecryptPANResponse{}
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecryptPANResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptPANResponse) ProtoMessage() {}
+
+func (x *DecryptPANResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_fpe_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptPANResponse.ProtoReflect.Descriptor instead.
+func (*DecryptPANResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_fpe_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DecryptPANResponse) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+var File_cards_v1_fpe_service_proto protoreflect.FileDescriptor
+
+const file_cards_v1_fpe_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1acards/v1/fpe_service.proto\x12\bcards.v1\"\x80\x01\n" +
+	"\x11EncryptPANRequest\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber\x124\n" +
+	"\talgorithm\x18\x02 \x01(\x0e2\x16.cards.v1.FPEAlgorithmR\talgorithm\x12\x14\n" +
+	"\x05tweak\x18\x03 \x01(\fR\x05tweak\"H\n" +
+	"\x12EncryptPANResponse\x122\n" +
+	"\x15encrypted_card_number\x18\x01 \x01(\tR\x13encryptedCardNumber\"\x93\x01\n" +
+	"\x11DecryptPANRequest\x122\n" +
+	"\x15encrypted_card_number\x18\x01 \x01(\tR\x13encryptedCardNumber\x124\n" +
+	"\talgorithm\x18\x02 \x01(\x0e2\x16.cards.v1.FPEAlgorithmR\talgorithm\x12\x14\n" +
+	"\x05tweak\x18\x03 \x01(\fR\x05tweak\"5\n" +
+	"\x12DecryptPANResponse\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber*]\n" +
+	"\fFPEAlgorithm\x12\x1d\n" +
+	"\x19FPE_ALGORITHM_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11FPE_ALGORITHM_FF1\x10\x01\x12\x17\n" +
+	"\x13FPE_ALGORITHM_FF3_1\x10\x022\x9e\x01\n" +
+	"\n" +
+	"FPEService\x12G\n" +
+	"\n" +
+	"EncryptPAN\x12\x1b.cards.v1.EncryptPANRequest\x1a\x1c.cards.v1.EncryptPANResponse\x12G\n" +
+	"\n" +
+	"DecryptPAN\x12\x1b.cards.v1.DecryptPANRequest\x1a\x1c.cards.v1.DecryptPANResponseB-Z+cards-service/internal/gen/cards/v1;cardsv1b\x06proto3"
+
+var (
+	file_cards_v1_fpe_service_proto_rawDescOnce sync.Once
+	file_cards_v1_fpe_service_proto_rawDescData []byte
+)
+
+func file_cards_v1_fpe_service_proto_rawDescGZIP() []byte {
+	file_cards_v1_fpe_service_proto_rawDescOnce.Do(func() {
+		file_cards_v1_fpe_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cards_v1_fpe_service_proto_rawDesc), len(file_cards_v1_fpe_service_proto_rawDesc)))
+	})
+	return file_cards_v1_fpe_service_proto_rawDescData
+}
+
+var file_cards_v1_fpe_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_cards_v1_fpe_service_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_cards_v1_fpe_service_proto_goTypes = []any{
+	(FPEAlgorithm)(0),          // 0: cards.v1.FPEAlgorithm
+	(*EncryptPANRequest)(nil),  // 1: cards.v1.EncryptPANRequest
+	(*EncryptPANResponse)(nil), // 2: cards.v1.EncryptPANResponse
+	(*DecryptPANRequest)(nil),  // 3: cards.v1.DecryptPANRequest
+	(*DecryptPANResponse)(nil), // 4: cards.v1.DecryptPANResponse
+}
+var file_cards_v1_fpe_service_proto_depIdxs = []int32{
+	0, // 0: cards.v1.EncryptPANRequest.algorithm:type_name -> cards.v1.FPEAlgorithm
+	0, // 1: cards.v1.DecryptPANRequest.algorithm:type_name -> cards.v1.FPEAlgorithm
+	1, // 2: cards.v1.FPEService.EncryptPAN:input_type -> cards.v1.EncryptPANRequest
+	3, // 3: cards.v1.FPEService.DecryptPAN:input_type -> cards.v1.DecryptPANRequest
+	2, // 4: cards.v1.FPEService.EncryptPAN:output_type -> cards.v1.EncryptPANResponse
+	4, // 5: cards.v1.FPEService.DecryptPAN:output_type -> cards.v1.DecryptPANResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_cards_v1_fpe_service_proto_init() }
+func file_cards_v1_fpe_service_proto_init() {
+	if File_cards_v1_fpe_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cards_v1_fpe_service_proto_rawDesc), len(file_cards_v1_fpe_service_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cards_v1_fpe_service_proto_goTypes,
+		DependencyIndexes: file_cards_v1_fpe_service_proto_depIdxs,
+		EnumInfos:         file_cards_v1_fpe_service_proto_enumTypes,
+		MessageInfos:      file_cards_v1_fpe_service_proto_msgTypes,
+	}.Build()
+	File_cards_v1_fpe_service_proto = out.File
+	file_cards_v1_fpe_service_proto_goTypes = nil
+	file_cards_v1_fpe_service_proto_depIdxs = nil
+}
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: cards/v1/fpe_service.proto
+
+package cardsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FPEService_EncryptPAN_FullMethodName = "/cards.v1.FPEService/EncryptPAN"
+	FPEService_DecryptPAN_FullMethodName = "/cards.v1.FPEService/DecryptPAN"
+)
+
+// FPEServiceClient is the client API for FPEService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FPEService encrypts card numbers into others of the same length that keep their first six
+// and last four digits, so they fit wherever a card number does. The digits in between are
+// encrypted with FF1 or FF3-1 of NIST SP 800-38G under the service key, and the results are
+// not Luhn-valid in general.
+type FPEServiceClient interface {
+	EncryptPAN(ctx context.Context, in *EncryptPANRequest, opts ...grpc.CallOption) (*EncryptPANResponse, error)
+	DecryptPAN(ctx context.Context, in *DecryptPANRequest, opts ...grpc.CallOption) (*DecryptPANResponse, error)
+}
+
+type fPEServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFPEServiceClient(cc grpc.ClientConnInterface) FPEServiceClient {
+	return &fPEServiceClient{cc}
+}
+
+func (c *fPEServiceClient) EncryptPAN(ctx context.Context, in *EncryptPANRequest, opts ...grpc.CallOption) (*EncryptPANResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EncryptPANResponse)
+	err := c.cc.Invoke(ctx, FPEService_EncryptPAN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPEServiceClient) DecryptPAN(ctx context.Context, in *DecryptPANRequest, opts ...grpc.CallOption) (*DecryptPANResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecryptPANResponse)
+	err := c.cc.Invoke(ctx, FPEService_DecryptPAN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FPEServiceServer is the server API for FPEService service.
+// All implementations must embed UnimplementedFPEServiceServer
+// for forward compatibility.
+//
+// FPEService encrypts card numbers into others of the same length that keep their first six
+// and last four digits, so they fit wherever a card number does. The digits in between are
+// encrypted with FF1 or FF3-1 of NIST SP 800-38G under the service key, and the results are
+// not Luhn-valid in general.
+type FPEServiceServer interface {
+	EncryptPAN(context.Context, *EncryptPANRequest) (*EncryptPANResponse, error)
+	DecryptPAN(context.Context, *DecryptPANRequest) (*DecryptPANResponse, error)
+	mustEmbedUnimplementedFPEServiceServer()
+}
+
+// UnimplementedFPEServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFPEServiceServer struct{}
+
+func (UnimplementedFPEServiceServer) EncryptPAN(context.Context, *EncryptPANRequest) (*EncryptPANResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncryptPAN not implemented")
+}
+func (UnimplementedFPEServiceServer) DecryptPAN(context.Context, *DecryptPANRequest) (*DecryptPANResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecryptPAN not implemented")
+}
+func (UnimplementedFPEServiceServer) mustEmbedUnimplementedFPEServiceServer() {}
+func (UnimplementedFPEServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeFPEServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FPEServiceServer will
+// result in compilation errors.
+type UnsafeFPEServiceServer interface {
+	mustEmbedUnimplementedFPEServiceServer()
+}
+
+func RegisterFPEServiceServer(s grpc.ServiceRegistrar, srv FPEServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFPEServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FPEService_ServiceDesc, srv)
+}
+
+func _FPEService_EncryptPAN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptPANRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPEServiceServer).EncryptPAN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FPEService_EncryptPAN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPEServiceServer).EncryptPAN(ctx, req.(*EncryptPANRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPEService_DecryptPAN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptPANRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPEServiceServer).DecryptPAN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FPEService_DecryptPAN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPEServiceServer).DecryptPAN(ctx, req.(*DecryptPANRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FPEService_ServiceDesc is the grpc.ServiceDesc for FPEService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FPEService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cards.v1.FPEService",
+	HandlerType: (*FPEServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EncryptPAN",
+			Handler:    _FPEService_EncryptPAN_Handler,
+		},
+		{
+			MethodName: "DecryptPAN",
+			Handler:    _FPEService_DecryptPAN_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cards/v1/fpe_service.proto",
+}
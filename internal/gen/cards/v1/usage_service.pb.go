@@ -0,0 +1,272 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: cards/v1/usage_service.proto
+
+package cardsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	mi := &file_cards_v1_usage_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_usage_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_usage_service_proto_rawDescGZIP(), []int{0}
+}
+
+type QuotaUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Used  int64                  `protobuf:"varint,1,opt,name=used,proto3" json:"used,omitempty"`
+	// A limit of zero means the quota is not enforced.
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	ResetsAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotaUsage) Reset() {
+	*x = QuotaUsage{}
+	mi := &file_cards_v1_usage_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotaUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotaUsage) ProtoMessage() {}
+
+func (x *QuotaUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_usage_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotaUsage.ProtoReflect.Descriptor instead.
+func (*QuotaUsage) Descriptor() ([]byte, []int) {
+	return file_cards_v1_usage_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *QuotaUsage) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QuotaUsage) GetResetsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return nil
+}
+
+type GetUsageResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AppId             string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	RequestsPerSecond float64                `protobuf:"fixed64,2,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	Burst             int32                  `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
+	Daily             *QuotaUsage            `protobuf:"bytes,4,opt,name=daily,proto3" json:"daily,omitempty"`
+	Monthly           *QuotaUsage            `protobuf:"bytes,5,opt,name=monthly,proto3" json:"monthly,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	mi := &file_cards_v1_usage_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_usage_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_usage_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetUsageResponse) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetUsageResponse) GetRequestsPerSecond() float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *GetUsageResponse) GetDaily() *QuotaUsage {
+	if x != nil {
+		return x.Daily
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetMonthly() *QuotaUsage {
+	if x != nil {
+		return x.Monthly
+	}
+	return nil
+}
+
+var File_cards_v1_usage_service_proto protoreflect.FileDescriptor
+
+const file_cards_v1_usage_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1ccards/v1/usage_service.proto\x12\bcards.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x11\n" +
+	"\x0fGetUsageRequest\"o\n" +
+	"\n" +
+	"QuotaUsage\x12\x12\n" +
+	"\x04used\x18\x01 \x01(\x03R\x04used\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x127\n" +
+	"\tresets_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bresetsAt\"\xcb\x01\n" +
+	"\x10GetUsageResponse\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12.\n" +
+	"\x13requests_per_second\x18\x02 \x01(\x01R\x11requestsPerSecond\x12\x14\n" +
+	"\x05burst\x18\x03 \x01(\x05R\x05burst\x12*\n" +
+	"\x05daily\x18\x04 \x01(\v2\x14.cards.v1.QuotaUsageR\x05daily\x12.\n" +
+	"\amonthly\x18\x05 \x01(\v2\x14.cards.v1.QuotaUsageR\amonthly2Q\n" +
+	"\fUsageService\x12A\n" +
+	"\bGetUsage\x12\x19.cards.v1.GetUsageRequest\x1a\x1a.cards.v1.GetUsageResponseB-Z+cards-service/internal/gen/cards/v1;cardsv1b\x06proto3"
+
+var (
+	file_cards_v1_usage_service_proto_rawDescOnce sync.Once
+	file_cards_v1_usage_service_proto_rawDescData []byte
+)
+
+func file_cards_v1_usage_service_proto_rawDescGZIP() []byte {
+	file_cards_v1_usage_service_proto_rawDescOnce.Do(func() {
+		file_cards_v1_usage_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cards_v1_usage_service_proto_rawDesc), len(file_cards_v1_usage_service_proto_rawDesc)))
+	})
+	return file_cards_v1_usage_service_proto_rawDescData
+}
+
+var file_cards_v1_usage_service_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_cards_v1_usage_service_proto_goTypes = []any{
+	(*GetUsageRequest)(nil),       // 0: cards.v1.GetUsageRequest
+	(*QuotaUsage)(nil),            // 1: cards.v1.QuotaUsage
+	(*GetUsageResponse)(nil),      // 2: cards.v1.GetUsageResponse
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_cards_v1_usage_service_proto_depIdxs = []int32{
+	3, // 0: cards.v1.QuotaUsage.resets_at:type_name -> google.protobuf.Timestamp
+	1, // 1: cards.v1.GetUsageResponse.daily:type_name -> cards.v1.QuotaUsage
+	1, // 2: cards.v1.GetUsageResponse.monthly:type_name -> cards.v1.QuotaUsage
+	0, // 3: cards.v1.UsageService.GetUsage:input_type -> cards.v1.GetUsageRequest
+	2, // 4: cards.v1.UsageService.GetUsage:output_type -> cards.v1.GetUsageResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_cards_v1_usage_service_proto_init() }
+func file_cards_v1_usage_service_proto_init() {
+	if File_cards_v1_usage_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cards_v1_usage_service_proto_rawDesc), len(file_cards_v1_usage_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cards_v1_usage_service_proto_goTypes,
+		DependencyIndexes: file_cards_v1_usage_service_proto_depIdxs,
+		MessageInfos:      file_cards_v1_usage_service_proto_msgTypes,
+	}.Build()
+	File_cards_v1_usage_service_proto = out.File
+	file_cards_v1_usage_service_proto_goTypes = nil
+	file_cards_v1_usage_service_proto_depIdxs = nil
+}
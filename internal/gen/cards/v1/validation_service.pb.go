@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: cards/v1/validation_service.proto
+
+package cardsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ValidateCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardNumber    string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCardRequest) Reset() {
+	*x = ValidateCardRequest{}
+	mi := &file_cards_v1_validation_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCardRequest) ProtoMessage() {}
+
+func (x *ValidateCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_validation_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCardRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCardRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_validation_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ValidateCardRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+type ValidateCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardNumber    string                 `protobuf:"bytes,1,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	ProviderName  string                 `protobuf:"bytes,2,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	ProviderBadge string                 `protobuf:"bytes,3,opt,name=provider_badge,json=providerBadge,proto3" json:"provider_badge,omitempty"`
+	// The BIN data set that identified the card: provider, or local when the provider could
+	// not answer. Empty when the BIN is not known.
+	BinSource string `protobuf:"bytes,4,opt,name=bin_source,json=binSource,proto3" json:"bin_source,omitempty"`
+	// Raised by velocity checks, such as card_velocity_exceeded.
+	RiskSignals []string `protobuf:"bytes,5,rep,name=risk_signals,json=riskSignals,proto3" json:"risk_signals,omitempty"`
+	// False when the policy of the calling app does not accept the card.
+	Accepted bool `protobuf:"varint,6,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Why the policy of the calling app does not accept the card.
+	PolicyReason string `protobuf:"bytes,7,opt,name=policy_reason,json=policyReason,proto3" json:"policy_reason,omitempty"`
+	// The custom rule of the calling app the card failed, if any.
+	PolicyRule    string `protobuf:"bytes,8,opt,name=policy_rule,json=policyRule,proto3" json:"policy_rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCardResponse) Reset() {
+	*x = ValidateCardResponse{}
+	mi := &file_cards_v1_validation_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCardResponse) ProtoMessage() {}
+
+func (x *ValidateCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_validation_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCardResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCardResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_validation_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValidateCardResponse) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *ValidateCardResponse) GetProviderName() string {
+	if x != nil {
+		return x.ProviderName
+	}
+	return ""
+}
+
+func (x *ValidateCardResponse) GetProviderBadge() string {
+	if x != nil {
+		return x.ProviderBadge
+	}
+	return ""
+}
+
+func (x *ValidateCardResponse) GetBinSource() string {
+	if x != nil {
+		return x.BinSource
+	}
+	return ""
+}
+
+func (x *ValidateCardResponse) GetRiskSignals() []string {
+	if x != nil {
+		return x.RiskSignals
+	}
+	return nil
+}
+
+func (x *ValidateCardResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ValidateCardResponse) GetPolicyReason() string {
+	if x != nil {
+		return x.PolicyReason
+	}
+	return ""
+}
+
+func (x *ValidateCardResponse) GetPolicyRule() string {
+	if x != nil {
+		return x.PolicyRule
+	}
+	return ""
+}
+
+var File_cards_v1_validation_service_proto protoreflect.FileDescriptor
+
+const file_cards_v1_validation_service_proto_rawDesc = "" +
+	"\n" +
+	"!cards/v1/validation_service.proto\x12\bcards.v1\"6\n" +
+	"\x13ValidateCardRequest\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber\"\xa7\x02\n" +
+	"\x14ValidateCardResponse\x12\x1f\n" +
+	"\vcard_number\x18\x01 \x01(\tR\n" +
+	"cardNumber\x12#\n" +
+	"\rprovider_name\x18\x02 \x01(\tR\fproviderName\x12%\n" +
+	"\x0eprovider_badge\x18\x03 \x01(\tR\rproviderBadge\x12\x1d\n" +
+	"\n" +
+	"bin_source\x18\x04 \x01(\tR\tbinSource\x12!\n" +
+	"\frisk_signals\x18\x05 \x03(\tR\vriskSignals\x12\x1a\n" +
+	"\baccepted\x18\x06 \x01(\bR\baccepted\x12#\n" +
+	"\rpolicy_reason\x18\a \x01(\tR\fpolicyReason\x12\x1f\n" +
+	"\vpolicy_rule\x18\b \x01(\tR\n" +
+	"policyRule2b\n" +
+	"\x11ValidationService\x12M\n" +
+	"\fValidateCard\x12\x1d.cards.v1.ValidateCardRequest\x1a\x1e.cards.v1.ValidateCardResponseB-Z+cards-service/internal/gen/cards/v1;cardsv1b\x06proto3"
+
+var (
+	file_cards_v1_validation_service_proto_rawDescOnce sync.Once
+	file_cards_v1_validation_service_proto_rawDescData []byte
+)
+
+func file_cards_v1_validation_service_proto_rawDescGZIP() []byte {
+	file_cards_v1_validation_service_proto_rawDescOnce.Do(func() {
+		file_cards_v1_validation_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cards_v1_validation_service_proto_rawDesc), len(file_cards_v1_validation_service_proto_rawDesc)))
+	})
+	return file_cards_v1_validation_service_proto_rawDescData
+}
+
+var file_cards_v1_validation_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_cards_v1_validation_service_proto_goTypes = []any{
+	(*ValidateCardRequest)(nil),  // 0: cards.v1.ValidateCardRequest
+	(*ValidateCardResponse)(nil), // 1: cards.v1.ValidateCardResponse
+}
+var file_cards_v1_validation_service_proto_depIdxs = []int32{
+	0, // 0: cards.v1.ValidationService.ValidateCard:input_type -> cards.v1.ValidateCardRequest
+	1, // 1: cards.v1.ValidationService.ValidateCard:output_type -> cards.v1.ValidateCardResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_cards_v1_validation_service_proto_init() }
+func file_cards_v1_validation_service_proto_init() {
+	if File_cards_v1_validation_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cards_v1_validation_service_proto_rawDesc), len(file_cards_v1_validation_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cards_v1_validation_service_proto_goTypes,
+		DependencyIndexes: file_cards_v1_validation_service_proto_depIdxs,
+		MessageInfos:      file_cards_v1_validation_service_proto_msgTypes,
+	}.Build()
+	File_cards_v1_validation_service_proto = out.File
+	file_cards_v1_validation_service_proto_goTypes = nil
+	file_cards_v1_validation_service_proto_depIdxs = nil
+}
@@ -0,0 +1,861 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: cards/v1/wallet_service.proto
+
+package cardsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// WalletCard describes a saved card without its card number.
+type WalletCard struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stands for the card in requests about it.
+	Token       string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	CustomerId  string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	MaskedPan   string                 `protobuf:"bytes,3,opt,name=masked_pan,json=maskedPan,proto3" json:"masked_pan,omitempty"`
+	Brand       string                 `protobuf:"bytes,4,opt,name=brand,proto3" json:"brand,omitempty"`
+	Badge       string                 `protobuf:"bytes,5,opt,name=badge,proto3" json:"badge,omitempty"`
+	ExpiryMonth int32                  `protobuf:"varint,6,opt,name=expiry_month,json=expiryMonth,proto3" json:"expiry_month,omitempty"`
+	ExpiryYear  int32                  `protobuf:"varint,7,opt,name=expiry_year,json=expiryYear,proto3" json:"expiry_year,omitempty"`
+	IsDefault   bool                   `protobuf:"varint,8,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// active, expiring once the app has been told the card is about to expire, or inactive
+	// once it has expired.
+	Status        string `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletCard) Reset() {
+	*x = WalletCard{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletCard) ProtoMessage() {}
+
+func (x *WalletCard) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletCard.ProtoReflect.Descriptor instead.
+func (*WalletCard) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WalletCard) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *WalletCard) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *WalletCard) GetMaskedPan() string {
+	if x != nil {
+		return x.MaskedPan
+	}
+	return ""
+}
+
+func (x *WalletCard) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *WalletCard) GetBadge() string {
+	if x != nil {
+		return x.Badge
+	}
+	return ""
+}
+
+func (x *WalletCard) GetExpiryMonth() int32 {
+	if x != nil {
+		return x.ExpiryMonth
+	}
+	return 0
+}
+
+func (x *WalletCard) GetExpiryYear() int32 {
+	if x != nil {
+		return x.ExpiryYear
+	}
+	return 0
+}
+
+func (x *WalletCard) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *WalletCard) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WalletCard) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type AddCardRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId  string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CardNumber  string                 `protobuf:"bytes,2,opt,name=card_number,json=cardNumber,proto3" json:"card_number,omitempty"`
+	ExpiryMonth int32                  `protobuf:"varint,3,opt,name=expiry_month,json=expiryMonth,proto3" json:"expiry_month,omitempty"`
+	// Four-digit year.
+	ExpiryYear    int32 `protobuf:"varint,4,opt,name=expiry_year,json=expiryYear,proto3" json:"expiry_year,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCardRequest) Reset() {
+	*x = AddCardRequest{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCardRequest) ProtoMessage() {}
+
+func (x *AddCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCardRequest.ProtoReflect.Descriptor instead.
+func (*AddCardRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddCardRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *AddCardRequest) GetCardNumber() string {
+	if x != nil {
+		return x.CardNumber
+	}
+	return ""
+}
+
+func (x *AddCardRequest) GetExpiryMonth() int32 {
+	if x != nil {
+		return x.ExpiryMonth
+	}
+	return 0
+}
+
+func (x *AddCardRequest) GetExpiryYear() int32 {
+	if x != nil {
+		return x.ExpiryYear
+	}
+	return 0
+}
+
+type AddCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *WalletCard            `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCardResponse) Reset() {
+	*x = AddCardResponse{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCardResponse) ProtoMessage() {}
+
+func (x *AddCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCardResponse.ProtoReflect.Descriptor instead.
+func (*AddCardResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddCardResponse) GetCard() *WalletCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type ListCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsRequest) Reset() {
+	*x = ListCardsRequest{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsRequest) ProtoMessage() {}
+
+func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListCardsRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListCardsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type ListCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*WalletCard          `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsResponse) Reset() {
+	*x = ListCardsResponse{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsResponse) ProtoMessage() {}
+
+func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListCardsResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCardsResponse) GetCards() []*WalletCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type GetCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardRequest) Reset() {
+	*x = GetCardRequest{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardRequest) ProtoMessage() {}
+
+func (x *GetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardRequest.ProtoReflect.Descriptor instead.
+func (*GetCardRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCardRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *GetCardRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type GetCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *WalletCard            `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardResponse) Reset() {
+	*x = GetCardResponse{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardResponse) ProtoMessage() {}
+
+func (x *GetCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardResponse.ProtoReflect.Descriptor instead.
+func (*GetCardResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCardResponse) GetCard() *WalletCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type RemoveCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCardRequest) Reset() {
+	*x = RemoveCardRequest{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCardRequest) ProtoMessage() {}
+
+func (x *RemoveCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCardRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCardRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveCardRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *RemoveCardRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RemoveCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCardResponse) Reset() {
+	*x = RemoveCardResponse{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCardResponse) ProtoMessage() {}
+
+func (x *RemoveCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCardResponse.ProtoReflect.Descriptor instead.
+func (*RemoveCardResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{8}
+}
+
+type SetDefaultCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultCardRequest) Reset() {
+	*x = SetDefaultCardRequest{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultCardRequest) ProtoMessage() {}
+
+func (x *SetDefaultCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultCardRequest.ProtoReflect.Descriptor instead.
+func (*SetDefaultCardRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetDefaultCardRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *SetDefaultCardRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SetDefaultCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *WalletCard            `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDefaultCardResponse) Reset() {
+	*x = SetDefaultCardResponse{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDefaultCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefaultCardResponse) ProtoMessage() {}
+
+func (x *SetDefaultCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefaultCardResponse.ProtoReflect.Descriptor instead.
+func (*SetDefaultCardResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetDefaultCardResponse) GetCard() *WalletCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type ListExpiringCardsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cards expiring up to this many days from now are listed, from 0 to 366.
+	WithinDays    int32 `protobuf:"varint,1,opt,name=within_days,json=withinDays,proto3" json:"within_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringCardsRequest) Reset() {
+	*x = ListExpiringCardsRequest{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringCardsRequest) ProtoMessage() {}
+
+func (x *ListExpiringCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListExpiringCardsRequest) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListExpiringCardsRequest) GetWithinDays() int32 {
+	if x != nil {
+		return x.WithinDays
+	}
+	return 0
+}
+
+type ListExpiringCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*WalletCard          `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringCardsResponse) Reset() {
+	*x = ListExpiringCardsResponse{}
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringCardsResponse) ProtoMessage() {}
+
+func (x *ListExpiringCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cards_v1_wallet_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListExpiringCardsResponse) Descriptor() ([]byte, []int) {
+	return file_cards_v1_wallet_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListExpiringCardsResponse) GetCards() []*WalletCard {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+var File_cards_v1_wallet_service_proto protoreflect.FileDescriptor
+
+const file_cards_v1_wallet_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1dcards/v1/wallet_service.proto\x12\bcards.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc4\x02\n" +
+	"\n" +
+	"WalletCard\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"masked_pan\x18\x03 \x01(\tR\tmaskedPan\x12\x14\n" +
+	"\x05brand\x18\x04 \x01(\tR\x05brand\x12\x14\n" +
+	"\x05badge\x18\x05 \x01(\tR\x05badge\x12!\n" +
+	"\fexpiry_month\x18\x06 \x01(\x05R\vexpiryMonth\x12\x1f\n" +
+	"\vexpiry_year\x18\a \x01(\x05R\n" +
+	"expiryYear\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\b \x01(\bR\tisDefault\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\"\x96\x01\n" +
+	"\x0eAddCardRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1f\n" +
+	"\vcard_number\x18\x02 \x01(\tR\n" +
+	"cardNumber\x12!\n" +
+	"\fexpiry_month\x18\x03 \x01(\x05R\vexpiryMonth\x12\x1f\n" +
+	"\vexpiry_year\x18\x04 \x01(\x05R\n" +
+	"expiryYear\";\n" +
+	"\x0fAddCardResponse\x12(\n" +
+	"\x04card\x18\x01 \x01(\v2\x14.cards.v1.WalletCardR\x04card\"3\n" +
+	"\x10ListCardsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"?\n" +
+	"\x11ListCardsResponse\x12*\n" +
+	"\x05cards\x18\x01 \x03(\v2\x14.cards.v1.WalletCardR\x05cards\"G\n" +
+	"\x0eGetCardRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\";\n" +
+	"\x0fGetCardResponse\x12(\n" +
+	"\x04card\x18\x01 \x01(\v2\x14.cards.v1.WalletCardR\x04card\"J\n" +
+	"\x11RemoveCardRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x14\n" +
+	"\x12RemoveCardResponse\"N\n" +
+	"\x15SetDefaultCardRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"B\n" +
+	"\x16SetDefaultCardResponse\x12(\n" +
+	"\x04card\x18\x01 \x01(\v2\x14.cards.v1.WalletCardR\x04card\";\n" +
+	"\x18ListExpiringCardsRequest\x12\x1f\n" +
+	"\vwithin_days\x18\x01 \x01(\x05R\n" +
+	"withinDays\"G\n" +
+	"\x19ListExpiringCardsResponse\x12*\n" +
+	"\x05cards\x18\x01 \x03(\v2\x14.cards.v1.WalletCardR\x05cards2\xd1\x03\n" +
+	"\rWalletService\x12>\n" +
+	"\aAddCard\x12\x18.cards.v1.AddCardRequest\x1a\x19.cards.v1.AddCardResponse\x12D\n" +
+	"\tListCards\x12\x1a.cards.v1.ListCardsRequest\x1a\x1b.cards.v1.ListCardsResponse\x12>\n" +
+	"\aGetCard\x12\x18.cards.v1.GetCardRequest\x1a\x19.cards.v1.GetCardResponse\x12G\n" +
+	"\n" +
+	"RemoveCard\x12\x1b.cards.v1.RemoveCardRequest\x1a\x1c.cards.v1.RemoveCardResponse\x12S\n" +
+	"\x0eSetDefaultCard\x12\x1f.cards.v1.SetDefaultCardRequest\x1a .cards.v1.SetDefaultCardResponse\x12\\\n" +
+	"\x11ListExpiringCards\x12\".cards.v1.ListExpiringCardsRequest\x1a#.cards.v1.ListExpiringCardsResponseB-Z+cards-service/internal/gen/cards/v1;cardsv1b\x06proto3"
+
+var (
+	file_cards_v1_wallet_service_proto_rawDescOnce sync.Once
+	file_cards_v1_wallet_service_proto_rawDescData []byte
+)
+
+func file_cards_v1_wallet_service_proto_rawDescGZIP() []byte {
+	file_cards_v1_wallet_service_proto_rawDescOnce.Do(func() {
+		file_cards_v1_wallet_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cards_v1_wallet_service_proto_rawDesc), len(file_cards_v1_wallet_service_proto_rawDesc)))
+	})
+	return file_cards_v1_wallet_service_proto_rawDescData
+}
+
+var file_cards_v1_wallet_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_cards_v1_wallet_service_proto_goTypes = []any{
+	(*WalletCard)(nil),                // 0: cards.v1.WalletCard
+	(*AddCardRequest)(nil),            // 1: cards.v1.AddCardRequest
+	(*AddCardResponse)(nil),           // 2: cards.v1.AddCardResponse
+	(*ListCardsRequest)(nil),          // 3: cards.v1.ListCardsRequest
+	(*ListCardsResponse)(nil),         // 4: cards.v1.ListCardsResponse
+	(*GetCardRequest)(nil),            // 5: cards.v1.GetCardRequest
+	(*GetCardResponse)(nil),           // 6: cards.v1.GetCardResponse
+	(*RemoveCardRequest)(nil),         // 7: cards.v1.RemoveCardRequest
+	(*RemoveCardResponse)(nil),        // 8: cards.v1.RemoveCardResponse
+	(*SetDefaultCardRequest)(nil),     // 9: cards.v1.SetDefaultCardRequest
+	(*SetDefaultCardResponse)(nil),    // 10: cards.v1.SetDefaultCardResponse
+	(*ListExpiringCardsRequest)(nil),  // 11: cards.v1.ListExpiringCardsRequest
+	(*ListExpiringCardsResponse)(nil), // 12: cards.v1.ListExpiringCardsResponse
+	(*timestamppb.Timestamp)(nil),     // 13: google.protobuf.Timestamp
+}
+var file_cards_v1_wallet_service_proto_depIdxs = []int32{
+	13, // 0: cards.v1.WalletCard.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: cards.v1.AddCardResponse.card:type_name -> cards.v1.WalletCard
+	0,  // 2: cards.v1.ListCardsResponse.cards:type_name -> cards.v1.WalletCard
+	0,  // 3: cards.v1.GetCardResponse.card:type_name -> cards.v1.WalletCard
+	0,  // 4: cards.v1.SetDefaultCardResponse.card:type_name -> cards.v1.WalletCard
+	0,  // 5: cards.v1.ListExpiringCardsResponse.cards:type_name -> cards.v1.WalletCard
+	1,  // 6: cards.v1.WalletService.AddCard:input_type -> cards.v1.AddCardRequest
+	3,  // 7: cards.v1.WalletService.ListCards:input_type -> cards.v1.ListCardsRequest
+	5,  // 8: cards.v1.WalletService.GetCard:input_type -> cards.v1.GetCardRequest
+	7,  // 9: cards.v1.WalletService.RemoveCard:input_type -> cards.v1.RemoveCardRequest
+	9,  // 10: cards.v1.WalletService.SetDefaultCard:input_type -> cards.v1.SetDefaultCardRequest
+	11, // 11: cards.v1.WalletService.ListExpiringCards:input_type -> cards.v1.ListExpiringCardsRequest
+	2,  // 12: cards.v1.WalletService.AddCard:output_type -> cards.v1.AddCardResponse
+	4,  // 13: cards.v1.WalletService.ListCards:output_type -> cards.v1.ListCardsResponse
+	6,  // 14: cards.v1.WalletService.GetCard:output_type -> cards.v1.GetCardResponse
+	8,  // 15: cards.v1.WalletService.RemoveCard:output_type -> cards.v1.RemoveCardResponse
+	10, // 16: cards.v1.WalletService.SetDefaultCard:output_type -> cards.v1.SetDefaultCardResponse
+	12, // 17: cards.v1.WalletService.ListExpiringCards:output_type -> cards.v1.ListExpiringCardsResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_cards_v1_wallet_service_proto_init() }
+func file_cards_v1_wallet_service_proto_init() {
+	if File_cards_v1_wallet_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cards_v1_wallet_service_proto_rawDesc), len(file_cards_v1_wallet_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_cards_v1_wallet_service_proto_goTypes,
+		DependencyIndexes: file_cards_v1_wallet_service_proto_depIdxs,
+		MessageInfos:      file_cards_v1_wallet_service_proto_msgTypes,
+	}.Build()
+	File_cards_v1_wallet_service_proto = out.File
+	file_cards_v1_wallet_service_proto_goTypes = nil
+	file_cards_v1_wallet_service_proto_depIdxs = nil
+}